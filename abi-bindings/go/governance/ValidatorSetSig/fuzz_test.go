@@ -0,0 +1,135 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validatorsetsig
+
+import (
+	"math/big"
+	"testing"
+
+	fuzzUtils "github.com/ava-labs/icm-contracts/utils/fuzz-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/stretchr/testify/require"
+)
+
+// validatorSetSigMessageCorpus holds abi.encode(ValidatorSetSigMessage) outputs of the Solidity
+// compiler. The same encodings are asserted in contracts/governance/tests/ValidatorSetSigTests.t.sol.
+var validatorSetSigMessageCorpus = []struct {
+	name     string
+	encoding string
+	message  ValidatorSetSigMessage
+}{
+	{
+		name: "empty payload",
+		encoding: "0000000000000000000000000000000000000000000000000000000000000020" +
+			"1234567812345678123456781234567812345678123456781234567812345678" +
+			"0000000000000000000000001111111111111111111111111111111111111111" +
+			"0000000000000000000000002222222222222222222222222222222222222222" +
+			"0000000000000000000000000000000000000000000000000000000000000003" +
+			"0000000000000000000000000000000000000000000000000000000000000001" +
+			"00000000000000000000000000000000000000000000000000000000000000c0" +
+			"0000000000000000000000000000000000000000000000000000000000000000",
+		message: ValidatorSetSigMessage{
+			TargetBlockchainID:     common.HexToHash("0x1234567812345678123456781234567812345678123456781234567812345678"),
+			ValidatorSetSigAddress: common.HexToAddress("0x1111111111111111111111111111111111111111"),
+			TargetContractAddress:  common.HexToAddress("0x2222222222222222222222222222222222222222"),
+			Nonce:                  big.NewInt(3),
+			Value:                  big.NewInt(1),
+			Payload:                []byte{},
+		},
+	},
+	{
+		name: "with value",
+		encoding: "0000000000000000000000000000000000000000000000000000000000000020" +
+			"abcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcd" +
+			"0000000000000000000000003333333333333333333333333333333333333333" +
+			"0000000000000000000000004444444444444444444444444444444444444444" +
+			"0000000000000000000000000000000000000000000000000000000000000005" +
+			"0000000000000000000000000000000000000000000000000de0b6b3a7640000" +
+			"00000000000000000000000000000000000000000000000000000000000000c0" +
+			"000000000000000000000000000000000000000000000000000000000000002c" +
+			"a9059cbb000102030405060708090a0b0c0d0e0f101112131415161718191a1b" +
+			"1c1d1e1f20212223242526270000000000000000000000000000000000000000",
+		message: ValidatorSetSigMessage{
+			TargetBlockchainID:     common.HexToHash("0xabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcd"),
+			ValidatorSetSigAddress: common.HexToAddress("0x3333333333333333333333333333333333333333"),
+			TargetContractAddress:  common.HexToAddress("0x4444444444444444444444444444444444444444"),
+			Nonce:                  big.NewInt(5),
+			Value:                  big.NewInt(1e18),
+			Payload: common.FromHex(
+				"0xa9059cbb000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f2021222324252627",
+			),
+		},
+	},
+}
+
+func TestValidatorSetSigMessageSolidityCorpus(t *testing.T) {
+	for _, test := range validatorSetSigMessageCorpus {
+		t.Run(test.name, func(t *testing.T) {
+			encoding := common.FromHex(test.encoding)
+
+			packed, err := test.message.Pack()
+			require.NoError(t, err)
+			require.Equal(t, encoding, packed)
+
+			var unpacked ValidatorSetSigMessage
+			require.NoError(t, unpacked.Unpack(encoding))
+			require.Equal(t, test.message, unpacked)
+		})
+	}
+}
+
+func TestValidatorSetSigMessageUnpackHugeLengthPrefix(t *testing.T) {
+	encoding := common.FromHex(validatorSetSigMessageCorpus[1].encoding)
+
+	// The payload length follows the six head words of the message tuple,
+	// which itself starts after the outer offset word.
+	const payloadLengthWord = 32 + 6*32
+	for _, length := range []*big.Int{
+		big.NewInt(1 << 32),
+		new(big.Int).SetUint64(1<<63 - 1),
+		math.MaxBig256,
+	} {
+		t.Run(length.String(), func(t *testing.T) {
+			malformed := common.CopyBytes(encoding)
+			copy(malformed[payloadLengthWord:payloadLengthWord+32], common.LeftPadBytes(length.Bytes(), 32))
+
+			var unpackErr error
+			allocated := fuzzUtils.AllocatedBytes(func() {
+				var message ValidatorSetSigMessage
+				unpackErr = message.Unpack(malformed)
+			})
+			require.Error(t, unpackErr)
+			require.Less(t, allocated, uint64(fuzzUtils.MaxUnpackAllocation))
+		})
+	}
+}
+
+func FuzzValidatorSetSigMessageUnpack(f *testing.F) {
+	for _, test := range validatorSetSigMessageCorpus {
+		f.Add(common.FromHex(test.encoding))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var message ValidatorSetSigMessage
+		var unpackErr error
+		allocated := fuzzUtils.AllocatedBytes(func() {
+			unpackErr = message.Unpack(data)
+		})
+		require.Less(t, allocated, uint64(fuzzUtils.MaxUnpackAllocation+64*len(data)))
+		if unpackErr != nil {
+			return
+		}
+
+		// Whatever the decoder accepts must re-encode to a canonical form
+		// that is stable under another round trip.
+		canonical, err := message.Pack()
+		require.NoError(t, err)
+		var reunpacked ValidatorSetSigMessage
+		require.NoError(t, reunpacked.Unpack(canonical))
+		repacked, err := reunpacked.Pack()
+		require.NoError(t, err)
+		require.Equal(t, canonical, repacked)
+	})
+}
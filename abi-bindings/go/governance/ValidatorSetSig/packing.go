@@ -4,9 +4,12 @@
 package validatorsetsig
 
 import (
+	"bytes"
 	"fmt"
+	"math/big"
 
 	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 )
 
@@ -54,11 +57,169 @@ func (m *ValidatorSetSigMessage) Unpack(messageBytes []byte) error {
 	return args.Copy(&m, unpacked)
 }
 
+func packMethod(method string, args ...interface{}) ([]byte, error) {
+	validatorSetSigABI, err := ValidatorSetSigMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abi")
+	}
+	return validatorSetSigABI.Pack(method, args...)
+}
+
+func packMethodOutput(method string, args ...interface{}) ([]byte, error) {
+	validatorSetSigABI, err := ValidatorSetSigMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abi")
+	}
+	return validatorSetSigABI.PackOutput(method, args...)
+}
+
+// unpackMethodInput checks that the calldata is a call to [method] and unpacks its arguments
+func unpackMethodInput(method string, input []byte) ([]interface{}, error) {
+	validatorSetSigABI, err := ValidatorSetSigMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abi")
+	}
+	m, ok := validatorSetSigABI.Methods[method]
+	if !ok {
+		return nil, fmt.Errorf("method %s not found in abi", method)
+	}
+	if len(input) < len(m.ID) || !bytes.Equal(input[:len(m.ID)], m.ID) {
+		return nil, fmt.Errorf("input is not a call to %s", method)
+	}
+	return m.Inputs.Unpack(input[len(m.ID):])
+}
+
+func unpackMethodResult(method string, result []byte) ([]interface{}, error) {
+	validatorSetSigABI, err := ValidatorSetSigMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abi")
+	}
+	return validatorSetSigABI.Unpack(method, result)
+}
+
 // PackExecuteCall packs the input to form a call to the executeCall function
 func PackExecuteCall(messageIndex uint32) ([]byte, error) {
-	abi, err := ValidatorSetSigMetaData.GetAbi()
+	return packMethod("executeCall", messageIndex)
+}
+
+// UnpackExecuteCallInput unpacks the calldata of a call to executeCall
+func UnpackExecuteCallInput(input []byte) (uint32, error) {
+	args, err := unpackMethodInput("executeCall", input)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get abi")
+		return 0, err
+	}
+	return *abi.ConvertType(args[0], new(uint32)).(*uint32), nil
+}
+
+// PackValidateMessage packs the input to form a call to the validateMessage function
+func PackValidateMessage(message ValidatorSetSigMessage) ([]byte, error) {
+	return packMethod("validateMessage", message)
+}
+
+// UnpackValidateMessageInput unpacks the calldata of a call to validateMessage
+func UnpackValidateMessageInput(input []byte) (ValidatorSetSigMessage, error) {
+	args, err := unpackMethodInput("validateMessage", input)
+	if err != nil {
+		return ValidatorSetSigMessage{}, err
+	}
+	return *abi.ConvertType(args[0], new(ValidatorSetSigMessage)).(*ValidatorSetSigMessage), nil
+}
+
+// PackNonces packs the input to form a call to the nonces getter
+func PackNonces(targetContractAddress common.Address) ([]byte, error) {
+	return packMethod("nonces", targetContractAddress)
+}
+
+// UnpackNoncesInput unpacks the calldata of a call to the nonces getter
+func UnpackNoncesInput(input []byte) (common.Address, error) {
+	args, err := unpackMethodInput("nonces", input)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return *abi.ConvertType(args[0], new(common.Address)).(*common.Address), nil
+}
+
+func PackNoncesOutput(nonce *big.Int) ([]byte, error) {
+	return packMethodOutput("nonces", nonce)
+}
+
+// UnpackNoncesResult unpacks the nonce returned by the nonces getter
+func UnpackNoncesResult(result []byte) (*big.Int, error) {
+	out, err := unpackMethodResult("nonces", result)
+	if err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
+}
+
+// PackBlockchainID forms a call to the blockchainID getter
+func PackBlockchainID() ([]byte, error) {
+	return packMethod("blockchainID")
+}
+
+func PackBlockchainIDOutput(blockchainID [32]byte) ([]byte, error) {
+	return packMethodOutput("blockchainID", blockchainID)
+}
+
+// UnpackBlockchainIDResult unpacks the blockchain ID returned by the blockchainID getter
+func UnpackBlockchainIDResult(result []byte) ([32]byte, error) {
+	return unpackBytes32Result("blockchainID", result)
+}
+
+// PackValidatorBlockchainID forms a call to the validatorBlockchainID getter
+func PackValidatorBlockchainID() ([]byte, error) {
+	return packMethod("validatorBlockchainID")
+}
+
+func PackValidatorBlockchainIDOutput(validatorBlockchainID [32]byte) ([]byte, error) {
+	return packMethodOutput("validatorBlockchainID", validatorBlockchainID)
+}
+
+// UnpackValidatorBlockchainIDResult unpacks the blockchain ID returned by the validatorBlockchainID getter
+func UnpackValidatorBlockchainIDResult(result []byte) ([32]byte, error) {
+	return unpackBytes32Result("validatorBlockchainID", result)
+}
+
+// PackValidatorsSourceAddress forms a call to the VALIDATORS_SOURCE_ADDRESS getter
+func PackValidatorsSourceAddress() ([]byte, error) {
+	return packMethod("VALIDATORS_SOURCE_ADDRESS")
+}
+
+func PackValidatorsSourceAddressOutput(sourceAddress common.Address) ([]byte, error) {
+	return packMethodOutput("VALIDATORS_SOURCE_ADDRESS", sourceAddress)
+}
+
+// UnpackValidatorsSourceAddressResult unpacks the address returned by the VALIDATORS_SOURCE_ADDRESS getter
+func UnpackValidatorsSourceAddressResult(result []byte) (common.Address, error) {
+	return unpackAddressResult("VALIDATORS_SOURCE_ADDRESS", result)
+}
+
+// PackWarpMessenger forms a call to the WARP_MESSENGER getter
+func PackWarpMessenger() ([]byte, error) {
+	return packMethod("WARP_MESSENGER")
+}
+
+func PackWarpMessengerOutput(warpMessenger common.Address) ([]byte, error) {
+	return packMethodOutput("WARP_MESSENGER", warpMessenger)
+}
+
+// UnpackWarpMessengerResult unpacks the address returned by the WARP_MESSENGER getter
+func UnpackWarpMessengerResult(result []byte) (common.Address, error) {
+	return unpackAddressResult("WARP_MESSENGER", result)
+}
+
+func unpackBytes32Result(method string, result []byte) ([32]byte, error) {
+	out, err := unpackMethodResult(method, result)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return *abi.ConvertType(out[0], new([32]byte)).(*[32]byte), nil
+}
+
+func unpackAddressResult(method string, result []byte) (common.Address, error) {
+	out, err := unpackMethodResult(method, result)
+	if err != nil {
+		return common.Address{}, err
 	}
-	return abi.Pack("executeCall", messageIndex)
+	return *abi.ConvertType(out[0], new(common.Address)).(*common.Address), nil
 }
@@ -0,0 +1,51 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validatorsetsig
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackUnpackMethods(t *testing.T) {
+	message := ValidatorSetSigMessage{
+		TargetBlockchainID:     [32]byte{1, 2, 3},
+		ValidatorSetSigAddress: common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567"),
+		TargetContractAddress:  common.HexToAddress("0x0123456789abcdef0123456789abcdef01234568"),
+		Nonce:                  big.NewInt(4),
+		Value:                  big.NewInt(5),
+		Payload:                []byte{6, 7, 8},
+	}
+
+	b, err := PackValidateMessage(message)
+	require.NoError(t, err)
+	unpackedMessage, err := UnpackValidateMessageInput(b)
+	require.NoError(t, err)
+	require.Equal(t, message, unpackedMessage)
+
+	b, err = PackExecuteCall(2)
+	require.NoError(t, err)
+	messageIndex, err := UnpackExecuteCallInput(b)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), messageIndex)
+
+	// Calldata for a different method must be rejected.
+	_, err = UnpackValidateMessageInput(b)
+	require.Error(t, err)
+
+	b, err = PackNonces(message.TargetContractAddress)
+	require.NoError(t, err)
+	targetAddress, err := UnpackNoncesInput(b)
+	require.NoError(t, err)
+	require.Equal(t, message.TargetContractAddress, targetAddress)
+
+	b, err = PackNoncesOutput(big.NewInt(9))
+	require.NoError(t, err)
+	nonce, err := UnpackNoncesResult(b)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(9), nonce)
+}
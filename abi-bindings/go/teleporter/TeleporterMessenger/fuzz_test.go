@@ -0,0 +1,219 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleportermessenger
+
+import (
+	"math/big"
+	"testing"
+
+	fuzzUtils "github.com/ava-labs/icm-contracts/utils/fuzz-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/stretchr/testify/require"
+)
+
+// teleporterMessageCorpus holds abi.encode(TeleporterMessage) outputs of the Solidity compiler.
+// The same encodings are asserted in contracts/teleporter/tests/EncodingCorpusTests.t.sol,
+// so a divergence between the Go packer and the on-chain encoding fails on one side.
+var teleporterMessageCorpus = []struct {
+	name     string
+	encoding string
+	message  TeleporterMessage
+}{
+	{
+		name: "empty message",
+		encoding: "0000000000000000000000000000000000000000000000000000000000000020" +
+			"0000000000000000000000000000000000000000000000000000000000000001" +
+			"0000000000000000000000001111111111111111111111111111111111111111" +
+			"1234567812345678123456781234567812345678123456781234567812345678" +
+			"0000000000000000000000002222222222222222222222222222222222222222" +
+			"00000000000000000000000000000000000000000000000000000000000186a0" +
+			"0000000000000000000000000000000000000000000000000000000000000100" +
+			"0000000000000000000000000000000000000000000000000000000000000120" +
+			"0000000000000000000000000000000000000000000000000000000000000140" +
+			"0000000000000000000000000000000000000000000000000000000000000000" +
+			"0000000000000000000000000000000000000000000000000000000000000000" +
+			"0000000000000000000000000000000000000000000000000000000000000000",
+		message: TeleporterMessage{
+			MessageNonce:            big.NewInt(1),
+			OriginSenderAddress:     common.HexToAddress("0x1111111111111111111111111111111111111111"),
+			DestinationBlockchainID: common.HexToHash("0x1234567812345678123456781234567812345678123456781234567812345678"),
+			DestinationAddress:      common.HexToAddress("0x2222222222222222222222222222222222222222"),
+			RequiredGasLimit:        big.NewInt(100_000),
+			AllowedRelayerAddresses: []common.Address{},
+			Receipts:                []TeleporterMessageReceipt{},
+			Message:                 []byte{},
+		},
+	},
+	{
+		name: "receipts and relayers",
+		encoding: "0000000000000000000000000000000000000000000000000000000000000020" +
+			"000000000000000000000000000000000000000000000000000000000000002a" +
+			"0000000000000000000000003333333333333333333333333333333333333333" +
+			"abcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcd" +
+			"0000000000000000000000004444444444444444444444444444444444444444" +
+			"00000000000000000000000000000000000000000000000000000000000493e0" +
+			"0000000000000000000000000000000000000000000000000000000000000100" +
+			"0000000000000000000000000000000000000000000000000000000000000160" +
+			"0000000000000000000000000000000000000000000000000000000000000200" +
+			"0000000000000000000000000000000000000000000000000000000000000002" +
+			"0000000000000000000000005555555555555555555555555555555555555555" +
+			"0000000000000000000000006666666666666666666666666666666666666666" +
+			"0000000000000000000000000000000000000000000000000000000000000002" +
+			"0000000000000000000000000000000000000000000000000000000000000007" +
+			"0000000000000000000000007777777777777777777777777777777777777777" +
+			"0000000000000000000000000000000000000000000000000000000000000008" +
+			"0000000000000000000000008888888888888888888888888888888888888888" +
+			"0000000000000000000000000000000000000000000000000000000000000004" +
+			"deadbeef00000000000000000000000000000000000000000000000000000000",
+		message: TeleporterMessage{
+			MessageNonce:            big.NewInt(42),
+			OriginSenderAddress:     common.HexToAddress("0x3333333333333333333333333333333333333333"),
+			DestinationBlockchainID: common.HexToHash("0xabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcd"),
+			DestinationAddress:      common.HexToAddress("0x4444444444444444444444444444444444444444"),
+			RequiredGasLimit:        big.NewInt(300_000),
+			AllowedRelayerAddresses: []common.Address{
+				common.HexToAddress("0x5555555555555555555555555555555555555555"),
+				common.HexToAddress("0x6666666666666666666666666666666666666666"),
+			},
+			Receipts: []TeleporterMessageReceipt{
+				{
+					ReceivedMessageNonce: big.NewInt(7),
+					RelayerRewardAddress: common.HexToAddress("0x7777777777777777777777777777777777777777"),
+				},
+				{
+					ReceivedMessageNonce: big.NewInt(8),
+					RelayerRewardAddress: common.HexToAddress("0x8888888888888888888888888888888888888888"),
+				},
+			},
+			Message: common.FromHex("0xdeadbeef"),
+		},
+	},
+	{
+		name: "max values",
+		encoding: "0000000000000000000000000000000000000000000000000000000000000020" +
+			"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff" +
+			"0000000000000000000000009999999999999999999999999999999999999999" +
+			"1234567812345678123456781234567812345678123456781234567812345678" +
+			"000000000000000000000000aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa" +
+			"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff" +
+			"0000000000000000000000000000000000000000000000000000000000000100" +
+			"0000000000000000000000000000000000000000000000000000000000000140" +
+			"00000000000000000000000000000000000000000000000000000000000001a0" +
+			"0000000000000000000000000000000000000000000000000000000000000001" +
+			"000000000000000000000000bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb" +
+			"0000000000000000000000000000000000000000000000000000000000000001" +
+			"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff" +
+			"000000000000000000000000cccccccccccccccccccccccccccccccccccccccc" +
+			"0000000000000000000000000000000000000000000000000000000000000021" +
+			"0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20" +
+			"2100000000000000000000000000000000000000000000000000000000000000",
+		message: TeleporterMessage{
+			MessageNonce:            math.MaxBig256,
+			OriginSenderAddress:     common.HexToAddress("0x9999999999999999999999999999999999999999"),
+			DestinationBlockchainID: common.HexToHash("0x1234567812345678123456781234567812345678123456781234567812345678"),
+			DestinationAddress:      common.HexToAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"),
+			RequiredGasLimit:        math.MaxBig256,
+			AllowedRelayerAddresses: []common.Address{
+				common.HexToAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"),
+			},
+			Receipts: []TeleporterMessageReceipt{
+				{
+					ReceivedMessageNonce: math.MaxBig256,
+					RelayerRewardAddress: common.HexToAddress("0xcccccccccccccccccccccccccccccccccccccccc"),
+				},
+			},
+			Message: common.FromHex(
+				"0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f2021",
+			),
+		},
+	},
+}
+
+func TestTeleporterMessageSolidityCorpus(t *testing.T) {
+	for _, test := range teleporterMessageCorpus {
+		t.Run(test.name, func(t *testing.T) {
+			encoding := common.FromHex(test.encoding)
+
+			packed, err := test.message.Pack()
+			require.NoError(t, err)
+			require.Equal(t, encoding, packed)
+
+			var unpacked TeleporterMessage
+			require.NoError(t, unpacked.Unpack(encoding))
+			require.Equal(t, test.message, unpacked)
+		})
+	}
+}
+
+func TestTeleporterMessageUnpackHugeLengthPrefix(t *testing.T) {
+	encoding := common.FromHex(teleporterMessageCorpus[1].encoding)
+
+	// The head of the message tuple starts after the outer offset word. The last three
+	// words of the head are the offsets of the dynamic fields, relative to the tuple.
+	const tupleStart = 32
+	tests := []struct {
+		name       string
+		fieldIndex int
+	}{
+		{name: "allowed relayer addresses", fieldIndex: 5},
+		{name: "receipts", fieldIndex: 6},
+		{name: "message", fieldIndex: 7},
+	}
+	lengths := []*big.Int{
+		big.NewInt(1 << 32),
+		new(big.Int).SetUint64(1<<63 - 1),
+		math.MaxBig256,
+	}
+	for _, test := range tests {
+		for _, length := range lengths {
+			t.Run(test.name+"/"+length.String(), func(t *testing.T) {
+				malformed := common.CopyBytes(encoding)
+				offsetWord := tupleStart + 32*test.fieldIndex
+				offset := new(big.Int).SetBytes(malformed[offsetWord : offsetWord+32]).Uint64()
+				lengthWord := tupleStart + offset
+				copy(malformed[lengthWord:lengthWord+32], common.LeftPadBytes(length.Bytes(), 32))
+
+				var unpackErr error
+				allocated := fuzzUtils.AllocatedBytes(func() {
+					var message TeleporterMessage
+					unpackErr = message.Unpack(malformed)
+				})
+				require.Error(t, unpackErr)
+				require.Less(t, allocated, uint64(fuzzUtils.MaxUnpackAllocation))
+			})
+		}
+	}
+}
+
+func FuzzTeleporterMessageUnpack(f *testing.F) {
+	for _, test := range teleporterMessageCorpus {
+		f.Add(common.FromHex(test.encoding))
+	}
+	packed, err := createTestTeleporterMessage(big.NewInt(1)).Pack()
+	require.NoError(f, err)
+	f.Add(packed)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var message TeleporterMessage
+		var unpackErr error
+		allocated := fuzzUtils.AllocatedBytes(func() {
+			unpackErr = message.Unpack(data)
+		})
+		require.Less(t, allocated, uint64(fuzzUtils.MaxUnpackAllocation+64*len(data)))
+		if unpackErr != nil {
+			return
+		}
+
+		// The decoder is lenient about padding and offsets, but whatever it accepts must
+		// re-encode to a canonical form that is stable under another round trip.
+		canonical, err := message.Pack()
+		require.NoError(t, err)
+		var reunpacked TeleporterMessage
+		require.NoError(t, reunpacked.Unpack(canonical))
+		repacked, err := reunpacked.Pack()
+		require.NoError(t, err)
+		require.Equal(t, canonical, repacked)
+	})
+}
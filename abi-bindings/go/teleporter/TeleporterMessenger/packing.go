@@ -4,6 +4,7 @@
 package teleportermessenger
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 
@@ -62,86 +63,713 @@ func (m *TeleporterMessage) Unpack(b []byte) error {
 	return args.Copy(&m, unpacked)
 }
 
-func PackSendCrossChainMessage(input TeleporterMessageInput) ([]byte, error) {
-	abi, err := TeleporterMessengerMetaData.GetAbi()
+// ReceiveCrossChainMessageInput is the decoded input of a call to receiveCrossChainMessage
+type ReceiveCrossChainMessageInput struct {
+	MessageIndex         uint32
+	RelayerRewardAddress common.Address
+}
+
+// RetryMessageExecutionInput is the decoded input of a call to retryMessageExecution
+type RetryMessageExecutionInput struct {
+	SourceBlockchainID [32]byte
+	Message            TeleporterMessage
+}
+
+// AddFeeAmountInput is the decoded input of a call to addFeeAmount
+type AddFeeAmountInput struct {
+	MessageID           [32]byte
+	FeeTokenAddress     common.Address
+	AdditionalFeeAmount *big.Int
+}
+
+// SendSpecifiedReceiptsInput is the decoded input of a call to sendSpecifiedReceipts
+type SendSpecifiedReceiptsInput struct {
+	SourceBlockchainID      [32]byte
+	MessageIDs              [][32]byte
+	FeeInfo                 TeleporterFeeInfo
+	AllowedRelayerAddresses []common.Address
+}
+
+// CalculateMessageIDInput is the decoded input of a call to calculateMessageID
+type CalculateMessageIDInput struct {
+	SourceBlockchainID      [32]byte
+	DestinationBlockchainID [32]byte
+	Nonce                   *big.Int
+}
+
+// CheckRelayerRewardAmountInput is the decoded input of a call to checkRelayerRewardAmount
+type CheckRelayerRewardAmountInput struct {
+	Relayer  common.Address
+	FeeAsset common.Address
+}
+
+// GetReceiptAtIndexInput is the decoded input of a call to getReceiptAtIndex
+type GetReceiptAtIndexInput struct {
+	SourceBlockchainID [32]byte
+	Index              *big.Int
+}
+
+// ReceiptQueueBounds is the result of a call to receiptQueues
+type ReceiptQueueBounds struct {
+	First *big.Int
+	Last  *big.Int
+}
+
+// SentMessageInfo is the result of a call to sentMessageInfo
+type SentMessageInfo struct {
+	MessageHash [32]byte
+	FeeInfo     TeleporterFeeInfo
+}
+
+func packMethod(method string, args ...interface{}) ([]byte, error) {
+	teleporterABI, err := TeleporterMessengerMetaData.GetAbi()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get abi")
 	}
+	return teleporterABI.Pack(method, args...)
+}
 
-	return abi.Pack("sendCrossChainMessage", input)
+func packMethodOutput(method string, args ...interface{}) ([]byte, error) {
+	teleporterABI, err := TeleporterMessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abi")
+	}
+	return teleporterABI.PackOutput(method, args...)
 }
 
-func PackRetryMessageExecution(sourceBlockchainID ids.ID, message TeleporterMessage) ([]byte, error) {
-	abi, err := TeleporterMessengerMetaData.GetAbi()
+// unpackMethodInput checks that the calldata is a call to [method] and unpacks its arguments
+func unpackMethodInput(method string, input []byte) ([]interface{}, error) {
+	teleporterABI, err := TeleporterMessengerMetaData.GetAbi()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get abi")
 	}
+	m, ok := teleporterABI.Methods[method]
+	if !ok {
+		return nil, fmt.Errorf("method %s not found in abi", method)
+	}
+	if len(input) < len(m.ID) || !bytes.Equal(input[:len(m.ID)], m.ID) {
+		return nil, fmt.Errorf("input is not a call to %s", method)
+	}
+	return m.Inputs.Unpack(input[len(m.ID):])
+}
 
-	return abi.Pack("retryMessageExecution", sourceBlockchainID, message)
+func unpackMethodResult(method string, result []byte) ([]interface{}, error) {
+	teleporterABI, err := TeleporterMessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abi")
+	}
+	return teleporterABI.Unpack(method, result)
+}
+
+//
+// sendCrossChainMessage
+//
+
+func PackSendCrossChainMessage(input TeleporterMessageInput) ([]byte, error) {
+	return packMethod("sendCrossChainMessage", input)
+}
+
+// UnpackSendCrossChainMessageInput unpacks the calldata of a call to sendCrossChainMessage
+func UnpackSendCrossChainMessageInput(input []byte) (TeleporterMessageInput, error) {
+	args, err := unpackMethodInput("sendCrossChainMessage", input)
+	if err != nil {
+		return TeleporterMessageInput{}, err
+	}
+	return *abi.ConvertType(args[0], new(TeleporterMessageInput)).(*TeleporterMessageInput), nil
+}
+
+func PackSendCrossChainMessageOutput(messageID [32]byte) ([]byte, error) {
+	return packMethodOutput("sendCrossChainMessage", messageID)
 }
 
+// UnpackSendCrossChainMessageResult unpacks the message ID returned by sendCrossChainMessage
+func UnpackSendCrossChainMessageResult(result []byte) ([32]byte, error) {
+	return unpackBytes32Result("sendCrossChainMessage", result)
+}
+
+//
+// retrySendCrossChainMessage
+//
+
+// PackRetrySendCrossChainMessage packs input to form a call to the retrySendCrossChainMessage function
+func PackRetrySendCrossChainMessage(message TeleporterMessage) ([]byte, error) {
+	return packMethod("retrySendCrossChainMessage", message)
+}
+
+// UnpackRetrySendCrossChainMessageInput unpacks the calldata of a call to retrySendCrossChainMessage
+func UnpackRetrySendCrossChainMessageInput(input []byte) (TeleporterMessage, error) {
+	args, err := unpackMethodInput("retrySendCrossChainMessage", input)
+	if err != nil {
+		return TeleporterMessage{}, err
+	}
+	return *abi.ConvertType(args[0], new(TeleporterMessage)).(*TeleporterMessage), nil
+}
+
+//
+// receiveCrossChainMessage
+//
+
 // PackReceiveCrossChainMessage packs a ReceiveCrossChainMessageInput to form
 // a call to the receiveCrossChainMessage function
 func PackReceiveCrossChainMessage(messageIndex uint32, relayerRewardAddress common.Address) ([]byte, error) {
-	abi, err := TeleporterMessengerMetaData.GetAbi()
+	return packMethod("receiveCrossChainMessage", messageIndex, relayerRewardAddress)
+}
+
+// UnpackReceiveCrossChainMessageInput unpacks the calldata of a call to receiveCrossChainMessage
+func UnpackReceiveCrossChainMessageInput(input []byte) (ReceiveCrossChainMessageInput, error) {
+	args, err := unpackMethodInput("receiveCrossChainMessage", input)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get abi")
+		return ReceiveCrossChainMessageInput{}, err
+	}
+	return ReceiveCrossChainMessageInput{
+		MessageIndex:         *abi.ConvertType(args[0], new(uint32)).(*uint32),
+		RelayerRewardAddress: *abi.ConvertType(args[1], new(common.Address)).(*common.Address),
+	}, nil
+}
+
+//
+// retryMessageExecution
+//
+
+func PackRetryMessageExecution(sourceBlockchainID ids.ID, message TeleporterMessage) ([]byte, error) {
+	return packMethod("retryMessageExecution", sourceBlockchainID, message)
+}
+
+// UnpackRetryMessageExecutionInput unpacks the calldata of a call to retryMessageExecution
+func UnpackRetryMessageExecutionInput(input []byte) (RetryMessageExecutionInput, error) {
+	args, err := unpackMethodInput("retryMessageExecution", input)
+	if err != nil {
+		return RetryMessageExecutionInput{}, err
+	}
+	return RetryMessageExecutionInput{
+		SourceBlockchainID: *abi.ConvertType(args[0], new([32]byte)).(*[32]byte),
+		Message:            *abi.ConvertType(args[1], new(TeleporterMessage)).(*TeleporterMessage),
+	}, nil
+}
+
+//
+// addFeeAmount
+//
+
+// PackAddFeeAmount packs input to form a call to the addFeeAmount function
+func PackAddFeeAmount(
+	messageID [32]byte,
+	feeTokenAddress common.Address,
+	additionalFeeAmount *big.Int,
+) ([]byte, error) {
+	return packMethod("addFeeAmount", messageID, feeTokenAddress, additionalFeeAmount)
+}
+
+// UnpackAddFeeAmountInput unpacks the calldata of a call to addFeeAmount
+func UnpackAddFeeAmountInput(input []byte) (AddFeeAmountInput, error) {
+	args, err := unpackMethodInput("addFeeAmount", input)
+	if err != nil {
+		return AddFeeAmountInput{}, err
+	}
+	return AddFeeAmountInput{
+		MessageID:           *abi.ConvertType(args[0], new([32]byte)).(*[32]byte),
+		FeeTokenAddress:     *abi.ConvertType(args[1], new(common.Address)).(*common.Address),
+		AdditionalFeeAmount: *abi.ConvertType(args[2], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+//
+// sendSpecifiedReceipts
+//
+
+// PackSendSpecifiedReceipts packs input to form a call to the sendSpecifiedReceipts function
+func PackSendSpecifiedReceipts(
+	sourceBlockchainID [32]byte,
+	messageIDs [][32]byte,
+	feeInfo TeleporterFeeInfo,
+	allowedRelayerAddresses []common.Address,
+) ([]byte, error) {
+	return packMethod("sendSpecifiedReceipts", sourceBlockchainID, messageIDs, feeInfo, allowedRelayerAddresses)
+}
+
+// UnpackSendSpecifiedReceiptsInput unpacks the calldata of a call to sendSpecifiedReceipts
+func UnpackSendSpecifiedReceiptsInput(input []byte) (SendSpecifiedReceiptsInput, error) {
+	args, err := unpackMethodInput("sendSpecifiedReceipts", input)
+	if err != nil {
+		return SendSpecifiedReceiptsInput{}, err
 	}
+	return SendSpecifiedReceiptsInput{
+		SourceBlockchainID:      *abi.ConvertType(args[0], new([32]byte)).(*[32]byte),
+		MessageIDs:              *abi.ConvertType(args[1], new([][32]byte)).(*[][32]byte),
+		FeeInfo:                 *abi.ConvertType(args[2], new(TeleporterFeeInfo)).(*TeleporterFeeInfo),
+		AllowedRelayerAddresses: *abi.ConvertType(args[3], new([]common.Address)).(*[]common.Address),
+	}, nil
+}
+
+func PackSendSpecifiedReceiptsOutput(messageID [32]byte) ([]byte, error) {
+	return packMethodOutput("sendSpecifiedReceipts", messageID)
+}
+
+// UnpackSendSpecifiedReceiptsResult unpacks the message ID returned by sendSpecifiedReceipts
+func UnpackSendSpecifiedReceiptsResult(result []byte) ([32]byte, error) {
+	return unpackBytes32Result("sendSpecifiedReceipts", result)
+}
+
+//
+// redeemRelayerRewards
+//
+
+// PackRedeemRelayerRewards packs input to form a call to the redeemRelayerRewards function
+func PackRedeemRelayerRewards(feeAsset common.Address) ([]byte, error) {
+	return packMethod("redeemRelayerRewards", feeAsset)
+}
+
+// UnpackRedeemRelayerRewardsInput unpacks the calldata of a call to redeemRelayerRewards
+func UnpackRedeemRelayerRewardsInput(input []byte) (common.Address, error) {
+	return unpackAddressInput("redeemRelayerRewards", input)
+}
+
+//
+// initializeBlockchainID
+//
+
+// PackInitializeBlockchainID forms a call to the initializeBlockchainID function
+func PackInitializeBlockchainID() ([]byte, error) {
+	return packMethod("initializeBlockchainID")
+}
 
-	return abi.Pack("receiveCrossChainMessage", messageIndex, relayerRewardAddress)
+func PackInitializeBlockchainIDOutput(blockchainID [32]byte) ([]byte, error) {
+	return packMethodOutput("initializeBlockchainID", blockchainID)
 }
 
+// UnpackInitializeBlockchainIDResult unpacks the blockchain ID returned by initializeBlockchainID
+func UnpackInitializeBlockchainIDResult(result []byte) ([32]byte, error) {
+	return unpackBytes32Result("initializeBlockchainID", result)
+}
+
+//
+// WARP_MESSENGER
+//
+
+// PackWarpMessenger forms a call to the WARP_MESSENGER getter
+func PackWarpMessenger() ([]byte, error) {
+	return packMethod("WARP_MESSENGER")
+}
+
+func PackWarpMessengerOutput(warpMessenger common.Address) ([]byte, error) {
+	return packMethodOutput("WARP_MESSENGER", warpMessenger)
+}
+
+// UnpackWarpMessengerResult unpacks the address returned by the WARP_MESSENGER getter
+func UnpackWarpMessengerResult(result []byte) (common.Address, error) {
+	return unpackAddressResult("WARP_MESSENGER", result)
+}
+
+//
+// blockchainID
+//
+
+// PackBlockchainID forms a call to the blockchainID getter
+func PackBlockchainID() ([]byte, error) {
+	return packMethod("blockchainID")
+}
+
+func PackBlockchainIDOutput(blockchainID [32]byte) ([]byte, error) {
+	return packMethodOutput("blockchainID", blockchainID)
+}
+
+// UnpackBlockchainIDResult unpacks the blockchain ID returned by the blockchainID getter
+func UnpackBlockchainIDResult(result []byte) ([32]byte, error) {
+	return unpackBytes32Result("blockchainID", result)
+}
+
+//
+// messageNonce
+//
+
+// PackMessageNonce forms a call to the messageNonce getter
+func PackMessageNonce() ([]byte, error) {
+	return packMethod("messageNonce")
+}
+
+func PackMessageNonceOutput(messageNonce *big.Int) ([]byte, error) {
+	return packMethodOutput("messageNonce", messageNonce)
+}
+
+// UnpackMessageNonceResult unpacks the nonce returned by the messageNonce getter
+func UnpackMessageNonceResult(result []byte) (*big.Int, error) {
+	return unpackUint256Result("messageNonce", result)
+}
+
+//
+// calculateMessageID
+//
+
 // PackCalculateMessageID packs input to form a call to the calculateMessageID function
 func PackCalculateMessageID(
 	sourceBlockchainID [32]byte,
 	destinationBlockchainID [32]byte,
 	nonce *big.Int,
 ) ([]byte, error) {
-	abi, err := TeleporterMessengerMetaData.GetAbi()
+	return packMethod("calculateMessageID", sourceBlockchainID, destinationBlockchainID, nonce)
+}
+
+// UnpackCalculateMessageIDInput unpacks the calldata of a call to calculateMessageID
+func UnpackCalculateMessageIDInput(input []byte) (CalculateMessageIDInput, error) {
+	args, err := unpackMethodInput("calculateMessageID", input)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get abi")
+		return CalculateMessageIDInput{}, err
 	}
-
-	return abi.Pack("calculateMessageID", sourceBlockchainID, destinationBlockchainID, nonce)
+	return CalculateMessageIDInput{
+		SourceBlockchainID:      *abi.ConvertType(args[0], new([32]byte)).(*[32]byte),
+		DestinationBlockchainID: *abi.ConvertType(args[1], new([32]byte)).(*[32]byte),
+		Nonce:                   *abi.ConvertType(args[2], new(*big.Int)).(**big.Int),
+	}, nil
 }
 
 func PackCalculateMessageIDOutput(messageID [32]byte) ([]byte, error) {
-	abi, err := TeleporterMessengerMetaData.GetAbi()
+	return packMethodOutput("calculateMessageID", messageID)
+}
+
+// UnpackCalculateMessageIDResult unpacks the message ID returned by calculateMessageID
+func UnpackCalculateMessageIDResult(result []byte) ([32]byte, error) {
+	return unpackBytes32Result("calculateMessageID", result)
+}
+
+//
+// checkRelayerRewardAmount
+//
+
+// PackCheckRelayerRewardAmount packs input to form a call to the checkRelayerRewardAmount function
+func PackCheckRelayerRewardAmount(relayer common.Address, feeAsset common.Address) ([]byte, error) {
+	return packMethod("checkRelayerRewardAmount", relayer, feeAsset)
+}
+
+// UnpackCheckRelayerRewardAmountInput unpacks the calldata of a call to checkRelayerRewardAmount
+func UnpackCheckRelayerRewardAmountInput(input []byte) (CheckRelayerRewardAmountInput, error) {
+	args, err := unpackMethodInput("checkRelayerRewardAmount", input)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get abi")
+		return CheckRelayerRewardAmountInput{}, err
 	}
+	return CheckRelayerRewardAmountInput{
+		Relayer:  *abi.ConvertType(args[0], new(common.Address)).(*common.Address),
+		FeeAsset: *abi.ConvertType(args[1], new(common.Address)).(*common.Address),
+	}, nil
+}
 
-	return abi.PackOutput("calculateMessageID", messageID)
+func PackCheckRelayerRewardAmountOutput(amount *big.Int) ([]byte, error) {
+	return packMethodOutput("checkRelayerRewardAmount", amount)
 }
 
-// PackMessageReceived packs a MessageReceivedInput to form a call to the messageReceived function
-func PackMessageReceived(messageID [32]byte) ([]byte, error) {
-	abi, err := TeleporterMessengerMetaData.GetAbi()
+// UnpackCheckRelayerRewardAmountResult unpacks the amount returned by checkRelayerRewardAmount
+func UnpackCheckRelayerRewardAmountResult(result []byte) (*big.Int, error) {
+	return unpackUint256Result("checkRelayerRewardAmount", result)
+}
+
+//
+// getFeeInfo
+//
+
+// PackGetFeeInfo packs input to form a call to the getFeeInfo function
+func PackGetFeeInfo(messageID [32]byte) ([]byte, error) {
+	return packMethod("getFeeInfo", messageID)
+}
+
+// UnpackGetFeeInfoInput unpacks the calldata of a call to getFeeInfo
+func UnpackGetFeeInfoInput(input []byte) ([32]byte, error) {
+	return unpackBytes32Input("getFeeInfo", input)
+}
+
+func PackGetFeeInfoOutput(feeInfo TeleporterFeeInfo) ([]byte, error) {
+	return packMethodOutput("getFeeInfo", feeInfo.FeeTokenAddress, feeInfo.Amount)
+}
+
+// UnpackGetFeeInfoResult unpacks the fee token address and amount returned by getFeeInfo
+func UnpackGetFeeInfoResult(result []byte) (TeleporterFeeInfo, error) {
+	out, err := unpackMethodResult("getFeeInfo", result)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get abi")
+		return TeleporterFeeInfo{}, err
 	}
-	return abi.Pack("messageReceived", messageID)
+	return TeleporterFeeInfo{
+		FeeTokenAddress: *abi.ConvertType(out[0], new(common.Address)).(*common.Address),
+		Amount:          *abi.ConvertType(out[1], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+//
+// getMessageHash
+//
+
+// PackGetMessageHash packs input to form a call to the getMessageHash function
+func PackGetMessageHash(messageID [32]byte) ([]byte, error) {
+	return packMethod("getMessageHash", messageID)
+}
+
+// UnpackGetMessageHashInput unpacks the calldata of a call to getMessageHash
+func UnpackGetMessageHashInput(input []byte) ([32]byte, error) {
+	return unpackBytes32Input("getMessageHash", input)
+}
+
+func PackGetMessageHashOutput(messageHash [32]byte) ([]byte, error) {
+	return packMethodOutput("getMessageHash", messageHash)
+}
+
+// UnpackGetMessageHashResult unpacks the message hash returned by getMessageHash
+func UnpackGetMessageHashResult(result []byte) ([32]byte, error) {
+	return unpackBytes32Result("getMessageHash", result)
+}
+
+//
+// getNextMessageID
+//
+
+// PackGetNextMessageID packs input to form a call to the getNextMessageID function
+func PackGetNextMessageID(destinationBlockchainID [32]byte) ([]byte, error) {
+	return packMethod("getNextMessageID", destinationBlockchainID)
+}
+
+// UnpackGetNextMessageIDInput unpacks the calldata of a call to getNextMessageID
+func UnpackGetNextMessageIDInput(input []byte) ([32]byte, error) {
+	return unpackBytes32Input("getNextMessageID", input)
+}
+
+func PackGetNextMessageIDOutput(messageID [32]byte) ([]byte, error) {
+	return packMethodOutput("getNextMessageID", messageID)
+}
+
+// UnpackGetNextMessageIDResult unpacks the message ID returned by getNextMessageID
+func UnpackGetNextMessageIDResult(result []byte) ([32]byte, error) {
+	return unpackBytes32Result("getNextMessageID", result)
+}
+
+//
+// getReceiptAtIndex
+//
+
+// PackGetReceiptAtIndex packs input to form a call to the getReceiptAtIndex function
+func PackGetReceiptAtIndex(sourceBlockchainID [32]byte, index *big.Int) ([]byte, error) {
+	return packMethod("getReceiptAtIndex", sourceBlockchainID, index)
+}
+
+// UnpackGetReceiptAtIndexInput unpacks the calldata of a call to getReceiptAtIndex
+func UnpackGetReceiptAtIndexInput(input []byte) (GetReceiptAtIndexInput, error) {
+	args, err := unpackMethodInput("getReceiptAtIndex", input)
+	if err != nil {
+		return GetReceiptAtIndexInput{}, err
+	}
+	return GetReceiptAtIndexInput{
+		SourceBlockchainID: *abi.ConvertType(args[0], new([32]byte)).(*[32]byte),
+		Index:              *abi.ConvertType(args[1], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+func PackGetReceiptAtIndexOutput(receipt TeleporterMessageReceipt) ([]byte, error) {
+	return packMethodOutput("getReceiptAtIndex", receipt)
+}
+
+// UnpackGetReceiptAtIndexResult unpacks the receipt returned by getReceiptAtIndex
+func UnpackGetReceiptAtIndexResult(result []byte) (TeleporterMessageReceipt, error) {
+	out, err := unpackMethodResult("getReceiptAtIndex", result)
+	if err != nil {
+		return TeleporterMessageReceipt{}, err
+	}
+	return *abi.ConvertType(out[0], new(TeleporterMessageReceipt)).(*TeleporterMessageReceipt), nil
+}
+
+//
+// getReceiptQueueSize
+//
+
+// PackGetReceiptQueueSize packs input to form a call to the getReceiptQueueSize function
+func PackGetReceiptQueueSize(sourceBlockchainID [32]byte) ([]byte, error) {
+	return packMethod("getReceiptQueueSize", sourceBlockchainID)
+}
+
+// UnpackGetReceiptQueueSizeInput unpacks the calldata of a call to getReceiptQueueSize
+func UnpackGetReceiptQueueSizeInput(input []byte) ([32]byte, error) {
+	return unpackBytes32Input("getReceiptQueueSize", input)
+}
+
+func PackGetReceiptQueueSizeOutput(size *big.Int) ([]byte, error) {
+	return packMethodOutput("getReceiptQueueSize", size)
+}
+
+// UnpackGetReceiptQueueSizeResult unpacks the size returned by getReceiptQueueSize
+func UnpackGetReceiptQueueSizeResult(result []byte) (*big.Int, error) {
+	return unpackUint256Result("getReceiptQueueSize", result)
+}
+
+//
+// getRelayerRewardAddress
+//
+
+// PackGetRelayerRewardAddress packs input to form a call to the getRelayerRewardAddress function
+func PackGetRelayerRewardAddress(messageID [32]byte) ([]byte, error) {
+	return packMethod("getRelayerRewardAddress", messageID)
+}
+
+// UnpackGetRelayerRewardAddressInput unpacks the calldata of a call to getRelayerRewardAddress
+func UnpackGetRelayerRewardAddressInput(input []byte) ([32]byte, error) {
+	return unpackBytes32Input("getRelayerRewardAddress", input)
+}
+
+func PackGetRelayerRewardAddressOutput(relayerRewardAddress common.Address) ([]byte, error) {
+	return packMethodOutput("getRelayerRewardAddress", relayerRewardAddress)
+}
+
+// UnpackGetRelayerRewardAddressResult unpacks the address returned by getRelayerRewardAddress
+func UnpackGetRelayerRewardAddressResult(result []byte) (common.Address, error) {
+	return unpackAddressResult("getRelayerRewardAddress", result)
+}
+
+//
+// messageReceived
+//
+
+// PackMessageReceived packs a MessageReceivedInput to form a call to the messageReceived function
+func PackMessageReceived(messageID [32]byte) ([]byte, error) {
+	return packMethod("messageReceived", messageID)
+}
+
+// UnpackMessageReceivedInput unpacks the calldata of a call to messageReceived
+func UnpackMessageReceivedInput(input []byte) ([32]byte, error) {
+	return unpackBytes32Input("messageReceived", input)
 }
 
 // UnpackMessageReceivedResult attempts to unpack result bytes to a bool indicating whether the message was received
 func UnpackMessageReceivedResult(result []byte) (bool, error) {
-	abi, err := TeleporterMessengerMetaData.GetAbi()
+	out, err := unpackMethodResult("messageReceived", result)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to get abi")
+		return false, err
 	}
-
-	var success bool
-	err = abi.UnpackIntoInterface(&success, "messageReceived", result)
-	return success, err
+	return *abi.ConvertType(out[0], new(bool)).(*bool), nil
 }
 
 func PackMessageReceivedOutput(success bool) ([]byte, error) {
-	abi, err := TeleporterMessengerMetaData.GetAbi()
+	return packMethodOutput("messageReceived", success)
+}
+
+//
+// receiptQueues
+//
+
+// PackReceiptQueues packs input to form a call to the receiptQueues getter
+func PackReceiptQueues(sourceBlockchainID [32]byte) ([]byte, error) {
+	return packMethod("receiptQueues", sourceBlockchainID)
+}
+
+// UnpackReceiptQueuesInput unpacks the calldata of a call to the receiptQueues getter
+func UnpackReceiptQueuesInput(input []byte) ([32]byte, error) {
+	return unpackBytes32Input("receiptQueues", input)
+}
+
+func PackReceiptQueuesOutput(bounds ReceiptQueueBounds) ([]byte, error) {
+	return packMethodOutput("receiptQueues", bounds.First, bounds.Last)
+}
+
+// UnpackReceiptQueuesResult unpacks the queue bounds returned by the receiptQueues getter
+func UnpackReceiptQueuesResult(result []byte) (ReceiptQueueBounds, error) {
+	out, err := unpackMethodResult("receiptQueues", result)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get abi")
+		return ReceiptQueueBounds{}, err
+	}
+	return ReceiptQueueBounds{
+		First: *abi.ConvertType(out[0], new(*big.Int)).(**big.Int),
+		Last:  *abi.ConvertType(out[1], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+//
+// receivedFailedMessageHashes
+//
+
+// PackReceivedFailedMessageHashes packs input to form a call to the receivedFailedMessageHashes getter
+func PackReceivedFailedMessageHashes(messageID [32]byte) ([]byte, error) {
+	return packMethod("receivedFailedMessageHashes", messageID)
+}
+
+// UnpackReceivedFailedMessageHashesInput unpacks the calldata of a call to the receivedFailedMessageHashes getter
+func UnpackReceivedFailedMessageHashesInput(input []byte) ([32]byte, error) {
+	return unpackBytes32Input("receivedFailedMessageHashes", input)
+}
+
+func PackReceivedFailedMessageHashesOutput(messageHash [32]byte) ([]byte, error) {
+	return packMethodOutput("receivedFailedMessageHashes", messageHash)
+}
+
+// UnpackReceivedFailedMessageHashesResult unpacks the message hash returned by the
+// receivedFailedMessageHashes getter
+func UnpackReceivedFailedMessageHashesResult(result []byte) ([32]byte, error) {
+	return unpackBytes32Result("receivedFailedMessageHashes", result)
+}
+
+//
+// sentMessageInfo
+//
+
+// PackSentMessageInfo packs input to form a call to the sentMessageInfo getter
+func PackSentMessageInfo(messageID [32]byte) ([]byte, error) {
+	return packMethod("sentMessageInfo", messageID)
+}
+
+// UnpackSentMessageInfoInput unpacks the calldata of a call to the sentMessageInfo getter
+func UnpackSentMessageInfoInput(input []byte) ([32]byte, error) {
+	return unpackBytes32Input("sentMessageInfo", input)
+}
+
+func PackSentMessageInfoOutput(info SentMessageInfo) ([]byte, error) {
+	return packMethodOutput("sentMessageInfo", info.MessageHash, info.FeeInfo)
+}
+
+// UnpackSentMessageInfoResult unpacks the message hash and fee info returned by the sentMessageInfo getter
+func UnpackSentMessageInfoResult(result []byte) (SentMessageInfo, error) {
+	out, err := unpackMethodResult("sentMessageInfo", result)
+	if err != nil {
+		return SentMessageInfo{}, err
 	}
+	return SentMessageInfo{
+		MessageHash: *abi.ConvertType(out[0], new([32]byte)).(*[32]byte),
+		FeeInfo:     *abi.ConvertType(out[1], new(TeleporterFeeInfo)).(*TeleporterFeeInfo),
+	}, nil
+}
 
-	return abi.PackOutput("messageReceived", success)
+//
+// Shared unpacking helpers for single value inputs and results
+//
+
+func unpackBytes32Input(method string, input []byte) ([32]byte, error) {
+	args, err := unpackMethodInput(method, input)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return *abi.ConvertType(args[0], new([32]byte)).(*[32]byte), nil
+}
+
+func unpackAddressInput(method string, input []byte) (common.Address, error) {
+	args, err := unpackMethodInput(method, input)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return *abi.ConvertType(args[0], new(common.Address)).(*common.Address), nil
+}
+
+func unpackBytes32Result(method string, result []byte) ([32]byte, error) {
+	out, err := unpackMethodResult(method, result)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return *abi.ConvertType(out[0], new([32]byte)).(*[32]byte), nil
+}
+
+func unpackAddressResult(method string, result []byte) (common.Address, error) {
+	out, err := unpackMethodResult(method, result)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return *abi.ConvertType(out[0], new(common.Address)).(*common.Address), nil
+}
+
+func unpackUint256Result(method string, result []byte) (*big.Int, error) {
+	out, err := unpackMethodResult(method, result)
+	if err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
 }
 
 // UnpackEvent unpacks the event data and topics into the provided interface
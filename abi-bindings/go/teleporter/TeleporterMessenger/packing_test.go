@@ -115,3 +115,169 @@ func TestUnpackEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestPackUnpackMethodInputs(t *testing.T) {
+	messageID := [32]byte{1, 2, 3}
+	blockchainID := [32]byte{4, 5, 6}
+	address := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+	message := createTestTeleporterMessage(big.NewInt(4))
+	feeInfo := TeleporterFeeInfo{
+		FeeTokenAddress: address,
+		Amount:          big.NewInt(10),
+	}
+
+	t.Run("sendCrossChainMessage", func(t *testing.T) {
+		input := TeleporterMessageInput{
+			DestinationBlockchainID: blockchainID,
+			DestinationAddress:      address,
+			FeeInfo:                 feeInfo,
+			RequiredGasLimit:        big.NewInt(100_000),
+			AllowedRelayerAddresses: []common.Address{address},
+			Message:                 []byte{1, 2, 3},
+		}
+		b, err := PackSendCrossChainMessage(input)
+		require.NoError(t, err)
+		unpacked, err := UnpackSendCrossChainMessageInput(b)
+		require.NoError(t, err)
+		require.Equal(t, input, unpacked)
+	})
+	t.Run("retrySendCrossChainMessage", func(t *testing.T) {
+		b, err := PackRetrySendCrossChainMessage(message)
+		require.NoError(t, err)
+		unpacked, err := UnpackRetrySendCrossChainMessageInput(b)
+		require.NoError(t, err)
+		require.Equal(t, message, unpacked)
+	})
+	t.Run("receiveCrossChainMessage", func(t *testing.T) {
+		b, err := PackReceiveCrossChainMessage(3, address)
+		require.NoError(t, err)
+		unpacked, err := UnpackReceiveCrossChainMessageInput(b)
+		require.NoError(t, err)
+		require.Equal(t, ReceiveCrossChainMessageInput{MessageIndex: 3, RelayerRewardAddress: address}, unpacked)
+	})
+	t.Run("retryMessageExecution", func(t *testing.T) {
+		b, err := PackRetryMessageExecution(ids.ID(blockchainID), message)
+		require.NoError(t, err)
+		unpacked, err := UnpackRetryMessageExecutionInput(b)
+		require.NoError(t, err)
+		require.Equal(t, RetryMessageExecutionInput{SourceBlockchainID: blockchainID, Message: message}, unpacked)
+	})
+	t.Run("addFeeAmount", func(t *testing.T) {
+		b, err := PackAddFeeAmount(messageID, address, big.NewInt(7))
+		require.NoError(t, err)
+		unpacked, err := UnpackAddFeeAmountInput(b)
+		require.NoError(t, err)
+		require.Equal(t, AddFeeAmountInput{
+			MessageID:           messageID,
+			FeeTokenAddress:     address,
+			AdditionalFeeAmount: big.NewInt(7),
+		}, unpacked)
+	})
+	t.Run("sendSpecifiedReceipts", func(t *testing.T) {
+		expected := SendSpecifiedReceiptsInput{
+			SourceBlockchainID:      blockchainID,
+			MessageIDs:              [][32]byte{messageID, {7, 8, 9}},
+			FeeInfo:                 feeInfo,
+			AllowedRelayerAddresses: []common.Address{address},
+		}
+		b, err := PackSendSpecifiedReceipts(
+			expected.SourceBlockchainID,
+			expected.MessageIDs,
+			expected.FeeInfo,
+			expected.AllowedRelayerAddresses,
+		)
+		require.NoError(t, err)
+		unpacked, err := UnpackSendSpecifiedReceiptsInput(b)
+		require.NoError(t, err)
+		require.Equal(t, expected, unpacked)
+	})
+	t.Run("redeemRelayerRewards", func(t *testing.T) {
+		b, err := PackRedeemRelayerRewards(address)
+		require.NoError(t, err)
+		unpacked, err := UnpackRedeemRelayerRewardsInput(b)
+		require.NoError(t, err)
+		require.Equal(t, address, unpacked)
+	})
+	t.Run("calculateMessageID", func(t *testing.T) {
+		b, err := PackCalculateMessageID(blockchainID, messageID, big.NewInt(2))
+		require.NoError(t, err)
+		unpacked, err := UnpackCalculateMessageIDInput(b)
+		require.NoError(t, err)
+		require.Equal(t, CalculateMessageIDInput{
+			SourceBlockchainID:      blockchainID,
+			DestinationBlockchainID: messageID,
+			Nonce:                   big.NewInt(2),
+		}, unpacked)
+	})
+	t.Run("getReceiptAtIndex", func(t *testing.T) {
+		b, err := PackGetReceiptAtIndex(blockchainID, big.NewInt(1))
+		require.NoError(t, err)
+		unpacked, err := UnpackGetReceiptAtIndexInput(b)
+		require.NoError(t, err)
+		require.Equal(t, GetReceiptAtIndexInput{SourceBlockchainID: blockchainID, Index: big.NewInt(1)}, unpacked)
+	})
+	t.Run("wrong method", func(t *testing.T) {
+		b, err := PackMessageReceived(messageID)
+		require.NoError(t, err)
+		_, err = UnpackGetMessageHashInput(b)
+		require.Error(t, err)
+		_, err = UnpackMessageReceivedInput(b[:3])
+		require.Error(t, err)
+	})
+}
+
+func TestPackUnpackMethodResults(t *testing.T) {
+	hash := [32]byte{1, 2, 3}
+	address := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+	feeInfo := TeleporterFeeInfo{
+		FeeTokenAddress: address,
+		Amount:          big.NewInt(10),
+	}
+	receipt := TeleporterMessageReceipt{
+		ReceivedMessageNonce: big.NewInt(3),
+		RelayerRewardAddress: address,
+	}
+
+	b, err := PackGetFeeInfoOutput(feeInfo)
+	require.NoError(t, err)
+	unpackedFeeInfo, err := UnpackGetFeeInfoResult(b)
+	require.NoError(t, err)
+	require.Equal(t, feeInfo, unpackedFeeInfo)
+
+	b, err = PackSentMessageInfoOutput(SentMessageInfo{MessageHash: hash, FeeInfo: feeInfo})
+	require.NoError(t, err)
+	info, err := UnpackSentMessageInfoResult(b)
+	require.NoError(t, err)
+	require.Equal(t, SentMessageInfo{MessageHash: hash, FeeInfo: feeInfo}, info)
+
+	b, err = PackGetReceiptAtIndexOutput(receipt)
+	require.NoError(t, err)
+	unpackedReceipt, err := UnpackGetReceiptAtIndexResult(b)
+	require.NoError(t, err)
+	require.Equal(t, receipt, unpackedReceipt)
+
+	bounds := ReceiptQueueBounds{First: big.NewInt(2), Last: big.NewInt(5)}
+	b, err = PackReceiptQueuesOutput(bounds)
+	require.NoError(t, err)
+	unpackedBounds, err := UnpackReceiptQueuesResult(b)
+	require.NoError(t, err)
+	require.Equal(t, bounds, unpackedBounds)
+
+	b, err = PackGetNextMessageIDOutput(hash)
+	require.NoError(t, err)
+	unpackedHash, err := UnpackGetNextMessageIDResult(b)
+	require.NoError(t, err)
+	require.Equal(t, hash, unpackedHash)
+
+	b, err = PackCheckRelayerRewardAmountOutput(big.NewInt(42))
+	require.NoError(t, err)
+	amount, err := UnpackCheckRelayerRewardAmountResult(b)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(42), amount)
+
+	b, err = PackMessageReceivedOutput(true)
+	require.NoError(t, err)
+	received, err := UnpackMessageReceivedResult(b)
+	require.NoError(t, err)
+	require.True(t, received)
+}
@@ -0,0 +1,138 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleportermessenger
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// MaximumReceiptCount is the maximum number of receipts included in a single message.
+// Must be kept in sync with _MAXIMUM_RECEIPT_COUNT in ReceiptQueue.sol
+const MaximumReceiptCount = 5
+
+var (
+	ErrEmptyReceiptQueue       = errors.New("receipt queue is empty")
+	ErrReceiptIndexOutOfBounds = errors.New("receipt queue index out of bounds")
+)
+
+// CalculateMessageHash computes the hash that TeleporterMessenger stores for a sent message,
+// and returns from getMessageHash. It is also the hash stored in receivedFailedMessageHashes
+// when message execution fails on the destination. Both are keccak256(abi.encode(message)).
+func CalculateMessageHash(message TeleporterMessage) (common.Hash, error) {
+	messageBytes, err := message.Pack()
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "failed to pack teleporter message")
+	}
+	return crypto.Keccak256Hash(messageBytes), nil
+}
+
+// ReceiptQueue is an in-memory model of the ReceiptQueue library used by TeleporterMessenger
+// to track receipts of messages received from a given source blockchain. It provides the same
+// FIFO ordering and batching semantics as the contract.
+type ReceiptQueue struct {
+	receipts []TeleporterMessageReceipt
+}
+
+// NewReceiptQueue returns an empty receipt queue
+func NewReceiptQueue() *ReceiptQueue {
+	return &ReceiptQueue{}
+}
+
+// NewReceiptQueueFromContract populates a receipt queue from the outstanding receipts stored
+// in the TeleporterMessenger contract for messages received from [sourceBlockchainID].
+func NewReceiptQueueFromContract(
+	opts *bind.CallOpts,
+	messenger *TeleporterMessengerCaller,
+	sourceBlockchainID ids.ID,
+) (*ReceiptQueue, error) {
+	size, err := messenger.GetReceiptQueueSize(opts, sourceBlockchainID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get receipt queue size")
+	}
+	if !size.IsUint64() {
+		return nil, errors.New("receipt queue size too large")
+	}
+	queue := NewReceiptQueue()
+	for i := uint64(0); i < size.Uint64(); i++ {
+		receipt, err := messenger.GetReceiptAtIndex(opts, sourceBlockchainID, new(big.Int).SetUint64(i))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get receipt at index %d", i)
+		}
+		queue.Enqueue(receipt)
+	}
+	return queue, nil
+}
+
+// Enqueue adds a receipt to the back of the queue
+func (q *ReceiptQueue) Enqueue(receipt TeleporterMessageReceipt) {
+	q.receipts = append(q.receipts, copyReceipt(receipt))
+}
+
+// EnqueueReceivedMessage adds the receipt that TeleporterMessenger.receiveCrossChainMessage stores
+// when delivering [message] on behalf of [relayerRewardAddress].
+func (q *ReceiptQueue) EnqueueReceivedMessage(message TeleporterMessage, relayerRewardAddress common.Address) {
+	q.Enqueue(TeleporterMessageReceipt{
+		ReceivedMessageNonce: message.MessageNonce,
+		RelayerRewardAddress: relayerRewardAddress,
+	})
+}
+
+// Dequeue removes and returns the oldest receipt in the queue
+func (q *ReceiptQueue) Dequeue() (TeleporterMessageReceipt, error) {
+	if len(q.receipts) == 0 {
+		return TeleporterMessageReceipt{}, ErrEmptyReceiptQueue
+	}
+	receipt := q.receipts[0]
+	q.receipts[0] = TeleporterMessageReceipt{}
+	q.receipts = q.receipts[1:]
+	return receipt, nil
+}
+
+// GetOutstandingReceiptsToSend dequeues and returns the receipts that TeleporterMessenger
+// attaches to the next message sent to the queue's source blockchain.
+func (q *ReceiptQueue) GetOutstandingReceiptsToSend() []TeleporterMessageReceipt {
+	receipts := q.PeekOutstandingReceiptsToSend()
+	for range receipts {
+		// Cannot fail, since at most Size() receipts are peeked.
+		_, _ = q.Dequeue()
+	}
+	return receipts
+}
+
+// PeekOutstandingReceiptsToSend returns the receipts that would be attached to the next message
+// without removing them from the queue.
+func (q *ReceiptQueue) PeekOutstandingReceiptsToSend() []TeleporterMessageReceipt {
+	resultSize := min(MaximumReceiptCount, q.Size())
+	receipts := make([]TeleporterMessageReceipt, resultSize)
+	for i := range receipts {
+		receipts[i] = copyReceipt(q.receipts[i])
+	}
+	return receipts
+}
+
+// Size returns the number of outstanding receipts in the queue
+func (q *ReceiptQueue) Size() int {
+	return len(q.receipts)
+}
+
+// GetReceiptAtIndex returns the receipt at the given index, with index 0 being the oldest receipt
+func (q *ReceiptQueue) GetReceiptAtIndex(index int) (TeleporterMessageReceipt, error) {
+	if index < 0 || index >= q.Size() {
+		return TeleporterMessageReceipt{}, ErrReceiptIndexOutOfBounds
+	}
+	return copyReceipt(q.receipts[index]), nil
+}
+
+func copyReceipt(receipt TeleporterMessageReceipt) TeleporterMessageReceipt {
+	if receipt.ReceivedMessageNonce != nil {
+		receipt.ReceivedMessageNonce = new(big.Int).Set(receipt.ReceivedMessageNonce)
+	}
+	return receipt
+}
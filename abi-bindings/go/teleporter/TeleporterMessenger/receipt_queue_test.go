@@ -0,0 +1,96 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleportermessenger
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestReceipt(nonce int64) TeleporterMessageReceipt {
+	return TeleporterMessageReceipt{
+		ReceivedMessageNonce: big.NewInt(nonce),
+		RelayerRewardAddress: common.BigToAddress(big.NewInt(nonce)),
+	}
+}
+
+func TestCalculateMessageHash(t *testing.T) {
+	message := createTestTeleporterMessage(big.NewInt(3))
+	messageBytes, err := message.Pack()
+	require.NoError(t, err)
+
+	hash, err := CalculateMessageHash(message)
+	require.NoError(t, err)
+	require.Equal(t, crypto.Keccak256Hash(messageBytes), hash)
+
+	// Changing any field of the message must change the hash.
+	message.Message = []byte{5}
+	alteredHash, err := CalculateMessageHash(message)
+	require.NoError(t, err)
+	require.NotEqual(t, hash, alteredHash)
+}
+
+func TestReceiptQueueOrdering(t *testing.T) {
+	queue := NewReceiptQueue()
+	_, err := queue.Dequeue()
+	require.ErrorIs(t, err, ErrEmptyReceiptQueue)
+	require.Empty(t, queue.GetOutstandingReceiptsToSend())
+
+	for i := int64(1); i <= 3; i++ {
+		queue.Enqueue(createTestReceipt(i))
+	}
+	require.Equal(t, 3, queue.Size())
+
+	receipt, err := queue.GetReceiptAtIndex(2)
+	require.NoError(t, err)
+	require.Equal(t, createTestReceipt(3), receipt)
+	_, err = queue.GetReceiptAtIndex(3)
+	require.ErrorIs(t, err, ErrReceiptIndexOutOfBounds)
+
+	receipt, err = queue.Dequeue()
+	require.NoError(t, err)
+	require.Equal(t, createTestReceipt(1), receipt)
+	require.Equal(t, 2, queue.Size())
+}
+
+func TestGetOutstandingReceiptsToSend(t *testing.T) {
+	tests := []struct {
+		name             string
+		queued           int
+		expectedSent     int
+		expectedRemained int
+	}{
+		{name: "empty", queued: 0, expectedSent: 0, expectedRemained: 0},
+		{name: "less than max", queued: 3, expectedSent: 3, expectedRemained: 0},
+		{name: "exactly max", queued: MaximumReceiptCount, expectedSent: MaximumReceiptCount, expectedRemained: 0},
+		{name: "more than max", queued: 12, expectedSent: MaximumReceiptCount, expectedRemained: 7},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			queue := NewReceiptQueue()
+			for i := 1; i <= test.queued; i++ {
+				queue.EnqueueReceivedMessage(
+					createTestTeleporterMessage(big.NewInt(int64(i))),
+					common.BigToAddress(big.NewInt(int64(i))),
+				)
+			}
+
+			peeked := queue.PeekOutstandingReceiptsToSend()
+			require.Equal(t, test.queued, queue.Size())
+
+			sent := queue.GetOutstandingReceiptsToSend()
+			require.Equal(t, peeked, sent)
+			require.Len(t, sent, test.expectedSent)
+			require.Equal(t, test.expectedRemained, queue.Size())
+			for i, receipt := range sent {
+				require.Equal(t, createTestReceipt(int64(i+1)), receipt)
+			}
+		})
+	}
+}
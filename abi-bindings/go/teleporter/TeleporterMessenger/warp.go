@@ -0,0 +1,126 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleportermessenger
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	teleporterutils "github.com/ava-labs/icm-contracts/utils/teleporter-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrNilMessageNonce          = errors.New("teleporter message nonce is nil")
+	ErrUnexpectedSourceAddress  = errors.New("unexpected addressed call source address")
+	ErrInvalidSourceAddressSize = errors.New("invalid addressed call source address size")
+)
+
+// TeleporterWarpMessage is the Warp-level view of a Teleporter message. It holds the
+// unsigned Warp message together with the decoded TeleporterMessage it carries, and the
+// message ID as computed by the sending TeleporterMessenger.
+type TeleporterWarpMessage struct {
+	UnsignedMessage            *avalancheWarp.UnsignedMessage
+	TeleporterMessengerAddress common.Address
+	SourceBlockchainID         ids.ID
+	MessageID                  ids.ID
+	Message                    TeleporterMessage
+}
+
+// NewTeleporterWarpMessage packs the TeleporterMessage into an AddressedCall sent by
+// [teleporterMessengerAddress], and wraps it in an unsigned Warp message originating
+// from [sourceBlockchainID]. This mirrors the envelope constructed by
+// TeleporterMessenger.sendCrossChainMessage via the Warp precompile.
+func NewTeleporterWarpMessage(
+	networkID uint32,
+	sourceBlockchainID ids.ID,
+	teleporterMessengerAddress common.Address,
+	message TeleporterMessage,
+) (*TeleporterWarpMessage, error) {
+	if message.MessageNonce == nil {
+		return nil, ErrNilMessageNonce
+	}
+	messageBytes, err := message.Pack()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to pack teleporter message")
+	}
+	addressedCall, err := payload.NewAddressedCall(teleporterMessengerAddress.Bytes(), messageBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create addressed call payload")
+	}
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(
+		networkID,
+		sourceBlockchainID,
+		addressedCall.Bytes(),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create unsigned warp message")
+	}
+	return newTeleporterWarpMessage(unsignedMessage, teleporterMessengerAddress, message)
+}
+
+// ParseTeleporterWarpMessage parses an unsigned Warp message into its Teleporter view.
+// The AddressedCall source address must match [expectedTeleporterAddress], the same
+// check TeleporterMessenger.receiveCrossChainMessage performs against its own address.
+func ParseTeleporterWarpMessage(
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	expectedTeleporterAddress common.Address,
+) (*TeleporterWarpMessage, error) {
+	addressedCall, err := payload.ParseAddressedCall(unsignedMessage.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse addressed call payload")
+	}
+	if len(addressedCall.SourceAddress) != common.AddressLength {
+		return nil, fmt.Errorf(
+			"%w: expected %d bytes, got %d",
+			ErrInvalidSourceAddressSize,
+			common.AddressLength,
+			len(addressedCall.SourceAddress),
+		)
+	}
+	sourceAddress := common.BytesToAddress(addressedCall.SourceAddress)
+	if sourceAddress != expectedTeleporterAddress {
+		return nil, fmt.Errorf(
+			"%w: expected %s, got %s",
+			ErrUnexpectedSourceAddress,
+			expectedTeleporterAddress.Hex(),
+			sourceAddress.Hex(),
+		)
+	}
+
+	var message TeleporterMessage
+	if err := message.Unpack(addressedCall.Payload); err != nil {
+		return nil, err
+	}
+	if message.MessageNonce == nil {
+		return nil, ErrNilMessageNonce
+	}
+	return newTeleporterWarpMessage(unsignedMessage, sourceAddress, message)
+}
+
+func newTeleporterWarpMessage(
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	teleporterMessengerAddress common.Address,
+	message TeleporterMessage,
+) (*TeleporterWarpMessage, error) {
+	messageID, err := teleporterutils.CalculateMessageID(
+		teleporterMessengerAddress,
+		unsignedMessage.SourceChainID,
+		message.DestinationBlockchainID,
+		message.MessageNonce,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to calculate message ID")
+	}
+	return &TeleporterWarpMessage{
+		UnsignedMessage:            unsignedMessage,
+		TeleporterMessengerAddress: teleporterMessengerAddress,
+		SourceBlockchainID:         unsignedMessage.SourceChainID,
+		MessageID:                  messageID,
+		Message:                    message,
+	}, nil
+}
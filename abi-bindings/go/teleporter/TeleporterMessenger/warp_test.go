@@ -0,0 +1,93 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleportermessenger
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	teleporterutils "github.com/ava-labs/icm-contracts/utils/teleporter-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeleporterWarpMessageRoundTrip(t *testing.T) {
+	networkID := uint32(12345)
+	sourceBlockchainID := ids.ID{5, 6, 7, 8}
+	teleporterAddress := common.HexToAddress("0xfeabb3b3f4eeae6b5769507a5e6b808704e5c626")
+	message := createTestTeleporterMessage(big.NewInt(7))
+
+	built, err := NewTeleporterWarpMessage(networkID, sourceBlockchainID, teleporterAddress, message)
+	require.NoError(t, err)
+	require.Equal(t, networkID, built.UnsignedMessage.NetworkID)
+	require.Equal(t, sourceBlockchainID, built.UnsignedMessage.SourceChainID)
+
+	expectedID, err := teleporterutils.CalculateMessageID(
+		teleporterAddress,
+		sourceBlockchainID,
+		message.DestinationBlockchainID,
+		message.MessageNonce,
+	)
+	require.NoError(t, err)
+	require.Equal(t, expectedID, built.MessageID)
+
+	// Parse the serialized message to make sure nothing is lost over the wire.
+	unsignedMessage, err := avalancheWarp.ParseUnsignedMessage(built.UnsignedMessage.Bytes())
+	require.NoError(t, err)
+	parsed, err := ParseTeleporterWarpMessage(unsignedMessage, teleporterAddress)
+	require.NoError(t, err)
+	require.Equal(t, built.MessageID, parsed.MessageID)
+	require.Equal(t, sourceBlockchainID, parsed.SourceBlockchainID)
+	require.Equal(t, teleporterAddress, parsed.TeleporterMessengerAddress)
+	require.Equal(t, message, parsed.Message)
+}
+
+func TestParseTeleporterWarpMessageErrors(t *testing.T) {
+	teleporterAddress := common.HexToAddress("0xfeabb3b3f4eeae6b5769507a5e6b808704e5c626")
+	messageBytes, err := createTestTeleporterMessage(big.NewInt(1)).Pack()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		sourceAddress []byte
+		payload       []byte
+		expectedErr   error
+	}{
+		{
+			name:          "unexpected source address",
+			sourceAddress: common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567").Bytes(),
+			payload:       messageBytes,
+			expectedErr:   ErrUnexpectedSourceAddress,
+		},
+		{
+			name:          "empty source address",
+			sourceAddress: []byte{},
+			payload:       messageBytes,
+			expectedErr:   ErrInvalidSourceAddressSize,
+		},
+		{
+			name:          "invalid teleporter message",
+			sourceAddress: teleporterAddress.Bytes(),
+			payload:       []byte{1, 2, 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			addressedCall, err := payload.NewAddressedCall(test.sourceAddress, test.payload)
+			require.NoError(t, err)
+			unsignedMessage, err := avalancheWarp.NewUnsignedMessage(1, ids.ID{1}, addressedCall.Bytes())
+			require.NoError(t, err)
+
+			_, err = ParseTeleporterWarpMessage(unsignedMessage, teleporterAddress)
+			require.Error(t, err)
+			if test.expectedErr != nil {
+				require.ErrorIs(t, err, test.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleporterregistry
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/stretchr/testify/require"
+)
+
+// registryWarpPayloadCorpus holds abi.encode(ProtocolRegistryEntry, address) outputs of the Solidity
+// compiler. The same encodings are asserted in contracts/teleporter/registry/tests/TeleporterRegistryTests.t.sol.
+var registryWarpPayloadCorpus = []struct {
+	name               string
+	encoding           string
+	entry              ProtocolRegistryEntry
+	destinationAddress common.Address
+}{
+	{
+		name: "version 1",
+		encoding: "0000000000000000000000000000000000000000000000000000000000000001" +
+			"0000000000000000000000007777777777777777777777777777777777777777" +
+			"0000000000000000000000008888888888888888888888888888888888888888",
+		entry: ProtocolRegistryEntry{
+			Version:         big.NewInt(1),
+			ProtocolAddress: common.HexToAddress("0x7777777777777777777777777777777777777777"),
+		},
+		destinationAddress: common.HexToAddress("0x8888888888888888888888888888888888888888"),
+	},
+	{
+		name: "max version",
+		encoding: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff" +
+			"0000000000000000000000009999999999999999999999999999999999999999" +
+			"000000000000000000000000aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		entry: ProtocolRegistryEntry{
+			Version:         math.MaxBig256,
+			ProtocolAddress: common.HexToAddress("0x9999999999999999999999999999999999999999"),
+		},
+		destinationAddress: common.HexToAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"),
+	},
+}
+
+func TestTeleporterRegistryWarpPayloadSolidityCorpus(t *testing.T) {
+	for _, test := range registryWarpPayloadCorpus {
+		t.Run(test.name, func(t *testing.T) {
+			encoding := common.FromHex(test.encoding)
+
+			packed, err := PackTeleporterRegistryWarpPayload(test.entry, test.destinationAddress)
+			require.NoError(t, err)
+			require.Equal(t, encoding, packed)
+
+			entry, destinationAddress, err := UnpackTeleporterRegistryWarpPayload(encoding)
+			require.NoError(t, err)
+			require.Equal(t, test.entry, entry)
+			require.Equal(t, test.destinationAddress, destinationAddress)
+		})
+	}
+}
+
+func FuzzUnpackTeleporterRegistryWarpPayload(f *testing.F) {
+	for _, test := range registryWarpPayloadCorpus {
+		f.Add(common.FromHex(test.encoding))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		entry, destinationAddress, err := UnpackTeleporterRegistryWarpPayload(data)
+		if err != nil {
+			return
+		}
+
+		// The payload only contains static types, so the canonical encoding is a prefix of
+		// any accepted input. The decoder ignores the upper 12 bytes of address words, which
+		// abi.decode in Solidity would reject, so those are the only bytes allowed to differ.
+		canonical, err := PackTeleporterRegistryWarpPayload(entry, destinationAddress)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(canonical), len(data))
+		cleared := common.CopyBytes(data[:len(canonical)])
+		for _, addressWord := range []int{32, 64} {
+			copy(cleared[addressWord:addressWord+12], make([]byte, 12))
+		}
+		require.Equal(t, canonical, cleared)
+	})
+}
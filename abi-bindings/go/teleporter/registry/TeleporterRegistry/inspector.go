@@ -0,0 +1,127 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleporterregistry
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// MaxVersionIncrement is the maximum amount a new version may exceed the latest version by.
+// Must be kept in sync with MAX_VERSION_INCREMENT in TeleporterRegistry.sol
+const MaxVersionIncrement = 500
+
+var (
+	ErrInvalidSourceChainID        = errors.New("invalid source chain ID")
+	ErrInvalidOriginSenderAddress  = errors.New("invalid origin sender address")
+	ErrNonCanonicalPayload         = errors.New("non-canonical registry payload encoding")
+	ErrInvalidDestinationAddress   = errors.New("invalid destination address")
+	ErrZeroVersion                 = errors.New("zero version")
+	ErrVersionNotGreaterThanLatest = errors.New("version not greater than latest version")
+	ErrVersionIncrementTooHigh     = errors.New("version increment too high")
+	ErrZeroProtocolAddress         = errors.New("zero protocol address")
+)
+
+// InspectTeleporterRegistryWarpMessage validates an unsigned Warp message meant to be delivered
+// to TeleporterRegistry.addProtocolVersion on the registry at [registryAddress], deployed on
+// [registryBlockchainID], and returns the protocol registry entry it carries.
+//
+// It performs the same checks as the contract: the message must be an off-chain Warp message
+// of the registry's own chain, i.e. its source chain is [registryBlockchainID] and its
+// AddressedCall has the zero source address, and it must be addressed to [registryAddress]. In
+// addition, the new version must be strictly greater than [latestVersion], so that it becomes
+// the latest Teleporter version once added. A nil [latestVersion] is treated as no version being
+// registered yet.
+// Each failed check is reported by wrapping one of the errors defined above.
+func InspectTeleporterRegistryWarpMessage(
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	registryBlockchainID ids.ID,
+	registryAddress common.Address,
+	latestVersion *big.Int,
+) (ProtocolRegistryEntry, error) {
+	if unsignedMessage.SourceChainID != registryBlockchainID {
+		return ProtocolRegistryEntry{}, fmt.Errorf(
+			"%w: expected %s, got %s",
+			ErrInvalidSourceChainID,
+			registryBlockchainID,
+			unsignedMessage.SourceChainID,
+		)
+	}
+	addressedCall, err := payload.ParseAddressedCall(unsignedMessage.Payload)
+	if err != nil {
+		return ProtocolRegistryEntry{}, errors.Wrap(err, "failed to parse addressed call payload")
+	}
+	// The Warp precompile converts the source address to an EVM address, which the contract
+	// compares to address(0)
+	if common.BytesToAddress(addressedCall.SourceAddress) != (common.Address{}) {
+		return ProtocolRegistryEntry{}, fmt.Errorf(
+			"%w: expected zero source address, got 0x%x",
+			ErrInvalidOriginSenderAddress,
+			addressedCall.SourceAddress,
+		)
+	}
+
+	entry, destinationAddress, err := UnpackTeleporterRegistryWarpPayload(addressedCall.Payload)
+	if err != nil {
+		return ProtocolRegistryEntry{}, err
+	}
+	// The Go decoder ignores the padding of address words, which abi.decode rejects on-chain.
+	canonical, err := PackTeleporterRegistryWarpPayload(entry, destinationAddress)
+	if err != nil {
+		return ProtocolRegistryEntry{}, errors.Wrap(err, "failed to pack registry payload")
+	}
+	if !bytes.HasPrefix(addressedCall.Payload, canonical) {
+		return ProtocolRegistryEntry{}, ErrNonCanonicalPayload
+	}
+	if destinationAddress != registryAddress {
+		return ProtocolRegistryEntry{}, fmt.Errorf(
+			"%w: expected %s, got %s",
+			ErrInvalidDestinationAddress,
+			registryAddress.Hex(),
+			destinationAddress.Hex(),
+		)
+	}
+
+	if err := validateProtocolRegistryEntry(entry, latestVersion); err != nil {
+		return ProtocolRegistryEntry{}, err
+	}
+	return entry, nil
+}
+
+func validateProtocolRegistryEntry(entry ProtocolRegistryEntry, latestVersion *big.Int) error {
+	if latestVersion == nil {
+		latestVersion = common.Big0
+	}
+	if entry.Version.Sign() == 0 {
+		return ErrZeroVersion
+	}
+	if entry.Version.Cmp(latestVersion) <= 0 {
+		return fmt.Errorf(
+			"%w: version %s, latest version %s",
+			ErrVersionNotGreaterThanLatest,
+			entry.Version,
+			latestVersion,
+		)
+	}
+	maxVersion := new(big.Int).Add(latestVersion, big.NewInt(MaxVersionIncrement))
+	if entry.Version.Cmp(maxVersion) > 0 {
+		return fmt.Errorf(
+			"%w: version %s, maximum allowed version %s",
+			ErrVersionIncrementTooHigh,
+			entry.Version,
+			maxVersion,
+		)
+	}
+	if entry.ProtocolAddress == (common.Address{}) {
+		return ErrZeroProtocolAddress
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleporterregistry
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectTeleporterRegistryWarpMessage(t *testing.T) {
+	registryBlockchainID := ids.ID{1, 2, 3}
+	registryAddress := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+	protocolAddress := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234568")
+	latestVersion := big.NewInt(2)
+
+	validPayload := func(version int64, protocolAddress common.Address) []byte {
+		b, err := PackTeleporterRegistryWarpPayload(
+			ProtocolRegistryEntry{Version: big.NewInt(version), ProtocolAddress: protocolAddress},
+			registryAddress,
+		)
+		require.NoError(t, err)
+		return b
+	}
+	dirtyPayload := validPayload(3, protocolAddress)
+	dirtyPayload[64] = 1
+
+	tests := []struct {
+		name          string
+		sourceChainID ids.ID
+		sourceAddress []byte
+		payload       []byte
+		latestVersion *big.Int
+		valid         bool
+		expectedErr   error
+	}{
+		{
+			name:          "valid",
+			sourceChainID: registryBlockchainID,
+			sourceAddress: []byte{},
+			payload:       validPayload(3, protocolAddress),
+			latestVersion: latestVersion,
+			valid:         true,
+		},
+		{
+			name:          "first version",
+			sourceChainID: registryBlockchainID,
+			sourceAddress: []byte{},
+			payload:       validPayload(1, protocolAddress),
+			valid:         true,
+		},
+		{
+			name:          "maximum version increment",
+			sourceChainID: registryBlockchainID,
+			sourceAddress: []byte{},
+			payload:       validPayload(2+MaxVersionIncrement, protocolAddress),
+			latestVersion: latestVersion,
+			valid:         true,
+		},
+		{
+			name:          "zero source address",
+			sourceChainID: registryBlockchainID,
+			sourceAddress: common.Address{}.Bytes(),
+			payload:       validPayload(3, protocolAddress),
+			latestVersion: latestVersion,
+			valid:         true,
+		},
+		{
+			name:          "other source chain",
+			sourceChainID: ids.ID{4, 5, 6},
+			sourceAddress: []byte{},
+			payload:       validPayload(3, protocolAddress),
+			latestVersion: latestVersion,
+			expectedErr:   ErrInvalidSourceChainID,
+		},
+		{
+			name:          "on-chain sender",
+			sourceChainID: registryBlockchainID,
+			sourceAddress: protocolAddress.Bytes(),
+			payload:       validPayload(3, protocolAddress),
+			latestVersion: latestVersion,
+			expectedErr:   ErrInvalidOriginSenderAddress,
+		},
+		{
+			name:          "dirty address padding",
+			sourceChainID: registryBlockchainID,
+			sourceAddress: []byte{},
+			payload:       dirtyPayload,
+			latestVersion: latestVersion,
+			expectedErr:   ErrNonCanonicalPayload,
+		},
+		{
+			name:          "other destination",
+			sourceChainID: registryBlockchainID,
+			sourceAddress: []byte{},
+			payload: func() []byte {
+				b, err := PackTeleporterRegistryWarpPayload(
+					ProtocolRegistryEntry{Version: big.NewInt(3), ProtocolAddress: protocolAddress},
+					protocolAddress,
+				)
+				require.NoError(t, err)
+				return b
+			}(),
+			latestVersion: latestVersion,
+			expectedErr:   ErrInvalidDestinationAddress,
+		},
+		{
+			name:          "zero version",
+			sourceChainID: registryBlockchainID,
+			sourceAddress: []byte{},
+			payload:       validPayload(0, protocolAddress),
+			latestVersion: latestVersion,
+			expectedErr:   ErrZeroVersion,
+		},
+		{
+			name:          "latest version",
+			sourceChainID: registryBlockchainID,
+			sourceAddress: []byte{},
+			payload:       validPayload(2, protocolAddress),
+			latestVersion: latestVersion,
+			expectedErr:   ErrVersionNotGreaterThanLatest,
+		},
+		{
+			name:          "version increment too high",
+			sourceChainID: registryBlockchainID,
+			sourceAddress: []byte{},
+			payload:       validPayload(3+MaxVersionIncrement, protocolAddress),
+			latestVersion: latestVersion,
+			expectedErr:   ErrVersionIncrementTooHigh,
+		},
+		{
+			name:          "zero protocol address",
+			sourceChainID: registryBlockchainID,
+			sourceAddress: []byte{},
+			payload:       validPayload(3, common.Address{}),
+			latestVersion: latestVersion,
+			expectedErr:   ErrZeroProtocolAddress,
+		},
+		{
+			name:          "malformed payload",
+			sourceChainID: registryBlockchainID,
+			sourceAddress: []byte{},
+			payload:       []byte{1, 2, 3},
+			latestVersion: latestVersion,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			addressedCall, err := payload.NewAddressedCall(test.sourceAddress, test.payload)
+			require.NoError(t, err)
+			unsignedMessage, err := avalancheWarp.NewUnsignedMessage(1, test.sourceChainID, addressedCall.Bytes())
+			require.NoError(t, err)
+
+			entry, err := InspectTeleporterRegistryWarpMessage(
+				unsignedMessage,
+				registryBlockchainID,
+				registryAddress,
+				test.latestVersion,
+			)
+			if test.valid {
+				require.NoError(t, err)
+				expectedEntry, _, err := UnpackTeleporterRegistryWarpPayload(test.payload)
+				require.NoError(t, err)
+				require.Equal(t, expectedEntry, entry)
+				return
+			}
+			require.Error(t, err)
+			if test.expectedErr != nil {
+				require.ErrorIs(t, err, test.expectedErr)
+			}
+		})
+	}
+}
@@ -4,7 +4,9 @@
 package teleporterregistry
 
 import (
+	"bytes"
 	"fmt"
+	"math/big"
 
 	"github.com/ava-labs/subnet-evm/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
@@ -87,7 +89,6 @@ func UnpackTeleporterRegistryWarpPayload(entryBytes []byte) (ProtocolRegistryEnt
 		},
 	}
 	unpacked, err := args.Unpack(entryBytes)
-	fmt.Println("unpacked: ", unpacked)
 	if err != nil {
 		return ProtocolRegistryEntry{}, common.Address{},
 			fmt.Errorf("failed to unpack to Teleporter registry entry with err: %v", err)
@@ -105,12 +106,229 @@ func UnpackTeleporterRegistryWarpPayload(entryBytes []byte) (ProtocolRegistryEnt
 	return payload.ProtocolRegistryEntry, payload.DestinationAddress, nil
 }
 
+func packMethod(method string, args ...interface{}) ([]byte, error) {
+	registryABI, err := TeleporterRegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abi")
+	}
+	return registryABI.Pack(method, args...)
+}
+
+func packMethodOutput(method string, args ...interface{}) ([]byte, error) {
+	registryABI, err := TeleporterRegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abi")
+	}
+	return registryABI.PackOutput(method, args...)
+}
+
+// unpackMethodInput checks that the calldata is a call to [method] and unpacks its arguments
+func unpackMethodInput(method string, input []byte) ([]interface{}, error) {
+	registryABI, err := TeleporterRegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abi")
+	}
+	m, ok := registryABI.Methods[method]
+	if !ok {
+		return nil, fmt.Errorf("method %s not found in abi", method)
+	}
+	if len(input) < len(m.ID) || !bytes.Equal(input[:len(m.ID)], m.ID) {
+		return nil, fmt.Errorf("input is not a call to %s", method)
+	}
+	return m.Inputs.Unpack(input[len(m.ID):])
+}
+
+func unpackMethodResult(method string, result []byte) ([]interface{}, error) {
+	registryABI, err := TeleporterRegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abi")
+	}
+	return registryABI.Unpack(method, result)
+}
+
 // PackAddProtocolVersion packs input to form a call to the addProtocolVersion function
 func PackAddProtocolVersion(messageIndex uint32) ([]byte, error) {
-	abi, err := TeleporterRegistryMetaData.GetAbi()
+	return packMethod("addProtocolVersion", messageIndex)
+}
+
+// UnpackAddProtocolVersionInput unpacks the calldata of a call to addProtocolVersion
+func UnpackAddProtocolVersionInput(input []byte) (uint32, error) {
+	args, err := unpackMethodInput("addProtocolVersion", input)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get abi")
+		return 0, err
+	}
+	return *abi.ConvertType(args[0], new(uint32)).(*uint32), nil
+}
+
+// PackGetAddressFromVersion packs input to form a call to the getAddressFromVersion function
+func PackGetAddressFromVersion(version *big.Int) ([]byte, error) {
+	return packMethod("getAddressFromVersion", version)
+}
+
+// UnpackGetAddressFromVersionInput unpacks the calldata of a call to getAddressFromVersion
+func UnpackGetAddressFromVersionInput(input []byte) (*big.Int, error) {
+	return unpackUint256Input("getAddressFromVersion", input)
+}
+
+func PackGetAddressFromVersionOutput(protocolAddress common.Address) ([]byte, error) {
+	return packMethodOutput("getAddressFromVersion", protocolAddress)
+}
+
+// UnpackGetAddressFromVersionResult unpacks the address returned by getAddressFromVersion
+func UnpackGetAddressFromVersionResult(result []byte) (common.Address, error) {
+	return unpackAddressResult("getAddressFromVersion", result)
+}
+
+// PackGetTeleporterFromVersion packs input to form a call to the getTeleporterFromVersion function
+func PackGetTeleporterFromVersion(version *big.Int) ([]byte, error) {
+	return packMethod("getTeleporterFromVersion", version)
+}
+
+// UnpackGetTeleporterFromVersionInput unpacks the calldata of a call to getTeleporterFromVersion
+func UnpackGetTeleporterFromVersionInput(input []byte) (*big.Int, error) {
+	return unpackUint256Input("getTeleporterFromVersion", input)
+}
+
+func PackGetTeleporterFromVersionOutput(teleporterAddress common.Address) ([]byte, error) {
+	return packMethodOutput("getTeleporterFromVersion", teleporterAddress)
+}
+
+// UnpackGetTeleporterFromVersionResult unpacks the address returned by getTeleporterFromVersion
+func UnpackGetTeleporterFromVersionResult(result []byte) (common.Address, error) {
+	return unpackAddressResult("getTeleporterFromVersion", result)
+}
+
+// PackGetVersionFromAddress packs input to form a call to the getVersionFromAddress function
+func PackGetVersionFromAddress(protocolAddress common.Address) ([]byte, error) {
+	return packMethod("getVersionFromAddress", protocolAddress)
+}
+
+// UnpackGetVersionFromAddressInput unpacks the calldata of a call to getVersionFromAddress
+func UnpackGetVersionFromAddressInput(input []byte) (common.Address, error) {
+	args, err := unpackMethodInput("getVersionFromAddress", input)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return *abi.ConvertType(args[0], new(common.Address)).(*common.Address), nil
+}
+
+func PackGetVersionFromAddressOutput(version *big.Int) ([]byte, error) {
+	return packMethodOutput("getVersionFromAddress", version)
+}
+
+// UnpackGetVersionFromAddressResult unpacks the version returned by getVersionFromAddress
+func UnpackGetVersionFromAddressResult(result []byte) (*big.Int, error) {
+	return unpackUint256Result("getVersionFromAddress", result)
+}
+
+// PackGetLatestTeleporter forms a call to the getLatestTeleporter function
+func PackGetLatestTeleporter() ([]byte, error) {
+	return packMethod("getLatestTeleporter")
+}
+
+func PackGetLatestTeleporterOutput(teleporterAddress common.Address) ([]byte, error) {
+	return packMethodOutput("getLatestTeleporter", teleporterAddress)
+}
+
+// UnpackGetLatestTeleporterResult unpacks the address returned by getLatestTeleporter
+func UnpackGetLatestTeleporterResult(result []byte) (common.Address, error) {
+	return unpackAddressResult("getLatestTeleporter", result)
+}
+
+// PackLatestVersion forms a call to the latestVersion getter
+func PackLatestVersion() ([]byte, error) {
+	return packMethod("latestVersion")
+}
+
+func PackLatestVersionOutput(version *big.Int) ([]byte, error) {
+	return packMethodOutput("latestVersion", version)
+}
+
+// UnpackLatestVersionResult unpacks the version returned by the latestVersion getter
+func UnpackLatestVersionResult(result []byte) (*big.Int, error) {
+	return unpackUint256Result("latestVersion", result)
+}
+
+// PackMaxVersionIncrement forms a call to the MAX_VERSION_INCREMENT getter
+func PackMaxVersionIncrement() ([]byte, error) {
+	return packMethod("MAX_VERSION_INCREMENT")
+}
+
+func PackMaxVersionIncrementOutput(maxVersionIncrement *big.Int) ([]byte, error) {
+	return packMethodOutput("MAX_VERSION_INCREMENT", maxVersionIncrement)
+}
+
+// UnpackMaxVersionIncrementResult unpacks the value returned by the MAX_VERSION_INCREMENT getter
+func UnpackMaxVersionIncrementResult(result []byte) (*big.Int, error) {
+	return unpackUint256Result("MAX_VERSION_INCREMENT", result)
+}
+
+// PackValidatorsSourceAddress forms a call to the VALIDATORS_SOURCE_ADDRESS getter
+func PackValidatorsSourceAddress() ([]byte, error) {
+	return packMethod("VALIDATORS_SOURCE_ADDRESS")
+}
+
+func PackValidatorsSourceAddressOutput(sourceAddress common.Address) ([]byte, error) {
+	return packMethodOutput("VALIDATORS_SOURCE_ADDRESS", sourceAddress)
+}
+
+// UnpackValidatorsSourceAddressResult unpacks the address returned by the VALIDATORS_SOURCE_ADDRESS getter
+func UnpackValidatorsSourceAddressResult(result []byte) (common.Address, error) {
+	return unpackAddressResult("VALIDATORS_SOURCE_ADDRESS", result)
+}
+
+// PackWarpMessenger forms a call to the WARP_MESSENGER getter
+func PackWarpMessenger() ([]byte, error) {
+	return packMethod("WARP_MESSENGER")
+}
+
+func PackWarpMessengerOutput(warpMessenger common.Address) ([]byte, error) {
+	return packMethodOutput("WARP_MESSENGER", warpMessenger)
+}
+
+// UnpackWarpMessengerResult unpacks the address returned by the WARP_MESSENGER getter
+func UnpackWarpMessengerResult(result []byte) (common.Address, error) {
+	return unpackAddressResult("WARP_MESSENGER", result)
+}
+
+// PackBlockchainID forms a call to the blockchainID getter
+func PackBlockchainID() ([]byte, error) {
+	return packMethod("blockchainID")
+}
+
+func PackBlockchainIDOutput(blockchainID [32]byte) ([]byte, error) {
+	return packMethodOutput("blockchainID", blockchainID)
+}
+
+// UnpackBlockchainIDResult unpacks the blockchain ID returned by the blockchainID getter
+func UnpackBlockchainIDResult(result []byte) ([32]byte, error) {
+	out, err := unpackMethodResult("blockchainID", result)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return *abi.ConvertType(out[0], new([32]byte)).(*[32]byte), nil
+}
+
+func unpackUint256Input(method string, input []byte) (*big.Int, error) {
+	args, err := unpackMethodInput(method, input)
+	if err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(args[0], new(*big.Int)).(**big.Int), nil
+}
+
+func unpackAddressResult(method string, result []byte) (common.Address, error) {
+	out, err := unpackMethodResult(method, result)
+	if err != nil {
+		return common.Address{}, err
 	}
+	return *abi.ConvertType(out[0], new(common.Address)).(*common.Address), nil
+}
 
-	return abi.Pack("addProtocolVersion", messageIndex)
+func unpackUint256Result(method string, result []byte) (*big.Int, error) {
+	out, err := unpackMethodResult(method, result)
+	if err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
 }
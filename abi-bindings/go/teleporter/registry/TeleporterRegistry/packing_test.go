@@ -39,3 +39,41 @@ func TestPackUnpackProtocolRegistryEntry(t *testing.T) {
 	require.Equal(t, entry.ProtocolAddress, unpackedEntry.ProtocolAddress)
 	require.Equal(t, destinationAddress, unpackedDestinationAddress)
 }
+
+func TestPackUnpackMethods(t *testing.T) {
+	address := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+
+	b, err := PackAddProtocolVersion(7)
+	require.NoError(t, err)
+	messageIndex, err := UnpackAddProtocolVersionInput(b)
+	require.NoError(t, err)
+	require.Equal(t, uint32(7), messageIndex)
+
+	b, err = PackGetAddressFromVersion(big.NewInt(2))
+	require.NoError(t, err)
+	version, err := UnpackGetAddressFromVersionInput(b)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2), version)
+
+	// Calldata for a different method must be rejected.
+	_, err = UnpackGetTeleporterFromVersionInput(b)
+	require.Error(t, err)
+
+	b, err = PackGetVersionFromAddress(address)
+	require.NoError(t, err)
+	unpackedAddress, err := UnpackGetVersionFromAddressInput(b)
+	require.NoError(t, err)
+	require.Equal(t, address, unpackedAddress)
+
+	b, err = PackGetLatestTeleporterOutput(address)
+	require.NoError(t, err)
+	unpackedAddress, err = UnpackGetLatestTeleporterResult(b)
+	require.NoError(t, err)
+	require.Equal(t, address, unpackedAddress)
+
+	b, err = PackLatestVersionOutput(big.NewInt(3))
+	require.NoError(t, err)
+	version, err = UnpackLatestVersionResult(b)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(3), version)
+}
@@ -0,0 +1,60 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"os"
+
+	localnetwork "github.com/ava-labs/icm-contracts/tests/network"
+	"github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+)
+
+var errNoNetworkDir = errors.New("no network directory, set --dir or " + localnetwork.LocalNetworkDirEnvVar)
+
+var rootCmd = &cobra.Command{
+	Use:   "local-network",
+	Short: "A CLI that manages a persistent local network for the E2E tests",
+	Long: `A CLI that manages a persistent local network for the E2E tests. The network
+is started once from a topology file, and can be reattached by later test runs
+by setting ` + localnetwork.LocalNetworkDirEnvVar + ` to its directory. Snapshots of
+the network can be taken and restored to reset chain state.`,
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main(). It only needs to happen once to the rootCmd.
+func Execute() {
+	err := rootCmd.Execute()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	// The network helpers assert with Gomega, whose failures end the command
+	gomega.RegisterFailHandler(func(message string, _ ...int) {
+		cobra.CheckErr(errors.New(message))
+	})
+}
+
+// addDirFlag adds the --dir flag of commands acting on an existing network
+func addDirFlag(cmd *cobra.Command) *string {
+	return cmd.Flags().String(
+		"dir",
+		os.Getenv(localnetwork.LocalNetworkDirEnvVar),
+		"Directory of the network (default $"+localnetwork.LocalNetworkDirEnvVar+")",
+	)
+}
+
+func checkDir(dir string) {
+	if dir == "" {
+		cobra.CheckErr(errNoNetworkDir)
+	}
+}
+
+func main() {
+	Execute()
+}
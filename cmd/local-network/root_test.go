@@ -0,0 +1,28 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRootCmd(t *testing.T) {
+	for _, args := range [][]string{{}, {"--help"}} {
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetErr(buf)
+		rootCmd.SetArgs(args)
+		require.NoError(t, rootCmd.Execute())
+
+		out := buf.String()
+		require.True(t, strings.HasPrefix(out, "A CLI that manages a persistent local network for the E2E tests"))
+		for _, command := range []string{"start", "stop", "snapshot", "restore", "snapshots"} {
+			require.Contains(t, out, command)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+
+	localnetwork "github.com/ava-labs/icm-contracts/tests/network"
+	"github.com/spf13/cobra"
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot NAME",
+	Short: "Saves the state of a local network as a named snapshot",
+	Long: `Saves the state of a local network as a named snapshot, replacing any snapshot
+of the same name. The nodes are restarted while their databases are copied.`,
+	Args: cobra.ExactArgs(1),
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore NAME",
+	Short: "Resets a local network to a named snapshot",
+	Args:  cobra.ExactArgs(1),
+}
+
+var snapshotsCmd = &cobra.Command{
+	Use:   "snapshots",
+	Short: "Lists the snapshots of a local network",
+	Args:  cobra.NoArgs,
+}
+
+func init() {
+	snapshotDir := addDirFlag(snapshotCmd)
+	snapshotCmd.Run = func(cmd *cobra.Command, args []string) {
+		checkDir(*snapshotDir)
+		ctx := context.Background()
+		localnetwork.ReadLocalNetwork(ctx, *snapshotDir).Snapshot(ctx, args[0])
+		cmd.Println("Saved snapshot", args[0])
+	}
+
+	restoreDir := addDirFlag(restoreCmd)
+	restoreCmd.Run = func(cmd *cobra.Command, args []string) {
+		checkDir(*restoreDir)
+		ctx := context.Background()
+		localnetwork.ReadLocalNetwork(ctx, *restoreDir).RestoreSnapshot(ctx, args[0])
+		cmd.Println("Restored snapshot", args[0])
+	}
+
+	snapshotsDir := addDirFlag(snapshotsCmd)
+	snapshotsCmd.Run = func(cmd *cobra.Command, _ []string) {
+		checkDir(*snapshotsDir)
+		names, err := localnetwork.Snapshots(*snapshotsDir)
+		cobra.CheckErr(err)
+		for _, name := range names {
+			cmd.Println(name)
+		}
+	}
+
+	rootCmd.AddCommand(snapshotCmd, restoreCmd, snapshotsCmd)
+}
@@ -0,0 +1,41 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+
+	localnetwork "github.com/ava-labs/icm-contracts/tests/network"
+	"github.com/spf13/cobra"
+)
+
+var startCmd = &cobra.Command{
+	Use:   "start TOPOLOGY_FILE",
+	Short: "Starts a local network from a topology file and leaves it running",
+	Long: `Given a YAML or JSON topology file, this command starts the network and deploys
+the contracts it describes. The network keeps running after the command exits, and
+its state is saved under its directory so that it can be reattached.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		topology, err := localnetwork.LoadTopology(args[0])
+		cobra.CheckErr(err)
+
+		deployment := localnetwork.DeployTopology(context.Background(), topology)
+		deployment.Network.SaveTeleporterInfo(deployment.Teleporter)
+		for name, pair := range deployment.ICTTPairs {
+			cmd.Printf(
+				"ICTT pair %s: token %s, home %s, remote %s\n",
+				name,
+				pair.Token,
+				pair.TokenHome,
+				pair.TokenRemote,
+			)
+		}
+		cmd.Printf("Network started, reattach with %s=%s\n", localnetwork.LocalNetworkDirEnvVar, deployment.Network.Dir())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(startCmd)
+}
@@ -0,0 +1,29 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/tests/fixture/tmpnet"
+	"github.com/spf13/cobra"
+)
+
+var stopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stops the nodes of a local network",
+	Long: `Stops the nodes of a local network. Its state is kept, and the nodes are
+started again when the network is reattached.`,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	dir := addDirFlag(stopCmd)
+	stopCmd.Run = func(cmd *cobra.Command, _ []string) {
+		checkDir(*dir)
+		cobra.CheckErr(tmpnet.StopNetwork(context.Background(), *dir))
+		cmd.Println("Network stopped")
+	}
+	rootCmd.AddCommand(stopCmd)
+}
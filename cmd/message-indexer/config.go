@@ -0,0 +1,119 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	indexerUtils "github.com/ava-labs/icm-contracts/utils/indexer-utils"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultListenAddress = "127.0.0.1:8080"
+
+var ErrInvalidConfig = errors.New("invalid config")
+
+// Config is the YAML or JSON configuration of the indexer
+type Config struct {
+	// Database is the path of the SQLite database, relative to the config file
+	Database string `json:"database" yaml:"database"`
+	// ListenAddress is the address of the query API. Defaults to 127.0.0.1:8080.
+	ListenAddress     string        `json:"listenAddress" yaml:"listenAddress"`
+	ConfirmationDepth uint64        `json:"confirmationDepth" yaml:"confirmationDepth"`
+	PollInterval      string        `json:"pollInterval" yaml:"pollInterval"`
+	Chains            []ChainConfig `json:"chains" yaml:"chains"`
+}
+
+type ChainConfig struct {
+	BlockchainID      string `json:"blockchainID" yaml:"blockchainID"`
+	RPCURL            string `json:"rpcURL" yaml:"rpcURL"`
+	TeleporterAddress string `json:"teleporterAddress" yaml:"teleporterAddress"`
+	StartBlock        uint64 `json:"startBlock" yaml:"startBlock"`
+}
+
+// LoadConfig reads and validates the config in [fileName]
+func LoadConfig(fileName string) (*Config, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	switch filepath.Ext(fileName) {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&config)
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&config)
+	default:
+		return nil, fmt.Errorf("%w: unsupported file extension %s", ErrInvalidConfig, filepath.Ext(fileName))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	if config.Database == "" {
+		return nil, fmt.Errorf("%w: no database", ErrInvalidConfig)
+	}
+	if !filepath.IsAbs(config.Database) {
+		config.Database = filepath.Join(filepath.Dir(fileName), config.Database)
+	}
+	if config.ListenAddress == "" {
+		config.ListenAddress = defaultListenAddress
+	}
+	if config.PollInterval != "" {
+		if _, err := time.ParseDuration(config.PollInterval); err != nil {
+			return nil, fmt.Errorf("%w: pollInterval: %w", ErrInvalidConfig, err)
+		}
+	}
+	if len(config.Chains) == 0 {
+		return nil, fmt.Errorf("%w: no chains", ErrInvalidConfig)
+	}
+	for _, chain := range config.Chains {
+		if _, err := ids.FromString(chain.BlockchainID); err != nil {
+			return nil, fmt.Errorf("%w: blockchainID %s: %w", ErrInvalidConfig, chain.BlockchainID, err)
+		}
+		if !common.IsHexAddress(chain.TeleporterAddress) {
+			return nil, fmt.Errorf("%w: teleporterAddress %s", ErrInvalidConfig, chain.TeleporterAddress)
+		}
+		if chain.RPCURL == "" {
+			return nil, fmt.Errorf("%w: no rpcURL for %s", ErrInvalidConfig, chain.BlockchainID)
+		}
+	}
+	return &config, nil
+}
+
+// IndexerConfig connects to the chains of [c], and returns the corresponding indexer config
+func (c *Config) IndexerConfig() (indexerUtils.Config, error) {
+	config := indexerUtils.Config{ConfirmationDepth: c.ConfirmationDepth}
+	if c.PollInterval != "" {
+		config.PollInterval, _ = time.ParseDuration(c.PollInterval)
+	}
+	for _, chain := range c.Chains {
+		client, err := ethclient.Dial(chain.RPCURL)
+		if err != nil {
+			return config, fmt.Errorf("failed to connect to %s: %w", chain.RPCURL, err)
+		}
+		blockchainID, _ := ids.FromString(chain.BlockchainID)
+		config.Chains = append(config.Chains, indexerUtils.Chain{
+			BlockchainID:      blockchainID,
+			Client:            client,
+			TeleporterAddress: common.HexToAddress(chain.TeleporterAddress),
+			StartBlock:        chain.StartBlock,
+		})
+	}
+	return config, nil
+}
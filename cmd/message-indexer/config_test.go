@@ -0,0 +1,68 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testChain = `
+    blockchainID: 2q9e4r6Mu3U68nU1fYjgbR6JvwrRx36CohpAX5UQxse55x1Q5
+    rpcURL: http://127.0.0.1:9650/ext/bc/C/rpc
+    teleporterAddress: "0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf"`
+
+func writeTestConfig(t *testing.T, name string, content string) string {
+	fileName := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(fileName, []byte(content), 0o600))
+	return fileName
+}
+
+func TestLoadConfig(t *testing.T) {
+	fileName := writeTestConfig(t, "indexer.yaml", `
+database: index.db
+pollInterval: 500ms
+chains:
+  -`+testChain+`
+    startBlock: 10
+`)
+	config, err := LoadConfig(fileName)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(filepath.Dir(fileName), "index.db"), config.Database)
+	require.Equal(t, defaultListenAddress, config.ListenAddress)
+	require.Len(t, config.Chains, 1)
+	require.Equal(t, uint64(10), config.Chains[0].StartBlock)
+
+	fileName = writeTestConfig(t, "indexer.json", `{
+		"database": "/tmp/index.db",
+		"listenAddress": ":9000",
+		"chains": [{
+			"blockchainID": "2q9e4r6Mu3U68nU1fYjgbR6JvwrRx36CohpAX5UQxse55x1Q5",
+			"rpcURL": "http://127.0.0.1:9650/ext/bc/C/rpc",
+			"teleporterAddress": "0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf"
+		}]
+	}`)
+	config, err = LoadConfig(fileName)
+	require.NoError(t, err)
+	require.Equal(t, "/tmp/index.db", config.Database)
+	require.Equal(t, ":9000", config.ListenAddress)
+
+	for name, content := range map[string]string{
+		"no database":          "chains:\n  -" + testChain,
+		"no chains":            "database: index.db",
+		"unknown field":        "database: index.db\nunknown: 1\nchains:\n  -" + testChain,
+		"invalid poll":         "database: index.db\npollInterval: soon\nchains:\n  -" + testChain,
+		"invalid blockchainID": "database: index.db\nchains:\n  - blockchainID: C\n    rpcURL: http://x",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadConfig(writeTestConfig(t, "indexer.yaml", content))
+			require.ErrorIs(t, err, ErrInvalidConfig)
+		})
+	}
+	_, err = LoadConfig(writeTestConfig(t, "indexer.toml", ""))
+	require.ErrorIs(t, err, ErrInvalidConfig)
+}
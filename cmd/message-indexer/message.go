@@ -0,0 +1,45 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+
+	indexerUtils "github.com/ava-labs/icm-contracts/utils/indexer-utils"
+	"github.com/spf13/cobra"
+)
+
+var messageCmd = &cobra.Command{
+	Use:   "message --db DATABASE MESSAGE_ID",
+	Short: "Prints the indexed state and events of a message",
+	Long: `Given a hex or CB58 encoded message ID, this command prints the state of the message
+and the events of every indexed chain that refer to it, read from the database. The
+database can be read while it is being written by the run command.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		messageID, err := indexerUtils.ParseMessageID(args[0])
+		cobra.CheckErr(err)
+		store, err := indexerUtils.OpenStore(dbPath)
+		cobra.CheckErr(err)
+		defer store.Close()
+
+		ctx := context.Background()
+		message, err := store.Message(ctx, messageID)
+		cobra.CheckErr(err)
+		events, err := store.MessageEvents(ctx, messageID)
+		cobra.CheckErr(err)
+		out, err := json.MarshalIndent(indexerUtils.MessageResponse{Message: message, Events: events}, "", "  ")
+		cobra.CheckErr(err)
+		cmd.Println(string(out))
+	},
+}
+
+var dbPath string
+
+func init() {
+	rootCmd.AddCommand(messageCmd)
+	messageCmd.Flags().StringVar(&dbPath, "db", "", "Path of the SQLite database")
+	cobra.CheckErr(messageCmd.MarkFlagRequired("db"))
+}
@@ -0,0 +1,35 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "message-indexer",
+	Short: "A service that indexes the lifecycle of Teleporter messages",
+	Long: `A service that indexes the lifecycle of Teleporter messages. The TeleporterMessenger
+events of several chains are stored in an SQLite database keyed by message ID, so that
+the state of a message can be looked up without scanning the logs of each chain.`,
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main(). It only needs to happen once to the rootCmd.
+func Execute() {
+	err := rootCmd.Execute()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+}
+
+func main() {
+	Execute()
+}
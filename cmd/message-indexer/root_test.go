@@ -0,0 +1,34 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRootCmd(t *testing.T) {
+	for _, args := range [][]string{{}, {"--help"}} {
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetErr(buf)
+		rootCmd.SetArgs(args)
+		require.NoError(t, rootCmd.Execute())
+
+		out := buf.String()
+		require.True(t, strings.HasPrefix(out, "A service that indexes the lifecycle of Teleporter messages"))
+		for _, command := range []string{"run", "message"} {
+			require.Contains(t, out, command)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"message", "0x01"})
+	require.ErrorContains(t, rootCmd.Execute(), `required flag(s) "db" not set`)
+}
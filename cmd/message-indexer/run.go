@@ -0,0 +1,78 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	indexerUtils "github.com/ava-labs/icm-contracts/utils/indexer-utils"
+	"github.com/spf13/cobra"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+var runCmd = &cobra.Command{
+	Use:   "run CONFIG_FILE",
+	Short: "Indexes the chains of a config file and serves the query API",
+	Long: `Given a YAML or JSON config file, this command indexes the TeleporterMessenger events
+of each chain into the database, following new blocks until it is interrupted. Indexing
+resumes from the last indexed block of each chain, and blocks that were reorged are rolled
+back. Messages are queried with the HTTP API served on the listen address.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := LoadConfig(args[0])
+		cobra.CheckErr(err)
+		indexerConfig, err := config.IndexerConfig()
+		cobra.CheckErr(err)
+		store, err := indexerUtils.OpenStore(config.Database)
+		cobra.CheckErr(err)
+		defer store.Close()
+		indexer, err := indexerUtils.NewIndexer(indexerConfig, store)
+		cobra.CheckErr(err)
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		server := &http.Server{
+			Addr:              config.ListenAddress,
+			Handler:           indexerUtils.NewHandler(store),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		serverErr := make(chan error, 1)
+		go func() {
+			serverErr <- server.ListenAndServe()
+		}()
+		cmd.Printf("Indexing %d chains, serving the query API on %s\n", len(indexerConfig.Chains), config.ListenAddress)
+
+		indexerErr := make(chan error, 1)
+		go func() {
+			indexerErr <- indexer.Run(ctx)
+		}()
+		select {
+		case err = <-serverErr:
+			stop()
+			<-indexerErr
+		case err = <-indexerErr:
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if shutdownErr := server.Shutdown(shutdownCtx); shutdownErr != nil {
+			cmd.PrintErrln("Failed to shut down the query API:", shutdownErr)
+		}
+		if !errors.Is(err, context.Canceled) {
+			cobra.CheckErr(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+}
@@ -0,0 +1,148 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	gasUtils "github.com/ava-labs/icm-contracts/utils/gas-utils"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+type gasLimitFlags struct {
+	rpcEndpoint            string
+	kind                   string
+	caller                 string
+	sourceBlockchainID     string
+	originSender           string
+	payload                string
+	originTokenTransferrer string
+	token                  string
+	amount                 string
+	enclosingGasLimit      uint64
+	headroomPercent        uint64
+}
+
+var gasLimitArgs gasLimitFlags
+
+var gasLimitCmd = &cobra.Command{
+	Use:   "required-gas-limit --rpc RPC_URL --caller CALLER_ADDRESS RECEIVER_ADDRESS",
+	Short: "Recommends a required gas limit for a Teleporter or ICTT send and call receiver",
+	Long: `Given a destination contract and message payload, this command simulates the call
+made to the contract when the message is delivered, and recommends the gas limit to
+send the message with, including a configurable headroom.
+
+The receiver kind selects the simulated call:
+  teleporter: ITeleporterReceiver.receiveTeleporterMessage, called by the TeleporterMessenger.
+              Use the result as TeleporterMessageInput.requiredGasLimit.
+  erc20:      IERC20SendAndCallReceiver.receiveTokens, called by the token transferrer.
+  native:     INativeSendAndCallReceiver.receiveTokens, called by the token transferrer.
+              Use the result as SendAndCallInput.recipientGasLimit.
+
+Pass --enclosing-gas-limit to check whether the gas available to the caller, such as the
+SendAndCallInput.requiredGasLimit, would starve the receiver due to the 63/64 rule.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		call, err := gasLimitArgs.receiverCall(args[0])
+		if err != nil {
+			return err
+		}
+		c, err := ethclient.Dial(gasLimitArgs.rpcEndpoint)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		advisor := gasUtils.NewRequiredGasLimitAdvisor(c, gasLimitArgs.headroomPercent)
+		advice, err := advisor.AdviseRequiredGasLimit(context.Background(), call)
+		if err != nil {
+			return err
+		}
+		adviceJson, err := json.MarshalIndent(advice, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(adviceJson))
+		if advice.Starved {
+			cmd.PrintErrf(
+				"Warning: an enclosing gas limit of %d starves the receiver, at least %d is required\n",
+				call.EnclosingGasLimit,
+				advice.MinimumCallerGas,
+			)
+		}
+		return nil
+	},
+}
+
+func (f *gasLimitFlags) receiverCall(receiver string) (gasUtils.ReceiverCall, error) {
+	kind, err := gasUtils.ParseReceiverKind(f.kind)
+	if err != nil {
+		return gasUtils.ReceiverCall{}, err
+	}
+	call := gasUtils.ReceiverCall{
+		Kind:                          kind,
+		Caller:                        common.HexToAddress(f.caller),
+		Receiver:                      common.HexToAddress(receiver),
+		OriginSenderAddress:           common.HexToAddress(f.originSender),
+		OriginTokenTransferrerAddress: common.HexToAddress(f.originTokenTransferrer),
+		Token:                         common.HexToAddress(f.token),
+		EnclosingGasLimit:             f.enclosingGasLimit,
+	}
+	if f.sourceBlockchainID != "" {
+		call.SourceBlockchainID, err = ids.FromString(f.sourceBlockchainID)
+		if err != nil {
+			return gasUtils.ReceiverCall{}, fmt.Errorf("invalid source blockchain ID: %w", err)
+		}
+	}
+	if f.payload != "" {
+		payload := f.payload
+		if !strings.HasPrefix(payload, "0x") {
+			payload = "0x" + payload
+		}
+		call.Payload, err = hexutil.Decode(payload)
+		if err != nil {
+			return gasUtils.ReceiverCall{}, fmt.Errorf("invalid payload: %w", err)
+		}
+	}
+	if kind != gasUtils.TeleporterReceiver {
+		amount, ok := new(big.Int).SetString(f.amount, 10)
+		if !ok {
+			return gasUtils.ReceiverCall{}, fmt.Errorf("invalid amount %q", f.amount)
+		}
+		call.Amount = amount
+	}
+	return call, nil
+}
+
+func init() {
+	rootCmd.AddCommand(gasLimitCmd)
+	flags := gasLimitCmd.Flags()
+	flags.StringVar(&gasLimitArgs.rpcEndpoint, "rpc", "", "RPC endpoint of the destination chain")
+	flags.StringVarP(&gasLimitArgs.kind, "kind", "k", gasUtils.TeleporterReceiver.String(),
+		"Receiver kind: teleporter, erc20 or native")
+	flags.StringVar(&gasLimitArgs.caller, "caller", "",
+		"Address of the TeleporterMessenger or token transferrer calling the receiver")
+	flags.StringVar(&gasLimitArgs.sourceBlockchainID, "source-blockchain-id", "",
+		"CB58 encoded blockchain ID the message is sent from")
+	flags.StringVar(&gasLimitArgs.originSender, "origin-sender", "", "Address of the message sender")
+	flags.StringVarP(&gasLimitArgs.payload, "payload", "p", "", "Hex encoded message or recipient payload")
+	flags.StringVar(&gasLimitArgs.originTokenTransferrer, "origin-token-transferrer", "",
+		"Address of the token transferrer the tokens are sent from")
+	flags.StringVar(&gasLimitArgs.token, "token", "", "Address of the token received, for erc20 receivers")
+	flags.StringVar(&gasLimitArgs.amount, "amount", "0", "Amount of tokens received, in wei")
+	flags.Uint64Var(&gasLimitArgs.enclosingGasLimit, "enclosing-gas-limit", 0,
+		"Gas available to the caller, checked against the 63/64 rule")
+	flags.Uint64Var(&gasLimitArgs.headroomPercent, "headroom", gasUtils.DefaultGasLimitHeadroomPercent,
+		"Percentage added to the simulated gas usage")
+	cobra.CheckErr(gasLimitCmd.MarkFlagRequired("rpc"))
+	cobra.CheckErr(gasLimitCmd.MarkFlagRequired("caller"))
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	gasUtils "github.com/ava-labs/icm-contracts/utils/gas-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasLimitCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "no args",
+			args: []string{"required-gas-limit"},
+			err:  fmt.Errorf("accepts 1 arg(s), received 0"),
+		},
+		{
+			name: "missing flags",
+			args: []string{"required-gas-limit", "0x1111111111111111111111111111111111111111"},
+			err:  fmt.Errorf("required flag(s)"),
+		},
+		{
+			name: "help",
+			args: []string{"required-gas-limit", "--help"},
+			err:  nil,
+			out:  "Given a destination contract and message payload",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestGasLimitReceiverCall(t *testing.T) {
+	sourceBlockchainID := ids.ID{1, 2, 3}
+	receiver := "0x1111111111111111111111111111111111111111"
+	flags := gasLimitFlags{
+		kind:               "native",
+		caller:             "0x2222222222222222222222222222222222222222",
+		sourceBlockchainID: sourceBlockchainID.String(),
+		payload:            "01020304",
+		amount:             "1000",
+		enclosingGasLimit:  500_000,
+	}
+	call, err := flags.receiverCall(receiver)
+	require.NoError(t, err)
+	require.Equal(t, gasUtils.NativeSendAndCallReceiver, call.Kind)
+	require.Equal(t, common.HexToAddress(receiver), call.Receiver)
+	require.Equal(t, sourceBlockchainID, call.SourceBlockchainID)
+	require.Equal(t, []byte{1, 2, 3, 4}, call.Payload)
+	require.Equal(t, big.NewInt(1000), call.Amount)
+	require.Equal(t, uint64(500_000), call.EnclosingGasLimit)
+
+	flags.kind = "unknown"
+	_, err = flags.receiverCall(receiver)
+	require.ErrorContains(t, err, "unknown receiver kind")
+
+	flags.kind = "erc20"
+	flags.amount = "not a number"
+	_, err = flags.receiverCall(receiver)
+	require.ErrorContains(t, err, "invalid amount")
+}
@@ -7,7 +7,7 @@ import (
 	"os"
 
 	"github.com/ava-labs/avalanchego/utils/logging"
-	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	abiUtils "github.com/ava-labs/icm-contracts/utils/abi-utils"
 	"github.com/ava-labs/subnet-evm/accounts/abi"
 	"github.com/spf13/cobra"
 )
@@ -59,7 +59,7 @@ func rootPreRunE(logLevelArg *string) error {
 			logging.Plain.ConsoleEncoder(),
 		),
 	)
-	abi, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	abi, err := abiUtils.ContractABI(abiUtils.TeleporterMessenger)
 	if err != nil {
 		return err
 	}
@@ -285,4 +285,55 @@ contract ValidatorSetSigTest is Test {
             payload: ""
         });
     }
+
+    // The same encodings are asserted against the Go packer in
+    // abi-bindings/go/governance/ValidatorSetSig/fuzz_test.go.
+    function testEncodeValidatorSetSigMessageCorpus() public pure {
+        ValidatorSetSigMessage memory message = ValidatorSetSigMessage({
+            targetBlockchainID: bytes32(
+                hex"1234567812345678123456781234567812345678123456781234567812345678"
+            ),
+            validatorSetSigAddress: 0x1111111111111111111111111111111111111111,
+            targetContractAddress: 0x2222222222222222222222222222222222222222,
+            nonce: 3,
+            value: 1,
+            payload: ""
+        });
+        assertEq(
+            abi.encode(message),
+            hex"0000000000000000000000000000000000000000000000000000000000000020"
+            hex"1234567812345678123456781234567812345678123456781234567812345678"
+            hex"0000000000000000000000001111111111111111111111111111111111111111"
+            hex"0000000000000000000000002222222222222222222222222222222222222222"
+            hex"0000000000000000000000000000000000000000000000000000000000000003"
+            hex"0000000000000000000000000000000000000000000000000000000000000001"
+            hex"00000000000000000000000000000000000000000000000000000000000000c0"
+            hex"0000000000000000000000000000000000000000000000000000000000000000"
+        );
+
+        message = ValidatorSetSigMessage({
+            targetBlockchainID: bytes32(
+                hex"abcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcd"
+            ),
+            validatorSetSigAddress: 0x3333333333333333333333333333333333333333,
+            targetContractAddress: 0x4444444444444444444444444444444444444444,
+            nonce: 5,
+            value: 1 ether,
+            payload: hex"a9059cbb000102030405060708090a0b0c0d0e0f101112131415161718191a1b"
+                hex"1c1d1e1f2021222324252627"
+        });
+        assertEq(
+            abi.encode(message),
+            hex"0000000000000000000000000000000000000000000000000000000000000020"
+            hex"abcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcd"
+            hex"0000000000000000000000003333333333333333333333333333333333333333"
+            hex"0000000000000000000000004444444444444444444444444444444444444444"
+            hex"0000000000000000000000000000000000000000000000000000000000000005"
+            hex"0000000000000000000000000000000000000000000000000de0b6b3a7640000"
+            hex"00000000000000000000000000000000000000000000000000000000000000c0"
+            hex"000000000000000000000000000000000000000000000000000000000000002c"
+            hex"a9059cbb000102030405060708090a0b0c0d0e0f101112131415161718191a1b"
+            hex"1c1d1e1f20212223242526270000000000000000000000000000000000000000"
+        );
+    }
 }
@@ -328,4 +328,33 @@ contract TeleporterRegistryTest is Test {
     ) internal pure returns (bytes memory) {
         return abi.encodePacked("TeleporterRegistry: ", errorMessage);
     }
+
+    // The same encodings are asserted against the Go packer in
+    // abi-bindings/go/teleporter/registry/TeleporterRegistry/fuzz_test.go.
+    function testEncodeRegistryWarpPayloadCorpus() public pure {
+        assertEq(
+            abi.encode(
+                ProtocolRegistryEntry({
+                    version: 1,
+                    protocolAddress: 0x7777777777777777777777777777777777777777
+                }),
+                0x8888888888888888888888888888888888888888
+            ),
+            hex"0000000000000000000000000000000000000000000000000000000000000001"
+            hex"0000000000000000000000007777777777777777777777777777777777777777"
+            hex"0000000000000000000000008888888888888888888888888888888888888888"
+        );
+        assertEq(
+            abi.encode(
+                ProtocolRegistryEntry({
+                    version: type(uint256).max,
+                    protocolAddress: 0x9999999999999999999999999999999999999999
+                }),
+                address(bytes20(hex"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"))
+            ),
+            hex"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"
+            hex"0000000000000000000000009999999999999999999999999999999999999999"
+            hex"000000000000000000000000aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+        );
+    }
 }
@@ -0,0 +1,159 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// SPDX-License-Identifier: Ecosystem
+
+pragma solidity 0.8.25;
+
+import {Test} from "@forge-std/Test.sol";
+import {TeleporterMessage, TeleporterMessageReceipt} from "../ITeleporterMessenger.sol";
+
+// Pins abi.encode(TeleporterMessage) to fixed byte strings. The same encodings are asserted
+// against the Go packer in abi-bindings/go/teleporter/TeleporterMessenger/fuzz_test.go, and
+// seed its fuzz targets, so any divergence between the two fails one of the test suites.
+contract EncodingCorpusTest is Test {
+    bytes32 public constant BLOCKCHAIN_ID_A =
+        bytes32(hex"1234567812345678123456781234567812345678123456781234567812345678");
+    bytes32 public constant BLOCKCHAIN_ID_B =
+        bytes32(hex"abcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcd");
+
+    function testEncodeEmptyMessage() public pure {
+        TeleporterMessage memory message = TeleporterMessage({
+            messageNonce: 1,
+            originSenderAddress: 0x1111111111111111111111111111111111111111,
+            destinationBlockchainID: BLOCKCHAIN_ID_A,
+            destinationAddress: 0x2222222222222222222222222222222222222222,
+            requiredGasLimit: 100_000,
+            allowedRelayerAddresses: new address[](0),
+            receipts: new TeleporterMessageReceipt[](0),
+            message: ""
+        });
+        assertEq(
+            abi.encode(message),
+            hex"0000000000000000000000000000000000000000000000000000000000000020"
+            hex"0000000000000000000000000000000000000000000000000000000000000001"
+            hex"0000000000000000000000001111111111111111111111111111111111111111"
+            hex"1234567812345678123456781234567812345678123456781234567812345678"
+            hex"0000000000000000000000002222222222222222222222222222222222222222"
+            hex"00000000000000000000000000000000000000000000000000000000000186a0"
+            hex"0000000000000000000000000000000000000000000000000000000000000100"
+            hex"0000000000000000000000000000000000000000000000000000000000000120"
+            hex"0000000000000000000000000000000000000000000000000000000000000140"
+            hex"0000000000000000000000000000000000000000000000000000000000000000"
+            hex"0000000000000000000000000000000000000000000000000000000000000000"
+            hex"0000000000000000000000000000000000000000000000000000000000000000"
+        );
+    }
+
+    function testEncodeReceiptsAndRelayers() public pure {
+        address[] memory allowedRelayerAddresses = new address[](2);
+        allowedRelayerAddresses[0] = 0x5555555555555555555555555555555555555555;
+        allowedRelayerAddresses[1] = 0x6666666666666666666666666666666666666666;
+        TeleporterMessageReceipt[] memory receipts = new TeleporterMessageReceipt[](2);
+        receipts[0] = TeleporterMessageReceipt({
+            receivedMessageNonce: 7,
+            relayerRewardAddress: 0x7777777777777777777777777777777777777777
+        });
+        receipts[1] = TeleporterMessageReceipt({
+            receivedMessageNonce: 8,
+            relayerRewardAddress: 0x8888888888888888888888888888888888888888
+        });
+        TeleporterMessage memory message = TeleporterMessage({
+            messageNonce: 42,
+            originSenderAddress: 0x3333333333333333333333333333333333333333,
+            destinationBlockchainID: BLOCKCHAIN_ID_B,
+            destinationAddress: 0x4444444444444444444444444444444444444444,
+            requiredGasLimit: 300_000,
+            allowedRelayerAddresses: allowedRelayerAddresses,
+            receipts: receipts,
+            message: hex"deadbeef"
+        });
+        assertEq(
+            abi.encode(message),
+            hex"0000000000000000000000000000000000000000000000000000000000000020"
+            hex"000000000000000000000000000000000000000000000000000000000000002a"
+            hex"0000000000000000000000003333333333333333333333333333333333333333"
+            hex"abcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcd"
+            hex"0000000000000000000000004444444444444444444444444444444444444444"
+            hex"00000000000000000000000000000000000000000000000000000000000493e0"
+            hex"0000000000000000000000000000000000000000000000000000000000000100"
+            hex"0000000000000000000000000000000000000000000000000000000000000160"
+            hex"0000000000000000000000000000000000000000000000000000000000000200"
+            hex"0000000000000000000000000000000000000000000000000000000000000002"
+            hex"0000000000000000000000005555555555555555555555555555555555555555"
+            hex"0000000000000000000000006666666666666666666666666666666666666666"
+            hex"0000000000000000000000000000000000000000000000000000000000000002"
+            hex"0000000000000000000000000000000000000000000000000000000000000007"
+            hex"0000000000000000000000007777777777777777777777777777777777777777"
+            hex"0000000000000000000000000000000000000000000000000000000000000008"
+            hex"0000000000000000000000008888888888888888888888888888888888888888"
+            hex"0000000000000000000000000000000000000000000000000000000000000004"
+            hex"deadbeef00000000000000000000000000000000000000000000000000000000"
+        );
+    }
+
+    function testEncodeMaxValues() public pure {
+        address[] memory allowedRelayerAddresses = new address[](1);
+        allowedRelayerAddresses[0] = address(bytes20(hex"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"));
+        TeleporterMessageReceipt[] memory receipts = new TeleporterMessageReceipt[](1);
+        receipts[0] = TeleporterMessageReceipt({
+            receivedMessageNonce: type(uint256).max,
+            relayerRewardAddress: address(bytes20(hex"cccccccccccccccccccccccccccccccccccccccc"))
+        });
+        TeleporterMessage memory message = TeleporterMessage({
+            messageNonce: type(uint256).max,
+            originSenderAddress: 0x9999999999999999999999999999999999999999,
+            destinationBlockchainID: BLOCKCHAIN_ID_A,
+            destinationAddress: address(bytes20(hex"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")),
+            requiredGasLimit: type(uint256).max,
+            allowedRelayerAddresses: allowedRelayerAddresses,
+            receipts: receipts,
+            message: hex"0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20" hex"21"
+        });
+        assertEq(
+            abi.encode(message),
+            hex"0000000000000000000000000000000000000000000000000000000000000020"
+            hex"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"
+            hex"0000000000000000000000009999999999999999999999999999999999999999"
+            hex"1234567812345678123456781234567812345678123456781234567812345678"
+            hex"000000000000000000000000aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+            hex"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"
+            hex"0000000000000000000000000000000000000000000000000000000000000100"
+            hex"0000000000000000000000000000000000000000000000000000000000000140"
+            hex"00000000000000000000000000000000000000000000000000000000000001a0"
+            hex"0000000000000000000000000000000000000000000000000000000000000001"
+            hex"000000000000000000000000bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+            hex"0000000000000000000000000000000000000000000000000000000000000001"
+            hex"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"
+            hex"000000000000000000000000cccccccccccccccccccccccccccccccccccccccc"
+            hex"0000000000000000000000000000000000000000000000000000000000000021"
+            hex"0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"
+            hex"2100000000000000000000000000000000000000000000000000000000000000"
+        );
+    }
+
+    function testDecodeMatchesEncode() public pure {
+        bytes memory encoding =
+            hex"0000000000000000000000000000000000000000000000000000000000000020"
+            hex"000000000000000000000000000000000000000000000000000000000000002a"
+            hex"0000000000000000000000003333333333333333333333333333333333333333"
+            hex"abcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcd"
+            hex"0000000000000000000000004444444444444444444444444444444444444444"
+            hex"00000000000000000000000000000000000000000000000000000000000493e0"
+            hex"0000000000000000000000000000000000000000000000000000000000000100"
+            hex"0000000000000000000000000000000000000000000000000000000000000160"
+            hex"0000000000000000000000000000000000000000000000000000000000000200"
+            hex"0000000000000000000000000000000000000000000000000000000000000002"
+            hex"0000000000000000000000005555555555555555555555555555555555555555"
+            hex"0000000000000000000000006666666666666666666666666666666666666666"
+            hex"0000000000000000000000000000000000000000000000000000000000000002"
+            hex"0000000000000000000000000000000000000000000000000000000000000007"
+            hex"0000000000000000000000007777777777777777777777777777777777777777"
+            hex"0000000000000000000000000000000000000000000000000000000000000008"
+            hex"0000000000000000000000008888888888888888888888888888888888888888"
+            hex"0000000000000000000000000000000000000000000000000000000000000004"
+            hex"deadbeef00000000000000000000000000000000000000000000000000000000";
+        TeleporterMessage memory message = abi.decode(encoding, (TeleporterMessage));
+        assertEq(abi.encode(message), encoding);
+    }
+}
@@ -0,0 +1,60 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// SPDX-License-Identifier: Ecosystem
+
+pragma solidity 0.8.25;
+
+import {Test} from "@forge-std/Test.sol";
+import {stdError} from "@forge-std/StdError.sol";
+import {TokenScalingUtils} from "@utilities/TokenScalingUtils.sol";
+
+contract TokenScalingUtilsTests is Test {
+    // The same values are asserted against the Go implementation in
+    // utils/ictt-utils/token_scaling_test.go.
+    function testTokenScalingCorpus() public {
+        assertEq(TokenScalingUtils.applyTokenScale(1e12, true, 1_500_000), 1_500_000e12);
+        assertEq(TokenScalingUtils.removeTokenScale(1e12, true, 1_500_000), 0);
+
+        assertEq(
+            TokenScalingUtils.applyTokenScale(1e12, false, 1_234_567_890_123_456_789), 1_234_567
+        );
+        assertEq(
+            TokenScalingUtils.removeTokenScale(1e12, false, 1_234_567_890_123_456_789),
+            1_234_567_890_123_456_789e12
+        );
+
+        assertEq(TokenScalingUtils.applyTokenScale(1, false, 42), 42);
+        assertEq(TokenScalingUtils.removeTokenScale(1, false, 42), 42);
+
+        assertEq(
+            TokenScalingUtils.removeTokenScale(1e18, true, type(uint256).max),
+            115_792_089_237_316_195_423_570_985_008_687_907_853_269_984_665_640_564_039_457
+        );
+        vm.expectRevert(stdError.arithmeticError);
+        this.applyTokenScale(1e18, true, type(uint256).max);
+    }
+
+    function testDeriveTokenMultiplierValues() public pure {
+        (uint256 tokenMultiplier, bool multiplyOnRemote) =
+            TokenScalingUtils.deriveTokenMultiplierValues(6, 18);
+        assertEq(tokenMultiplier, 1e12);
+        assertTrue(multiplyOnRemote);
+
+        (tokenMultiplier, multiplyOnRemote) = TokenScalingUtils.deriveTokenMultiplierValues(18, 6);
+        assertEq(tokenMultiplier, 1e12);
+        assertFalse(multiplyOnRemote);
+
+        (tokenMultiplier, multiplyOnRemote) = TokenScalingUtils.deriveTokenMultiplierValues(18, 18);
+        assertEq(tokenMultiplier, 1);
+        assertFalse(multiplyOnRemote);
+    }
+
+    function applyTokenScale(
+        uint256 tokenMultiplier,
+        bool multiplyOnRemote,
+        uint256 homeTokenAmount
+    ) external pure returns (uint256) {
+        return TokenScalingUtils.applyTokenScale(tokenMultiplier, multiplyOnRemote, homeTokenAmount);
+    }
+}
@@ -353,4 +353,59 @@ contract ValidatorMessagesTest is Test {
         );
         return packed;
     }
+
+    // The same encodings are asserted against the Go packer and the P-Chain codec in
+    // tests/utils/conversion_data_test.go.
+    function testPackConversionDataCorpus() public pure {
+        bytes32 subnetID =
+            bytes32(hex"1234567812345678123456781234567812345678123456781234567812345678");
+        bytes32 managerBlockchainID =
+            bytes32(hex"abcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcd");
+        bytes memory packed = ValidatorMessages.packConversionData(
+            ConversionData({
+                subnetID: subnetID,
+                validatorManagerBlockchainID: managerBlockchainID,
+                validatorManagerAddress: 0x1111111111111111111111111111111111111111,
+                initialValidators: new InitialValidator[](0)
+            })
+        );
+        assertEq(
+            packed,
+            hex"0000123456781234567812345678123456781234567812345678123456781234"
+            hex"5678abcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdef"
+            hex"abcd00000014111111111111111111111111111111111111111100000000"
+        );
+
+        InitialValidator[] memory initialValidators = new InitialValidator[](2);
+        initialValidators[0] = InitialValidator({
+            nodeID: hex"1234567812345678123456781234567812345678",
+            blsPublicKey: DEFAULT_BLS_PUBLIC_KEY,
+            weight: 100
+        });
+        initialValidators[1] = InitialValidator({
+            nodeID: hex"7856341278563412785634127856341278563412",
+            blsPublicKey: hex"785634127856341278563412785634127856341278563412"
+                hex"785634127856341278563412785634127856341278563412",
+            weight: type(uint64).max
+        });
+        packed = ValidatorMessages.packConversionData(
+            ConversionData({
+                subnetID: subnetID,
+                validatorManagerBlockchainID: managerBlockchainID,
+                validatorManagerAddress: 0x2222222222222222222222222222222222222222,
+                initialValidators: initialValidators
+            })
+        );
+        assertEq(
+            packed,
+            hex"0000123456781234567812345678123456781234567812345678123456781234"
+            hex"5678abcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdef"
+            hex"abcd000000142222222222222222222222222222222222222222000000020000"
+            hex"0014123456781234567812345678123456781234567812345678123456781234"
+            hex"5678123456781234567812345678123456781234567812345678123456781234"
+            hex"5678123456780000000000000064000000147856341278563412785634127856"
+            hex"3412785634127856341278563412785634127856341278563412785634127856"
+            hex"34127856341278563412785634127856341278563412ffffffffffffffff"
+        );
+    }
 }
@@ -20,13 +20,15 @@ function printUsage() {
 Arguments:
     --components component1,component2            Comma separated list of test suites to run. Valid components are:
                                                   $(echo $valid_components | tr ' ' '\n' | sort | tr '\n' ' ')
-                                                  (default: all)
+                                                  (default: all except $opt_in_components)
 Options:
     --help                                        Print this help message
 EOF
 }
 
 valid_components=$(ls -d $ICM_CONTRACTS_PATH/tests/suites/*/ | xargs -n 1 basename)
+# Suites that are only run when requested with --components
+opt_in_components="topology"
 components=
 
 while [ $# -gt 0 ]; do
@@ -46,9 +48,13 @@ while [ $# -gt 0 ]; do
     shift
 done
 
-# Run all suites if no component is provided
+# Run all suites except the opt-in ones if no component is provided
 if [ -z "$components" ]; then
-    components=$valid_components
+    for component in $valid_components; do
+        if [[ " $opt_in_components " != *" $component "* ]]; then
+            components="$components $component"
+        fi
+    done
 fi
 
 # Exit if invalid component is provided
@@ -100,11 +106,25 @@ for component in $(echo $components | tr ',' ' '); do
 
     echo "Running e2e tests for $component"
 
-    RUN_E2E=true SIG_AGG_PATH=$ICM_SERVICES_BUILD_PATH/signature-aggregator ./tests/suites/$component/$component.test \
-    --ginkgo.vv \
-    --ginkgo.label-filter=${GINKGO_LABEL_FILTER:-""} \
-    --ginkgo.focus=${GINKGO_FOCUS:-""} \
-    --ginkgo.trace
+    # Only the teleporter suite isolates its specs from each other, so only it runs in parallel.
+    # Ginkgo runs the parallel processes from the suite's directory, so the suite resolves its
+    # files against ICM_CONTRACTS_PATH rather than the working directory.
+    if [ -n "${GINKGO_PROCS:-}" ] && [ "$component" = "teleporter" ]; then
+        # Run the specs across parallel processes
+        ICM_CONTRACTS_PATH=$ICM_CONTRACTS_PATH RUN_E2E=true SIG_AGG_PATH=$ICM_SERVICES_BUILD_PATH/signature-aggregator ginkgo \
+        --procs=$GINKGO_PROCS \
+        -vv \
+        --label-filter=${GINKGO_LABEL_FILTER:-""} \
+        --focus=${GINKGO_FOCUS:-""} \
+        --trace \
+        ./tests/suites/$component/$component.test
+    else
+        RUN_E2E=true SIG_AGG_PATH=$ICM_SERVICES_BUILD_PATH/signature-aggregator ./tests/suites/$component/$component.test \
+        --ginkgo.vv \
+        --ginkgo.label-filter=${GINKGO_LABEL_FILTER:-""} \
+        --ginkgo.focus=${GINKGO_FOCUS:-""} \
+        --ginkgo.trace
+    fi
 
     echo "$component e2e tests passed"
     echo ""
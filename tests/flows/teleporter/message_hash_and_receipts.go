@@ -0,0 +1,110 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleporter
+
+import (
+	"context"
+	"math/big"
+
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	localnetwork "github.com/ava-labs/icm-contracts/tests/network"
+	"github.com/ava-labs/icm-contracts/tests/utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	. "github.com/onsi/gomega"
+)
+
+// Checks the Go message hash and receipt queue models against the TeleporterMessenger contract
+func MessageHashAndReceiptQueue(network *localnetwork.LocalNetwork, teleporter utils.TeleporterTestInfo) {
+	l1AInfo := network.GetPrimaryNetworkInfo()
+	l1BInfo, _ := network.GetTwoL1s()
+	l1ATeleporterMessenger := teleporter.TeleporterMessenger(l1AInfo)
+	l1BTeleporterMessenger := teleporter.TeleporterMessenger(l1BInfo)
+	_, fundedKey := network.GetFundedAccountInfo()
+	ctx := context.Background()
+
+	aggregator := network.GetSignatureAggregator()
+	defer aggregator.Shutdown()
+
+	sendCrossChainMessageInput := teleportermessenger.TeleporterMessageInput{
+		DestinationBlockchainID: l1BInfo.BlockchainID,
+		DestinationAddress:      common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		FeeInfo: teleportermessenger.TeleporterFeeInfo{
+			FeeTokenAddress: common.Address{},
+			Amount:          big.NewInt(0),
+		},
+		RequiredGasLimit:        big.NewInt(1),
+		AllowedRelayerAddresses: []common.Address{},
+		Message:                 []byte{1, 2, 3, 4},
+	}
+
+	// Model the receipt queue on L1 B for messages received from L1 A, starting from the on-chain state.
+	receiptQueue, err := teleportermessenger.NewReceiptQueueFromContract(
+		&bind.CallOpts{},
+		&l1BTeleporterMessenger.TeleporterMessengerCaller,
+		l1AInfo.BlockchainID,
+	)
+	Expect(err).Should(BeNil())
+
+	// Send more messages than fit in a single batch of receipts so that batching is exercised.
+	for i := 0; i < teleportermessenger.MaximumReceiptCount+2; i++ {
+		receipt, messageID := utils.SendCrossChainMessageAndWaitForAcceptance(
+			ctx, l1ATeleporterMessenger, l1AInfo, l1BInfo, sendCrossChainMessageInput, fundedKey,
+		)
+		sendEvent, err := utils.GetEventFromLogs(receipt.Logs, l1ATeleporterMessenger.ParseSendCrossChainMessage)
+		Expect(err).Should(BeNil())
+
+		// The stored message hash must match the Go computation.
+		expectedHash, err := l1ATeleporterMessenger.GetMessageHash(&bind.CallOpts{}, messageID)
+		Expect(err).Should(BeNil())
+		calculatedHash, err := teleportermessenger.CalculateMessageHash(sendEvent.Message)
+		Expect(err).Should(BeNil())
+		Expect(calculatedHash[:]).Should(Equal(expectedHash[:]))
+
+		deliveryReceipt := teleporter.RelayTeleporterMessage(
+			ctx, receipt, l1AInfo, l1BInfo, true, fundedKey, nil, aggregator,
+		)
+		receiveEvent, err := utils.GetEventFromLogs(
+			deliveryReceipt.Logs,
+			l1BTeleporterMessenger.ParseReceiveCrossChainMessage,
+		)
+		Expect(err).Should(BeNil())
+		receiptQueue.EnqueueReceivedMessage(receiveEvent.Message, receiveEvent.RewardRedeemer)
+	}
+	checkReceiptQueue(receiptQueue, l1BTeleporterMessenger, l1AInfo.BlockchainID)
+
+	// Each message from L1 B to L1 A must carry exactly the receipts predicted by the model.
+	sendCrossChainMessageInput.DestinationBlockchainID = l1AInfo.BlockchainID
+	for receiptQueue.Size() > 0 {
+		expectedReceipts := receiptQueue.GetOutstandingReceiptsToSend()
+		receipt, _ := utils.SendCrossChainMessageAndWaitForAcceptance(
+			ctx, l1BTeleporterMessenger, l1BInfo, l1AInfo, sendCrossChainMessageInput, fundedKey,
+		)
+		sendEvent, err := utils.GetEventFromLogs(receipt.Logs, l1BTeleporterMessenger.ParseSendCrossChainMessage)
+		Expect(err).Should(BeNil())
+		Expect(sendEvent.Message.Receipts).Should(Equal(expectedReceipts))
+		checkReceiptQueue(receiptQueue, l1BTeleporterMessenger, l1AInfo.BlockchainID)
+	}
+}
+
+func checkReceiptQueue(
+	receiptQueue *teleportermessenger.ReceiptQueue,
+	teleporterMessenger *teleportermessenger.TeleporterMessenger,
+	sourceBlockchainID [32]byte,
+) {
+	size, err := teleporterMessenger.GetReceiptQueueSize(&bind.CallOpts{}, sourceBlockchainID)
+	Expect(err).Should(BeNil())
+	Expect(size.Int64()).Should(Equal(int64(receiptQueue.Size())))
+	for i := 0; i < receiptQueue.Size(); i++ {
+		expectedReceipt, err := teleporterMessenger.GetReceiptAtIndex(
+			&bind.CallOpts{},
+			sourceBlockchainID,
+			big.NewInt(int64(i)),
+		)
+		Expect(err).Should(BeNil())
+		modelReceipt, err := receiptQueue.GetReceiptAtIndex(i)
+		Expect(err).Should(BeNil())
+		Expect(modelReceipt).Should(Equal(expectedReceipt))
+	}
+}
@@ -10,9 +10,7 @@ import (
 	. "github.com/onsi/gomega"
 )
 
-const (
-	teleporterByteCodeFile = "./out/TeleporterMessenger.sol/TeleporterMessenger.json"
-)
+var teleporterByteCodeFile = utils.RepoPath("out/TeleporterMessenger.sol/TeleporterMessenger.json")
 
 func TeleporterRegistry(network *localnetwork.LocalNetwork, teleporter utils.TeleporterTestInfo) {
 	// Deploy dApp on both chains that use Teleporter Registry
@@ -40,4 +40,21 @@ func CalculateMessageID(network *localnetwork.LocalNetwork, teleporter utils.Tel
 	)
 	Expect(err).Should(BeNil())
 	Expect(ids.ID(expectedMessageID)).Should(Equal(calculatedMessageID))
+
+	// The next message ID predicted from the messenger's nonce matches the contract
+	nextMessageID, err := teleporterutils.NextMessageID(
+		&bind.CallOpts{},
+		teleporter.TeleporterMessenger(l1Info),
+		ids.ID(destinationBlockchainID),
+	)
+	Expect(err).Should(BeNil())
+	predictedMessageIDs, err := teleporterutils.PredictMessageIDs(
+		&bind.CallOpts{},
+		teleporterContractAddress,
+		teleporter.TeleporterMessenger(l1Info),
+		ids.ID(destinationBlockchainID),
+		1,
+	)
+	Expect(err).Should(BeNil())
+	Expect(predictedMessageIDs).Should(Equal([]ids.ID{nextMessageID}))
 }
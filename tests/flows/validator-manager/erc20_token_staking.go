@@ -11,6 +11,7 @@ import (
 	exampleerc20 "github.com/ava-labs/icm-contracts/abi-bindings/go/mocks/ExampleERC20"
 	erc20tokenstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/ERC20TokenStakingManager"
 	istakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/interfaces/IStakingManager"
+	"github.com/ava-labs/icm-contracts/tests/interfaces"
 	localnetwork "github.com/ava-labs/icm-contracts/tests/network"
 	"github.com/ava-labs/icm-contracts/tests/utils"
 	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
@@ -61,12 +62,14 @@ func ERC20TokenStakingManager(network *localnetwork.LocalNetwork) {
 	erc20, err := exampleerc20.NewExampleERC20(erc20Address, l1AInfo.RPCClient)
 	Expect(err).Should(BeNil())
 
-	signatureAggregator := utils.NewSignatureAggregator(
+	signatureAggregator, err := utils.NewSignatureAggregator(
 		cChainInfo.NodeURIs[0],
-		[]ids.ID{
-			l1AInfo.SubnetID,
+		network.GetNetworkID(),
+		[]interfaces.L1TestInfo{
+			l1AInfo,
 		},
 	)
+	Expect(err).Should(BeNil())
 	defer signatureAggregator.Shutdown()
 
 	//
@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/units"
 	nativetokenstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/NativeTokenStakingManager"
 	istakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/interfaces/IStakingManager"
+	"github.com/ava-labs/icm-contracts/tests/interfaces"
 	localnetwork "github.com/ava-labs/icm-contracts/tests/network"
 	"github.com/ava-labs/icm-contracts/tests/utils"
 	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
@@ -57,12 +58,14 @@ func NativeTokenStakingManager(network *localnetwork.LocalNetwork) {
 	Expect(err).Should(BeNil())
 	utils.AddNativeMinterAdmin(ctx, l1AInfo, fundedKey, stakingManagerProxy.Address)
 
-	signatureAggregator := utils.NewSignatureAggregator(
+	signatureAggregator, err := utils.NewSignatureAggregator(
 		cChainInfo.NodeURIs[0],
-		[]ids.ID{
-			l1AInfo.SubnetID,
+		network.GetNetworkID(),
+		[]interfaces.L1TestInfo{
+			l1AInfo,
 		},
 	)
+	Expect(err).Should(BeNil())
 	defer signatureAggregator.Shutdown()
 
 	//
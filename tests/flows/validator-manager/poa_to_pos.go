@@ -5,12 +5,12 @@ import (
 	"math/big"
 	"time"
 
-	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/units"
 	ownableupgradeable "github.com/ava-labs/icm-contracts/abi-bindings/go/OwnableUpgradeable"
 	nativetokenstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/NativeTokenStakingManager"
 	validatormanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/ValidatorManager"
 	istakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/interfaces/IStakingManager"
+	"github.com/ava-labs/icm-contracts/tests/interfaces"
 	localnetwork "github.com/ava-labs/icm-contracts/tests/network"
 	"github.com/ava-labs/icm-contracts/tests/utils"
 	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
@@ -74,12 +74,14 @@ func PoAMigrationToPoS(network *localnetwork.LocalNetwork) {
 	validatorManager, err := validatormanager.NewValidatorManager(validatorManagerProxy.Address, l1AInfo.RPCClient)
 	Expect(err).Should(BeNil())
 
-	signatureAggregator := utils.NewSignatureAggregator(
+	signatureAggregator, err := utils.NewSignatureAggregator(
 		cChainInfo.NodeURIs[0],
-		[]ids.ID{
-			l1AInfo.SubnetID,
+		network.GetNetworkID(),
+		[]interfaces.L1TestInfo{
+			l1AInfo,
 		},
 	)
+	Expect(err).Should(BeNil())
 	defer signatureAggregator.Shutdown()
 
 	//
@@ -47,7 +47,7 @@ type ProxyAddress struct {
 
 // Implements Network, pointing to the network setup in local_network_setup.go
 type LocalNetwork struct {
-	tmpnet.Network
+	*tmpnet.Network
 
 	extraNodes                      []*tmpnet.Node // to add as more L1 validators in the tests
 	primaryNetworkValidators        []*tmpnet.Node
@@ -56,6 +56,9 @@ type LocalNetwork struct {
 	validatorManagerSpecializations map[ids.ID]ProxyAddress
 	logger                          logging.Logger
 	deployedL1Specs                 map[string]L1Spec
+	teleporter                      []persistedTeleporterInfo
+	// fundedKey overrides the EVM funded account of views of the network
+	fundedKey *ecdsa.PrivateKey
 }
 
 const (
@@ -73,6 +76,8 @@ type L1Spec struct {
 	TeleporterDeployedBytecode   string
 	TeleporterDeployerAddress    common.Address
 	RequirePrimaryNetworkSigners bool
+	// Overrides the genesis template passed to NewLocalNetwork
+	GenesisTemplateFile string
 }
 
 func NewLocalNetwork(
@@ -109,10 +114,14 @@ func NewLocalNetwork(
 		initialVdrNodes := subnetEvmTestUtils.NewTmpnetNodes(l1Spec.NodeCount)
 		extraNodes = append(extraNodes, initialVdrNodes...)
 
+		genesisTemplateFile := warpGenesisTemplateFile
+		if l1Spec.GenesisTemplateFile != "" {
+			genesisTemplateFile = l1Spec.GenesisTemplateFile
+		}
 		l1 := subnetEvmTestUtils.NewTmpnetSubnet(
 			l1Spec.Name,
 			utils.InstantiateGenesisTemplate(
-				warpGenesisTemplateFile,
+				genesisTemplateFile,
 				l1Spec.EVMChainID,
 				l1Spec.TeleporterContractAddress,
 				l1Spec.TeleporterDeployedBytecode,
@@ -173,7 +182,7 @@ func NewLocalNetwork(
 	primaryNetworkValidators = append(primaryNetworkValidators, network.Nodes...)
 
 	localNetwork := &LocalNetwork{
-		Network:                         *network,
+		Network:                         network,
 		extraNodes:                      extraNodes,
 		globalFundedKey:                 globalFundedKey,
 		primaryNetworkValidators:        primaryNetworkValidators,
@@ -182,6 +191,7 @@ func NewLocalNetwork(
 		logger:                          logger,
 		deployedL1Specs:                 deployedL1Specs,
 	}
+	localNetwork.SaveState()
 
 	return localNetwork
 }
@@ -325,6 +335,7 @@ func (n *LocalNetwork) ConvertSubnet(
 	}
 	utils.PChainProposerVMWorkaround(pChainWallet)
 	utils.AdvanceProposerVM(ctx, l1, senderKey, 5)
+	n.SaveState()
 
 	return nodes, validationIDs
 }
@@ -373,14 +384,13 @@ func (n *LocalNetwork) GetValidatorManager(subnetID ids.ID) (ProxyAddress, Proxy
 }
 
 func (n *LocalNetwork) GetSignatureAggregator() *utils.SignatureAggregator {
-	var subnetIDs []ids.ID
-	for _, l1 := range n.GetL1Infos() {
-		subnetIDs = append(subnetIDs, l1.SubnetID)
-	}
-	return utils.NewSignatureAggregator(
+	signatureAggregator, err := utils.NewSignatureAggregator(
 		n.GetPrimaryNetworkInfo().NodeURIs[0],
-		subnetIDs,
+		n.GetNetworkID(),
+		n.GetL1Infos(),
 	)
+	Expect(err).Should(BeNil())
+	return signatureAggregator
 }
 
 func (n *LocalNetwork) GetExtraNodes(count int) []*tmpnet.Node {
@@ -462,6 +472,18 @@ func (n *LocalNetwork) GetL1Info(subnetID ids.ID) interfaces.L1TestInfo {
 	return interfaces.L1TestInfo{}
 }
 
+// Returns the info of the L1 created from the L1Spec named [name]
+func (n *LocalNetwork) GetL1InfoByName(name string) interfaces.L1TestInfo {
+	var subnetID ids.ID
+	for _, l1 := range n.Network.Subnets {
+		if l1.Name == name {
+			subnetID = l1.SubnetID
+		}
+	}
+	Expect(subnetID).ShouldNot(Equal(ids.Empty), "unknown L1 %s", name)
+	return n.GetL1Info(subnetID)
+}
+
 // Returns all l1 info sorted in lexicographic order of L1Name.
 func (n *LocalNetwork) GetL1Infos() []interfaces.L1TestInfo {
 	l1s := make([]interfaces.L1TestInfo, len(n.Network.Subnets))
@@ -506,6 +528,9 @@ func (n *LocalNetwork) GetAllL1Infos() []interfaces.L1TestInfo {
 }
 
 func (n *LocalNetwork) GetFundedAccountInfo() (common.Address, *ecdsa.PrivateKey) {
+	if n.fundedKey != nil {
+		return crypto.PubkeyToAddress(n.fundedKey.PublicKey), n.fundedKey
+	}
 	ecdsaKey := n.globalFundedKey.ToECDSA()
 	fundedAddress := crypto.PubkeyToAddress(ecdsaKey.PublicKey)
 	return fundedAddress, ecdsaKey
@@ -0,0 +1,145 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	"github.com/ava-labs/icm-contracts/tests/interfaces"
+	"github.com/ava-labs/icm-contracts/tests/utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	// processFunds is the native balance given to the funded key of each parallel process, on
+	// every chain
+	processFunds = new(big.Int).Mul(big.NewInt(1e18), big.NewInt(10_000))
+
+	// specFunds is the native balance given to the funded key of each isolated spec, on every chain
+	specFunds = new(big.Int).Mul(big.NewInt(1e18), big.NewInt(100))
+)
+
+// DeriveKey deterministically derives a key from the network's funded key and [label]
+func (n *LocalNetwork) DeriveKey(label string) *ecdsa.PrivateKey {
+	_, fundedKey := n.GetFundedAccountInfo()
+	key, err := crypto.ToECDSA(crypto.Keccak256(crypto.FromECDSA(fundedKey), []byte(label)))
+	Expect(err).Should(BeNil())
+	return key
+}
+
+// FundKeys transfers [amount] from the network's funded key to each of [keys], on every chain
+func (n *LocalNetwork) FundKeys(ctx context.Context, amount *big.Int, keys ...*ecdsa.PrivateKey) {
+	_, fundedKey := n.GetFundedAccountInfo()
+	for _, l1 := range n.GetAllL1Infos() {
+		for _, key := range keys {
+			tx := utils.CreateNativeTransferTransaction(ctx, l1, fundedKey, utils.PrivateKeyToAddress(key), amount)
+			utils.SendTransactionAndWaitForSuccess(ctx, l1, tx)
+		}
+	}
+}
+
+// WithFundedKey returns a view of the network whose funded account is [key]. The view shares the
+// network's tmpnet.Network and deployed contracts, but validators and L1s added through it are
+// not added to [n].
+func (n *LocalNetwork) WithFundedKey(key *ecdsa.PrivateKey) *LocalNetwork {
+	return &LocalNetwork{
+		Network:                         n.Network,
+		extraNodes:                      n.extraNodes,
+		primaryNetworkValidators:        n.primaryNetworkValidators,
+		globalFundedKey:                 n.globalFundedKey,
+		validatorManagers:               n.validatorManagers,
+		validatorManagerSpecializations: n.validatorManagerSpecializations,
+		logger:                          n.logger,
+		deployedL1Specs:                 n.deployedL1Specs,
+		teleporter:                      n.teleporter,
+		fundedKey:                       key,
+	}
+}
+
+func processKeyLabel(process int) string {
+	return fmt.Sprintf("ginkgo-process-%d", process)
+}
+
+// FundParallelProcesses funds the keys of Ginkgo processes 1 to [parallelTotal]. It must be called
+// by a single process, before any process calls ForParallelProcess.
+func (n *LocalNetwork) FundParallelProcesses(ctx context.Context, parallelTotal int) {
+	keys := make([]*ecdsa.PrivateKey, 0, parallelTotal)
+	for process := 1; process <= parallelTotal; process++ {
+		keys = append(keys, n.DeriveKey(processKeyLabel(process)))
+	}
+	n.FundKeys(ctx, processFunds, keys...)
+}
+
+// ForParallelProcess returns a view of the network funded by the key of Ginkgo process [process],
+// so that processes don't race on the nonces of a shared key
+func (n *LocalNetwork) ForParallelProcess(process int) *LocalNetwork {
+	return n.WithFundedKey(n.DeriveKey(processKeyLabel(process)))
+}
+
+// NewIsolatedTeleporter deploys a new TeleporterMessenger and TeleporterRegistry to every chain,
+// and returns them with a view of the network funded by a new key derived from [label]. Flows
+// using the returned network and Teleporter deployment share no receipt queues, message nonces
+// or relayer rewards with other specs, so they can run in parallel with them.
+func (n *LocalNetwork) NewIsolatedTeleporter(
+	ctx context.Context,
+	label string,
+) (*LocalNetwork, utils.TeleporterTestInfo) {
+	key := n.DeriveKey(label)
+	n.FundKeys(ctx, specFunds, key)
+	spec := n.WithFundedKey(key)
+
+	// TeleporterMessenger only accepts messages sent by its own address on other chains, so every
+	// instance is deployed by the same key at the same nonce
+	l1s := spec.GetAllL1Infos()
+	address := utils.PrivateKeyToAddress(key)
+	var nonce uint64
+	for _, l1 := range l1s {
+		l1Nonce, err := l1.RPCClient.NonceAt(ctx, address, nil)
+		Expect(err).Should(BeNil())
+		nonce = max(nonce, l1Nonce)
+	}
+	teleporter := utils.NewTeleporterTestInfo(l1s)
+	expectedAddress := crypto.CreateAddress(address, nonce)
+	for _, l1 := range l1s {
+		advanceNonce(ctx, l1, key, nonce)
+
+		opts, err := bind.NewKeyedTransactorWithChainID(key, l1.EVMChainID)
+		Expect(err).Should(BeNil())
+		messengerAddress, tx, _, err := teleportermessenger.DeployTeleporterMessenger(opts, l1.RPCClient)
+		Expect(err).Should(BeNil())
+		utils.WaitForTransactionSuccess(ctx, l1, tx.Hash())
+		Expect(messengerAddress).Should(Equal(expectedAddress))
+
+		teleporter.SetTeleporter(messengerAddress, l1)
+		teleporter.InitializeBlockchainID(l1, key)
+		teleporter.DeployTeleporterRegistry(l1, key)
+	}
+	return spec, teleporter
+}
+
+// advanceNonce sends transfers from [key] to itself until its nonce on [l1] is [nonce]
+func advanceNonce(
+	ctx context.Context,
+	l1 interfaces.L1TestInfo,
+	key *ecdsa.PrivateKey,
+	nonce uint64,
+) {
+	address := utils.PrivateKeyToAddress(key)
+	for {
+		current, err := l1.RPCClient.NonceAt(ctx, address, nil)
+		Expect(err).Should(BeNil())
+		if current >= nonce {
+			return
+		}
+		tx := utils.CreateNativeTransferTransaction(ctx, l1, key, address, common.Big0)
+		utils.SendTransactionAndWaitForSuccess(ctx, l1, tx)
+	}
+}
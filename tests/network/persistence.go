@@ -0,0 +1,359 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	goLog "log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/tests/fixture/tmpnet"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	proxyadmin "github.com/ava-labs/icm-contracts/abi-bindings/go/ProxyAdmin"
+	"github.com/ava-labs/icm-contracts/tests/utils"
+	"github.com/ethereum/go-ethereum/common"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	// LocalNetworkDirEnvVar is the directory of a persistent network for suites to reattach to,
+	// rather than starting their own network
+	LocalNetworkDirEnvVar = "LOCAL_NETWORK_DIR"
+
+	localNetworkStateFileName = "icm-local-network.json"
+	snapshotsDirName          = "snapshots"
+)
+
+// erc1967AdminSlot is the storage slot of the admin of a TransparentUpgradeableProxy
+var erc1967AdminSlot = common.HexToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+
+// localNetworkState is the state of a LocalNetwork that tmpnet doesn't persist
+type localNetworkState struct {
+	// FundedKey is the hex encoded private key of the funded account, stored in plaintext like the
+	// pre-funded keys tmpnet stores in the network's config
+	FundedKey                       string                    `json:"fundedKey"`
+	L1Specs                         []L1Spec                  `json:"l1Specs"`
+	PrimaryNetworkValidators        []ids.NodeID              `json:"primaryNetworkValidators"`
+	ExtraNodes                      []tmpnet.FlagsMap         `json:"extraNodes"`
+	ValidatorManagers               []persistedProxyAddress   `json:"validatorManagers"`
+	ValidatorManagerSpecializations []persistedProxyAddress   `json:"validatorManagerSpecializations"`
+	Teleporter                      []persistedTeleporterInfo `json:"teleporter"`
+}
+
+type persistedProxyAddress struct {
+	SubnetID ids.ID         `json:"subnetID"`
+	Address  common.Address `json:"address"`
+	// Proxy is true if the contract is behind a TransparentUpgradeableProxy, whose admin is read
+	// from the chain when the network is reattached
+	Proxy bool `json:"proxy"`
+}
+
+type persistedTeleporterInfo struct {
+	BlockchainID               ids.ID         `json:"blockchainID"`
+	TeleporterMessengerAddress common.Address `json:"teleporterMessengerAddress"`
+	TeleporterRegistryAddress  common.Address `json:"teleporterRegistryAddress"`
+}
+
+// ReadLocalNetwork reattaches to the network persisted in [dir] by SaveState, starting its nodes if
+// they were stopped
+func ReadLocalNetwork(ctx context.Context, dir string) *LocalNetwork {
+	network, err := tmpnet.ReadNetwork(dir)
+	Expect(err).Should(BeNil())
+
+	n := &LocalNetwork{
+		Network: network,
+		logger:  logging.NewLogger("tmpnet"),
+	}
+	n.start(ctx)
+	n.readState(ctx)
+	goLog.Println("Reattached to network", n.Dir())
+	return n
+}
+
+// SaveState writes the state of the network that isn't stored by tmpnet under Dir(), so that the
+// network can be reattached with ReadLocalNetwork. The state includes the funded private key in
+// plaintext, so it is only readable by the owner of the file.
+func (n *LocalNetwork) SaveState() {
+	state := localNetworkState{
+		FundedKey:                       hex.EncodeToString(n.globalFundedKey.Bytes()),
+		ValidatorManagers:               persistProxyAddresses(n.validatorManagers),
+		ValidatorManagerSpecializations: persistProxyAddresses(n.validatorManagerSpecializations),
+		Teleporter:                      n.teleporter,
+	}
+	for _, l1Spec := range n.deployedL1Specs {
+		state.L1Specs = append(state.L1Specs, l1Spec)
+	}
+	sort.Slice(state.L1Specs, func(i, j int) bool {
+		return state.L1Specs[i].Name < state.L1Specs[j].Name
+	})
+	for _, node := range n.primaryNetworkValidators {
+		state.PrimaryNetworkValidators = append(state.PrimaryNetworkValidators, node.NodeID)
+	}
+	for _, node := range n.extraNodes {
+		state.ExtraNodes = append(state.ExtraNodes, node.Flags)
+	}
+
+	stateBytes, err := json.MarshalIndent(state, "", "  ")
+	Expect(err).Should(BeNil())
+	stateFile := filepath.Join(n.Dir(), localNetworkStateFileName)
+	Expect(os.WriteFile(stateFile, stateBytes, 0o600)).Should(BeNil())
+	// os.WriteFile keeps the mode of an existing file
+	Expect(os.Chmod(stateFile, 0o600)).Should(BeNil())
+}
+
+func persistProxyAddresses(proxyAddresses map[ids.ID]ProxyAddress) []persistedProxyAddress {
+	persisted := make([]persistedProxyAddress, 0, len(proxyAddresses))
+	for subnetID, proxyAddress := range proxyAddresses {
+		persisted = append(persisted, persistedProxyAddress{
+			SubnetID: subnetID,
+			Address:  proxyAddress.Address,
+			Proxy:    proxyAddress.ProxyAdmin != nil,
+		})
+	}
+	return persisted
+}
+
+func (n *LocalNetwork) readState(ctx context.Context) {
+	stateBytes, err := os.ReadFile(filepath.Join(n.Dir(), localNetworkStateFileName))
+	Expect(err).Should(BeNil())
+	var state localNetworkState
+	Expect(json.Unmarshal(stateBytes, &state)).Should(BeNil())
+
+	fundedKey, err := hex.DecodeString(state.FundedKey)
+	Expect(err).Should(BeNil())
+	n.globalFundedKey, err = secp256k1.ToPrivateKey(fundedKey)
+	Expect(err).Should(BeNil())
+
+	n.deployedL1Specs = make(map[string]L1Spec, len(state.L1Specs))
+	for _, l1Spec := range state.L1Specs {
+		n.deployedL1Specs[l1Spec.Name] = l1Spec
+	}
+	n.primaryNetworkValidators = nil
+	for _, nodeID := range state.PrimaryNetworkValidators {
+		node, err := n.Network.GetNode(nodeID)
+		Expect(err).Should(BeNil())
+		n.primaryNetworkValidators = append(n.primaryNetworkValidators, node)
+	}
+	n.extraNodes = nil
+	for _, flags := range state.ExtraNodes {
+		node := tmpnet.NewNode("")
+		node.Flags = flags
+		Expect(node.EnsureKeys()).Should(BeNil())
+		n.extraNodes = append(n.extraNodes, node)
+	}
+	n.validatorManagers = n.readProxyAddresses(ctx, state.ValidatorManagers)
+	n.validatorManagerSpecializations = n.readProxyAddresses(ctx, state.ValidatorManagerSpecializations)
+	n.teleporter = state.Teleporter
+}
+
+func (n *LocalNetwork) readProxyAddresses(
+	ctx context.Context,
+	persisted []persistedProxyAddress,
+) map[ids.ID]ProxyAddress {
+	proxyAddresses := make(map[ids.ID]ProxyAddress, len(persisted))
+	for _, proxyAddress := range persisted {
+		var admin *proxyadmin.ProxyAdmin
+		if proxyAddress.Proxy {
+			l1 := n.GetL1Info(proxyAddress.SubnetID)
+			adminSlot, err := l1.RPCClient.StorageAt(ctx, proxyAddress.Address, erc1967AdminSlot, nil)
+			Expect(err).Should(BeNil())
+			admin, err = proxyadmin.NewProxyAdmin(common.BytesToAddress(adminSlot), l1.RPCClient)
+			Expect(err).Should(BeNil())
+		}
+		proxyAddresses[proxyAddress.SubnetID] = ProxyAddress{
+			Address:    proxyAddress.Address,
+			ProxyAdmin: admin,
+		}
+	}
+	return proxyAddresses
+}
+
+// SaveTeleporterInfo records the TeleporterMessenger and TeleporterRegistry of each chain, to be
+// returned by GetTeleporterInfo once the network is reattached
+func (n *LocalNetwork) SaveTeleporterInfo(teleporter utils.TeleporterTestInfo) {
+	n.teleporter = nil
+	for blockchainID, info := range teleporter {
+		n.teleporter = append(n.teleporter, persistedTeleporterInfo{
+			BlockchainID:               blockchainID,
+			TeleporterMessengerAddress: info.TeleporterMessengerAddress,
+			TeleporterRegistryAddress:  info.TeleporterRegistryAddress,
+		})
+	}
+	n.SaveState()
+}
+
+// GetTeleporterInfo returns the Teleporter deployment recorded by SaveTeleporterInfo
+func (n *LocalNetwork) GetTeleporterInfo() utils.TeleporterTestInfo {
+	Expect(n.teleporter).ShouldNot(BeEmpty(), "no Teleporter deployment saved")
+	l1s := n.GetAllL1Infos()
+	teleporter := utils.NewTeleporterTestInfo(l1s)
+	for _, l1 := range l1s {
+		for _, info := range n.teleporter {
+			if info.BlockchainID != l1.BlockchainID {
+				continue
+			}
+			if info.TeleporterMessengerAddress != (common.Address{}) {
+				teleporter.SetTeleporter(info.TeleporterMessengerAddress, l1)
+			}
+			if info.TeleporterRegistryAddress != (common.Address{}) {
+				teleporter.SetTeleporterRegistry(info.TeleporterRegistryAddress, l1)
+			}
+		}
+	}
+	return teleporter
+}
+
+// Snapshot saves the state of the network as [name], overwriting any previous snapshot of the
+// same name. The nodes are stopped while their databases are copied.
+func (n *LocalNetwork) Snapshot(ctx context.Context, name string) {
+	goLog.Println("Taking snapshot", name)
+	n.SaveState()
+	n.stop(ctx)
+
+	snapshotDir := n.snapshotDir(name)
+	Expect(os.RemoveAll(snapshotDir)).Should(BeNil())
+	Expect(copyDir(n.Dir(), snapshotDir, snapshotsDirName)).Should(BeNil())
+
+	n.start(ctx)
+}
+
+// RestoreSnapshot resets the network to the snapshot [name]. Nodes added since the snapshot are
+// stopped. Node URIs are preserved, but websocket clients of the network must be redialed.
+func (n *LocalNetwork) RestoreSnapshot(ctx context.Context, name string) {
+	goLog.Println("Restoring snapshot", name)
+	snapshotDir := n.snapshotDir(name)
+	_, err := os.Stat(snapshotDir)
+	Expect(err).Should(BeNil(), "unknown snapshot %s", name)
+
+	n.stop(ctx)
+	entries, err := os.ReadDir(n.Dir())
+	Expect(err).Should(BeNil())
+	for _, entry := range entries {
+		if entry.Name() != snapshotsDirName {
+			Expect(os.RemoveAll(filepath.Join(n.Dir(), entry.Name()))).Should(BeNil())
+		}
+	}
+	Expect(copyDir(snapshotDir, n.Dir(), "")).Should(BeNil())
+
+	network, err := tmpnet.ReadNetwork(n.Dir())
+	Expect(err).Should(BeNil())
+	n.Network = network
+	n.start(ctx)
+	n.readState(ctx)
+}
+
+// Snapshots returns the names of the network's snapshots
+func (n *LocalNetwork) Snapshots() []string {
+	names, err := Snapshots(n.Dir())
+	Expect(err).Should(BeNil())
+	return names
+}
+
+// Snapshots returns the names of the snapshots of the network in [dir], without reattaching to it
+func Snapshots(dir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, snapshotsDirName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+func (n *LocalNetwork) snapshotDir(name string) string {
+	Expect(name).ShouldNot(BeEmpty())
+	Expect(filepath.Base(name)).Should(Equal(name), "invalid snapshot name %s", name)
+	return filepath.Join(n.Dir(), snapshotsDirName, name)
+}
+
+// stop stops the nodes, keeping their API ports so that node URIs are unchanged once restarted
+func (n *LocalNetwork) stop(ctx context.Context) {
+	for _, node := range n.Network.Nodes {
+		node.RuntimeConfig.ReuseDynamicPorts = true
+		Expect(node.SaveAPIPort()).Should(BeNil())
+		Expect(node.Write()).Should(BeNil())
+	}
+	Expect(n.Network.Stop(ctx)).Should(BeNil())
+}
+
+// start restarts the network if any node isn't running
+func (n *LocalNetwork) start(ctx context.Context) {
+	for _, node := range n.Network.Nodes {
+		if healthy, err := node.IsHealthy(ctx); err == nil && healthy {
+			continue
+		}
+		for _, restartNode := range n.Network.Nodes {
+			restartNode.RuntimeConfig.ReuseDynamicPorts = true
+		}
+		Expect(n.Network.Restart(ctx, n.logger)).Should(BeNil())
+		return
+	}
+}
+
+// copyDir recursively copies [src] to [dst], except for the top level entry named [exclude]
+func copyDir(src string, dst string, exclude string) error {
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if exclude != "" && relPath == exclude {
+			return filepath.SkipDir
+		}
+		target := filepath.Join(dst, relPath)
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case entry.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case info.Mode()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			return copyFile(path, target, info.Mode().Perm())
+		}
+	})
+}
+
+func copyFile(src string, dst string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
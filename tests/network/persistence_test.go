@@ -0,0 +1,50 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyDir(t *testing.T) {
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "node", "db"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "node", "db", "000001.log"), []byte("state"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(src, localNetworkStateFileName), []byte("{}"), 0o600))
+	require.NoError(t, os.Symlink("node", filepath.Join(src, "latest")))
+	require.NoError(t, os.MkdirAll(filepath.Join(src, snapshotsDirName, "old"), 0o755))
+
+	dst := filepath.Join(t.TempDir(), "copy")
+	require.NoError(t, copyDir(src, dst, snapshotsDirName))
+
+	contents, err := os.ReadFile(filepath.Join(dst, "node", "db", "000001.log"))
+	require.NoError(t, err)
+	require.Equal(t, "state", string(contents))
+	info, err := os.Stat(filepath.Join(dst, localNetworkStateFileName))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	link, err := os.Readlink(filepath.Join(dst, "latest"))
+	require.NoError(t, err)
+	require.Equal(t, "node", link)
+	_, err = os.Stat(filepath.Join(dst, snapshotsDirName))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	names, err := Snapshots(dir)
+	require.NoError(t, err)
+	require.Empty(t, names)
+
+	for _, name := range []string{"initial", "converted"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, snapshotsDirName, name), 0o755))
+	}
+	names, err = Snapshots(dir)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"initial", "converted"}, names)
+}
@@ -0,0 +1,339 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/icm-contracts/tests/utils"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrInvalidTopology = errors.New("invalid topology")
+
+// TeleporterDeployment is how TeleporterMessenger is made available on a chain
+type TeleporterDeployment string
+
+const (
+	// TeleporterGenesis includes TeleporterMessenger in the genesis of an L1
+	TeleporterGenesis TeleporterDeployment = "genesis"
+	// TeleporterDeploy deploys TeleporterMessenger with its keyless transaction once the network is up
+	TeleporterDeploy TeleporterDeployment = "deploy"
+	// TeleporterNone leaves the chain without TeleporterMessenger
+	TeleporterNone TeleporterDeployment = "none"
+)
+
+// ValidatorManagerType is the validator manager an L1 is converted with
+type ValidatorManagerType string
+
+const (
+	PoAValidatorManagerType       ValidatorManagerType = "poa"
+	NativeTokenStakingManagerType ValidatorManagerType = "native"
+	ERC20TokenStakingManagerType  ValidatorManagerType = "erc20"
+)
+
+const defaultICTTTokenDecimals uint8 = 18
+
+var validatorManagerTypes = map[ValidatorManagerType]utils.ValidatorManagerConcreteType{
+	PoAValidatorManagerType:       utils.PoAValidatorManager,
+	NativeTokenStakingManagerType: utils.NativeTokenStakingManager,
+	ERC20TokenStakingManagerType:  utils.ERC20TokenStakingManager,
+}
+
+// Topology describes a local network and the contracts deployed to it. Topologies are loaded from
+// YAML or JSON files with LoadTopology, and deployed with DeployTopology. Chains are referred to
+// by the name of their L1, and the C-Chain by utils.CChainPathSpecifier.
+type Topology struct {
+	// Name of the tmpnet network
+	Name string `json:"name" yaml:"name"`
+	// GenesisTemplate is the default genesis template of the L1s
+	GenesisTemplate string `json:"genesisTemplate" yaml:"genesisTemplate"`
+	// TeleporterByteCode is the forge artifact of TeleporterMessenger. Required if any chain has
+	// TeleporterMessenger.
+	TeleporterByteCode string                 `json:"teleporterByteCode" yaml:"teleporterByteCode"`
+	PrimaryNetwork     PrimaryNetworkTopology `json:"primaryNetwork" yaml:"primaryNetwork"`
+	L1s                []L1Topology           `json:"l1s" yaml:"l1s"`
+	Contracts          ContractsTopology      `json:"contracts" yaml:"contracts"`
+}
+
+type PrimaryNetworkTopology struct {
+	// Validators is the number of primary network validators. There must be at least one per L1,
+	// which is the default.
+	Validators int `json:"validators" yaml:"validators"`
+	// ExtraNodes are started later by tests, eg to add L1 validators
+	ExtraNodes int `json:"extraNodes" yaml:"extraNodes"`
+	// Teleporter is either deploy, the default, or none
+	Teleporter TeleporterDeployment `json:"teleporter" yaml:"teleporter"`
+}
+
+type L1Topology struct {
+	Name       string `json:"name" yaml:"name"`
+	EVMChainID uint64 `json:"evmChainID" yaml:"evmChainID"`
+	// NodeCount is the number of validators of the L1 once converted
+	NodeCount int `json:"nodeCount" yaml:"nodeCount"`
+	// GenesisTemplate overrides the topology's genesis template
+	GenesisTemplate string `json:"genesisTemplate" yaml:"genesisTemplate"`
+	// Teleporter is genesis, the default, deploy or none
+	Teleporter TeleporterDeployment `json:"teleporter" yaml:"teleporter"`
+	// RequirePrimaryNetworkSigners is set in the Warp config of the genesis
+	RequirePrimaryNetworkSigners bool `json:"requirePrimaryNetworkSigners" yaml:"requirePrimaryNetworkSigners"`
+	// ValidatorManager converts the L1 once Teleporter is deployed. The L1 keeps its subnet
+	// validators if it is not set.
+	ValidatorManager *ValidatorManagerTopology `json:"validatorManager" yaml:"validatorManager"`
+}
+
+type ValidatorManagerTopology struct {
+	Type  ValidatorManagerType `json:"type" yaml:"type"`
+	Proxy bool                 `json:"proxy" yaml:"proxy"`
+	// Weights of the initial validators, one per node. Defaults to equal weights.
+	Weights []uint64 `json:"weights" yaml:"weights"`
+}
+
+type ContractsTopology struct {
+	// TeleporterRegistries are the chains to deploy a TeleporterRegistry to
+	TeleporterRegistries []string           `json:"teleporterRegistries" yaml:"teleporterRegistries"`
+	ICTTPairs            []ICTTPairTopology `json:"icttPairs" yaml:"icttPairs"`
+}
+
+// ICTTPairTopology is an ERC20TokenHome for a new ExampleERC20Decimals token, and an
+// ERC20TokenRemote registered with it. Both chains need a TeleporterRegistry.
+type ICTTPairTopology struct {
+	Name        string `json:"name" yaml:"name"`
+	Home        string `json:"home" yaml:"home"`
+	Remote      string `json:"remote" yaml:"remote"`
+	TokenName   string `json:"tokenName" yaml:"tokenName"`
+	TokenSymbol string `json:"tokenSymbol" yaml:"tokenSymbol"`
+	// Decimals of both the token and the remote. Defaults to 18 if unset, and may be set to 0.
+	Decimals *uint8 `json:"decimals,omitempty" yaml:"decimals,omitempty"`
+}
+
+// LoadTopology reads a topology from a .json, .yaml or .yml file. Relative paths in the topology
+// are relative to the directory of the file.
+func LoadTopology(fileName string) (*Topology, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var topology Topology
+	switch filepath.Ext(fileName) {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&topology)
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&topology)
+	default:
+		return nil, fmt.Errorf("%w: unsupported file extension %s", ErrInvalidTopology, filepath.Ext(fileName))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTopology, err)
+	}
+
+	dir := filepath.Dir(fileName)
+	topology.GenesisTemplate = resolvePath(dir, topology.GenesisTemplate)
+	topology.TeleporterByteCode = resolvePath(dir, topology.TeleporterByteCode)
+	for i := range topology.L1s {
+		topology.L1s[i].GenesisTemplate = resolvePath(dir, topology.L1s[i].GenesisTemplate)
+	}
+
+	topology.setDefaults()
+	if err := topology.Validate(); err != nil {
+		return nil, err
+	}
+	return &topology, nil
+}
+
+func resolvePath(dir string, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+func (t *Topology) setDefaults() {
+	if t.PrimaryNetwork.Validators == 0 {
+		t.PrimaryNetwork.Validators = max(len(t.L1s), 1)
+	}
+	if t.PrimaryNetwork.Teleporter == "" {
+		t.PrimaryNetwork.Teleporter = TeleporterDeploy
+	}
+	for i := range t.L1s {
+		l1 := &t.L1s[i]
+		if l1.GenesisTemplate == "" {
+			l1.GenesisTemplate = t.GenesisTemplate
+		}
+		if l1.Teleporter == "" {
+			l1.Teleporter = TeleporterGenesis
+		}
+		if l1.ValidatorManager != nil && len(l1.ValidatorManager.Weights) == 0 {
+			for range l1.NodeCount {
+				l1.ValidatorManager.Weights = append(l1.ValidatorManager.Weights, units.Schmeckle)
+			}
+		}
+	}
+	for i := range t.Contracts.ICTTPairs {
+		if t.Contracts.ICTTPairs[i].Decimals == nil {
+			decimals := defaultICTTTokenDecimals
+			t.Contracts.ICTTPairs[i].Decimals = &decimals
+		}
+	}
+}
+
+// Validate checks that the topology can be deployed
+func (t *Topology) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("%w: no network name", ErrInvalidTopology)
+	}
+	if t.PrimaryNetwork.Validators < len(t.L1s) {
+		return fmt.Errorf(
+			"%w: %d primary network validators for %d L1s",
+			ErrInvalidTopology,
+			t.PrimaryNetwork.Validators,
+			len(t.L1s),
+		)
+	}
+	if t.PrimaryNetwork.ExtraNodes < 0 {
+		return fmt.Errorf("%w: negative extra node count", ErrInvalidTopology)
+	}
+
+	// Teleporter deployments by chain name
+	chains := make(map[string]TeleporterDeployment, len(t.L1s)+1)
+	switch t.PrimaryNetwork.Teleporter {
+	case TeleporterDeploy, TeleporterNone:
+	default:
+		return fmt.Errorf(
+			"%w: primary network Teleporter must be %s or %s, got %q",
+			ErrInvalidTopology,
+			TeleporterDeploy,
+			TeleporterNone,
+			t.PrimaryNetwork.Teleporter,
+		)
+	}
+	chains[utils.CChainPathSpecifier] = t.PrimaryNetwork.Teleporter
+
+	evmChainIDs := make(map[uint64]string, len(t.L1s))
+	for _, l1 := range t.L1s {
+		if l1.Name == "" {
+			return fmt.Errorf("%w: L1 without a name", ErrInvalidTopology)
+		}
+		if _, ok := chains[l1.Name]; ok {
+			return fmt.Errorf("%w: duplicate chain name %s", ErrInvalidTopology, l1.Name)
+		}
+		if l1.EVMChainID == 0 {
+			return fmt.Errorf("%w: L1 %s has no EVM chain ID", ErrInvalidTopology, l1.Name)
+		}
+		if other, ok := evmChainIDs[l1.EVMChainID]; ok {
+			return fmt.Errorf(
+				"%w: L1s %s and %s have EVM chain ID %d",
+				ErrInvalidTopology,
+				other,
+				l1.Name,
+				l1.EVMChainID,
+			)
+		}
+		evmChainIDs[l1.EVMChainID] = l1.Name
+		if l1.NodeCount < 1 {
+			return fmt.Errorf("%w: L1 %s has no nodes", ErrInvalidTopology, l1.Name)
+		}
+		if l1.GenesisTemplate == "" {
+			return fmt.Errorf("%w: L1 %s has no genesis template", ErrInvalidTopology, l1.Name)
+		}
+		switch l1.Teleporter {
+		case TeleporterGenesis, TeleporterDeploy, TeleporterNone:
+		default:
+			return fmt.Errorf(
+				"%w: L1 %s has unknown Teleporter deployment %q",
+				ErrInvalidTopology,
+				l1.Name,
+				l1.Teleporter,
+			)
+		}
+		if vdrManager := l1.ValidatorManager; vdrManager != nil {
+			if _, ok := validatorManagerTypes[vdrManager.Type]; !ok {
+				return fmt.Errorf(
+					"%w: L1 %s has unknown validator manager type %q",
+					ErrInvalidTopology,
+					l1.Name,
+					vdrManager.Type,
+				)
+			}
+			if len(vdrManager.Weights) != l1.NodeCount {
+				return fmt.Errorf(
+					"%w: L1 %s has %d nodes but %d validator weights",
+					ErrInvalidTopology,
+					l1.Name,
+					l1.NodeCount,
+					len(vdrManager.Weights),
+				)
+			}
+		}
+		chains[l1.Name] = l1.Teleporter
+	}
+
+	if t.needsTeleporter() && t.TeleporterByteCode == "" {
+		return fmt.Errorf("%w: no TeleporterMessenger bytecode", ErrInvalidTopology)
+	}
+
+	registries := make(map[string]bool, len(t.Contracts.TeleporterRegistries))
+	for _, name := range t.Contracts.TeleporterRegistries {
+		teleporter, ok := chains[name]
+		if !ok {
+			return fmt.Errorf("%w: TeleporterRegistry on unknown chain %s", ErrInvalidTopology, name)
+		}
+		if teleporter == TeleporterNone {
+			return fmt.Errorf("%w: TeleporterRegistry on %s, which has no Teleporter", ErrInvalidTopology, name)
+		}
+		if registries[name] {
+			return fmt.Errorf("%w: duplicate TeleporterRegistry on %s", ErrInvalidTopology, name)
+		}
+		registries[name] = true
+	}
+
+	pairs := make(map[string]bool, len(t.Contracts.ICTTPairs))
+	for _, pair := range t.Contracts.ICTTPairs {
+		if pair.Name == "" {
+			return fmt.Errorf("%w: ICTT pair without a name", ErrInvalidTopology)
+		}
+		if pairs[pair.Name] {
+			return fmt.Errorf("%w: duplicate ICTT pair %s", ErrInvalidTopology, pair.Name)
+		}
+		pairs[pair.Name] = true
+		if pair.Home == pair.Remote {
+			return fmt.Errorf("%w: ICTT pair %s has its home and remote on %s", ErrInvalidTopology, pair.Name, pair.Home)
+		}
+		for _, name := range []string{pair.Home, pair.Remote} {
+			if !registries[name] {
+				return fmt.Errorf(
+					"%w: ICTT pair %s needs a TeleporterRegistry on %s",
+					ErrInvalidTopology,
+					pair.Name,
+					name,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// needsTeleporter is true if TeleporterMessenger is available on any chain
+func (t *Topology) needsTeleporter() bool {
+	if t.PrimaryNetwork.Teleporter != TeleporterNone {
+		return true
+	}
+	for _, l1 := range t.L1s {
+		if l1.Teleporter != TeleporterNone {
+			return true
+		}
+	}
+	return false
+}
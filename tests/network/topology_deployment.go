@@ -0,0 +1,192 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	goLog "log"
+
+	"github.com/ava-labs/icm-contracts/tests/interfaces"
+	"github.com/ava-labs/icm-contracts/tests/utils"
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	"github.com/ethereum/go-ethereum/common"
+	. "github.com/onsi/gomega"
+)
+
+// TopologyDeployment is a network started from a Topology
+type TopologyDeployment struct {
+	Network    *LocalNetwork
+	Teleporter utils.TeleporterTestInfo
+	// ICTTPairs by name
+	ICTTPairs map[string]ICTTPairDeployment
+}
+
+type ICTTPairDeployment struct {
+	Token       common.Address
+	TokenHome   common.Address
+	TokenRemote common.Address
+}
+
+// DeployTopology starts the network described by [topology], and deploys TeleporterMessenger,
+// the validator managers and the contracts of the topology, in that order. Contracts are deployed
+// and owned by the network's funded key. [topology] is expected to come from LoadTopology, which
+// sets its defaults and validates it.
+func DeployTopology(ctx context.Context, topology *Topology) *TopologyDeployment {
+	var (
+		teleporterDeployerTransaction []byte
+		teleporterDeployedBytecode    string
+		teleporterDeployerAddress     common.Address
+		teleporterContractAddress     common.Address
+	)
+	if topology.needsTeleporter() {
+		var err error
+		teleporterDeployerTransaction,
+			teleporterDeployedBytecode,
+			teleporterDeployerAddress,
+			teleporterContractAddress,
+			err = deploymentUtils.ConstructKeylessTransaction(
+			topology.TeleporterByteCode,
+			false,
+			deploymentUtils.GetDefaultContractCreationGasPrice(),
+		)
+		Expect(err).Should(BeNil())
+	}
+
+	l1Specs := make([]L1Spec, 0, len(topology.L1s))
+	for _, l1 := range topology.L1s {
+		l1Spec := L1Spec{
+			Name:                         l1.Name,
+			EVMChainID:                   l1.EVMChainID,
+			NodeCount:                    l1.NodeCount,
+			RequirePrimaryNetworkSigners: l1.RequirePrimaryNetworkSigners,
+			GenesisTemplateFile:          l1.GenesisTemplate,
+		}
+		if l1.Teleporter == TeleporterGenesis {
+			l1Spec.TeleporterContractAddress = teleporterContractAddress
+			l1Spec.TeleporterDeployedBytecode = teleporterDeployedBytecode
+			l1Spec.TeleporterDeployerAddress = teleporterDeployerAddress
+		}
+		l1Specs = append(l1Specs, l1Spec)
+	}
+	localNetwork := NewLocalNetwork(
+		ctx,
+		topology.Name,
+		topology.GenesisTemplate,
+		l1Specs,
+		topology.PrimaryNetwork.Validators,
+		topology.PrimaryNetwork.ExtraNodes,
+	)
+	deployment := &TopologyDeployment{
+		Network:    localNetwork,
+		Teleporter: utils.NewTeleporterTestInfo(localNetwork.GetAllL1Infos()),
+		ICTTPairs:  make(map[string]ICTTPairDeployment),
+	}
+	_, fundedKey := localNetwork.GetFundedAccountInfo()
+	fundedAddress := utils.PrivateKeyToAddress(fundedKey)
+
+	// The C-Chain is deployed to first, then the L1s in the order of the topology
+	chainNames := []string{utils.CChainPathSpecifier}
+	teleporterDeployments := []TeleporterDeployment{topology.PrimaryNetwork.Teleporter}
+	for _, l1 := range topology.L1s {
+		chainNames = append(chainNames, l1.Name)
+		teleporterDeployments = append(teleporterDeployments, l1.Teleporter)
+	}
+	for i, name := range chainNames {
+		teleporter := teleporterDeployments[i]
+		if teleporter == TeleporterNone {
+			continue
+		}
+		l1 := deployment.ChainInfo(name)
+		if teleporter == TeleporterDeploy {
+			deployment.Teleporter.DeployTeleporterMessenger(
+				ctx,
+				l1,
+				teleporterDeployerTransaction,
+				teleporterDeployerAddress,
+				teleporterContractAddress,
+				fundedKey,
+			)
+		}
+		deployment.Teleporter.SetTeleporter(teleporterContractAddress, l1)
+		deployment.Teleporter.InitializeBlockchainID(l1, fundedKey)
+	}
+	for _, name := range topology.Contracts.TeleporterRegistries {
+		deployment.Teleporter.DeployTeleporterRegistry(deployment.ChainInfo(name), fundedKey)
+	}
+
+	for _, l1 := range topology.L1s {
+		if l1.ValidatorManager == nil {
+			continue
+		}
+		goLog.Printf("Converting L1 %s with a %s validator manager", l1.Name, l1.ValidatorManager.Type)
+		localNetwork.ConvertSubnet(
+			ctx,
+			deployment.ChainInfo(l1.Name),
+			validatorManagerTypes[l1.ValidatorManager.Type],
+			l1.ValidatorManager.Weights,
+			fundedKey,
+			l1.ValidatorManager.Proxy,
+		)
+	}
+
+	if len(topology.Contracts.ICTTPairs) != 0 {
+		aggregator := localNetwork.GetSignatureAggregator()
+		defer aggregator.Shutdown()
+		for _, pair := range topology.Contracts.ICTTPairs {
+			homeL1 := deployment.ChainInfo(pair.Home)
+			remoteL1 := deployment.ChainInfo(pair.Remote)
+			decimals := *pair.Decimals
+
+			tokenAddress, _ := utils.DeployExampleERC20Decimals(ctx, fundedKey, homeL1, decimals)
+			tokenHomeAddress, _ := utils.DeployERC20TokenHome(
+				ctx,
+				deployment.Teleporter,
+				fundedKey,
+				homeL1,
+				fundedAddress,
+				tokenAddress,
+				decimals,
+			)
+			tokenRemoteAddress, _ := utils.DeployERC20TokenRemote(
+				ctx,
+				deployment.Teleporter,
+				fundedKey,
+				remoteL1,
+				fundedAddress,
+				homeL1.BlockchainID,
+				tokenHomeAddress,
+				decimals,
+				pair.TokenName,
+				pair.TokenSymbol,
+				decimals,
+			)
+			utils.RegisterERC20TokenRemoteOnHome(
+				ctx,
+				deployment.Teleporter,
+				homeL1,
+				tokenHomeAddress,
+				remoteL1,
+				tokenRemoteAddress,
+				fundedKey,
+				aggregator,
+			)
+			deployment.ICTTPairs[pair.Name] = ICTTPairDeployment{
+				Token:       tokenAddress,
+				TokenHome:   tokenHomeAddress,
+				TokenRemote: tokenRemoteAddress,
+			}
+			goLog.Printf("Deployed ICTT pair %s from %s to %s", pair.Name, pair.Home, pair.Remote)
+		}
+	}
+
+	return deployment
+}
+
+// ChainInfo returns the info of the chain named [name] in the topology
+func (d *TopologyDeployment) ChainInfo(name string) interfaces.L1TestInfo {
+	if name == utils.CChainPathSpecifier {
+		return d.Network.GetPrimaryNetworkInfo()
+	}
+	return d.Network.GetL1InfoByName(name)
+}
@@ -0,0 +1,167 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/stretchr/testify/require"
+)
+
+const testTopologyYAML = `
+name: test-network
+genesisTemplate: genesis.json
+teleporterByteCode: /artifacts/TeleporterMessenger.json
+l1s:
+  - name: A
+    evmChainID: 1
+    nodeCount: 2
+    validatorManager:
+      type: erc20
+      proxy: true
+  - name: B
+    evmChainID: 2
+    nodeCount: 1
+    genesisTemplate: /templates/b.json
+    teleporter: none
+contracts:
+  teleporterRegistries: [C, A]
+  icttPairs:
+    - name: C-to-A
+      home: C
+      remote: A
+    - name: A-to-C
+      home: A
+      remote: C
+      decimals: 0
+`
+
+func writeTopologyFile(t *testing.T, fileName string, content string) string {
+	path := filepath.Join(t.TempDir(), fileName)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadTopology(t *testing.T) {
+	path := writeTopologyFile(t, "topology.yaml", testTopologyYAML)
+	topology, err := LoadTopology(path)
+	require.NoError(t, err)
+
+	require.Equal(t, "test-network", topology.Name)
+	require.Equal(t, filepath.Join(filepath.Dir(path), "genesis.json"), topology.GenesisTemplate)
+	require.Equal(t, "/artifacts/TeleporterMessenger.json", topology.TeleporterByteCode)
+	require.Equal(t, 2, topology.PrimaryNetwork.Validators)
+	require.Equal(t, TeleporterDeploy, topology.PrimaryNetwork.Teleporter)
+
+	require.Len(t, topology.L1s, 2)
+	l1A, l1B := topology.L1s[0], topology.L1s[1]
+	require.Equal(t, topology.GenesisTemplate, l1A.GenesisTemplate)
+	require.Equal(t, TeleporterGenesis, l1A.Teleporter)
+	require.Equal(t, ERC20TokenStakingManagerType, l1A.ValidatorManager.Type)
+	require.True(t, l1A.ValidatorManager.Proxy)
+	require.Equal(t, []uint64{units.Schmeckle, units.Schmeckle}, l1A.ValidatorManager.Weights)
+	require.Equal(t, "/templates/b.json", l1B.GenesisTemplate)
+	require.Equal(t, TeleporterNone, l1B.Teleporter)
+	require.Nil(t, l1B.ValidatorManager)
+
+	require.Equal(t, defaultICTTTokenDecimals, *topology.Contracts.ICTTPairs[0].Decimals)
+	// Zero decimals are kept
+	require.Zero(t, *topology.Contracts.ICTTPairs[1].Decimals)
+}
+
+func TestLoadTopologyJSON(t *testing.T) {
+	path := writeTopologyFile(t, "topology.json", `{
+		"name": "test-network",
+		"genesisTemplate": "genesis.json",
+		"primaryNetwork": {"teleporter": "none"},
+		"l1s": [{"name": "A", "evmChainID": 1, "nodeCount": 1, "teleporter": "none"}]
+	}`)
+	topology, err := LoadTopology(path)
+	require.NoError(t, err)
+	require.Equal(t, 1, topology.PrimaryNetwork.Validators)
+	require.False(t, topology.needsTeleporter())
+
+	// Unknown fields are rejected rather than ignored
+	path = writeTopologyFile(t, "topology.json", `{"name": "test-network", "l1Specs": []}`)
+	_, err = LoadTopology(path)
+	require.ErrorIs(t, err, ErrInvalidTopology)
+
+	path = writeTopologyFile(t, "topology.toml", `name = "test-network"`)
+	_, err = LoadTopology(path)
+	require.ErrorIs(t, err, ErrInvalidTopology)
+}
+
+func TestValidateTopology(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Topology)
+	}{
+		{
+			name: "too few primary network validators",
+			modify: func(topology *Topology) {
+				topology.PrimaryNetwork.Validators = 1
+			},
+		},
+		{
+			name: "L1 named after the C-Chain",
+			modify: func(topology *Topology) {
+				topology.L1s[1].Name = "C"
+			},
+		},
+		{
+			name: "duplicate EVM chain ID",
+			modify: func(topology *Topology) {
+				topology.L1s[1].EVMChainID = topology.L1s[0].EVMChainID
+			},
+		},
+		{
+			name: "Teleporter in the C-Chain genesis",
+			modify: func(topology *Topology) {
+				topology.PrimaryNetwork.Teleporter = TeleporterGenesis
+			},
+		},
+		{
+			name: "unknown validator manager",
+			modify: func(topology *Topology) {
+				topology.L1s[0].ValidatorManager.Type = "pos"
+			},
+		},
+		{
+			name: "validator weights don't match the node count",
+			modify: func(topology *Topology) {
+				topology.L1s[0].ValidatorManager.Weights = []uint64{1}
+			},
+		},
+		{
+			name: "no Teleporter bytecode",
+			modify: func(topology *Topology) {
+				topology.TeleporterByteCode = ""
+			},
+		},
+		{
+			name: "registry without Teleporter",
+			modify: func(topology *Topology) {
+				topology.Contracts.TeleporterRegistries = append(topology.Contracts.TeleporterRegistries, "B")
+			},
+		},
+		{
+			name: "ICTT pair without a registry",
+			modify: func(topology *Topology) {
+				topology.Contracts.ICTTPairs[0].Remote = "B"
+			},
+		},
+	}
+	path := writeTopologyFile(t, "topology.yaml", testTopologyYAML)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			topology, err := LoadTopology(path)
+			require.NoError(t, err)
+			test.modify(topology)
+			require.ErrorIs(t, topology.Validate(), ErrInvalidTopology)
+		})
+	}
+}
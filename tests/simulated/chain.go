@@ -0,0 +1,159 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulated
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/constants"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/eth"
+	"github.com/ava-labs/subnet-evm/eth/ethconfig"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/node"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// blockGap is the number of seconds between consecutive simulated blocks
+const blockGap = 2
+
+var _ eth.PushGossiper = (*noopPushGossiper)(nil)
+
+type noopPushGossiper struct{}
+
+func (*noopPushGossiper) Add(*types.Transaction) {}
+
+// chain is a single in-process EVM chain. It mirrors subnet-evm's simulated backend, but builds
+// blocks with a predicate context so that Warp messages are verified by the real precompile.
+type chain struct {
+	spec     ChainSpec
+	snowCtx  *snow.Context
+	backend  *eth.Ethereum
+	server   *rpc.Server
+	client   *autoCommitClient
+	clock    *mockable.Clock
+	commitMu sync.Mutex
+}
+
+func newChain(spec ChainSpec, snowCtx *snow.Context, alloc types.GenesisAlloc) (*chain, error) {
+	chainConfig := *params.TestChainConfig
+	chainConfig.ChainID = spec.EVMChainID
+	chainConfig.AvalancheContext = params.AvalancheContext{SnowCtx: snowCtx}
+	chainConfig.GenesisPrecompiles = params.Precompiles{
+		warp.ConfigKey: warp.NewConfig(utils.NewUint64(0), 0, spec.RequirePrimaryNetworkSigners),
+	}
+
+	ethConf := ethconfig.DefaultConfig
+	ethConf.Genesis = &core.Genesis{
+		Config:   &chainConfig,
+		GasLimit: chainConfig.FeeConfig.GasLimit.Uint64(),
+		Alloc:    alloc,
+	}
+	ethConf.AllowUnfinalizedQueries = true
+	// Required to issue the keyless TeleporterMessenger deployment transaction
+	ethConf.AllowUnprotectedTxs = true
+	ethConf.Miner.Etherbase = constants.BlackholeAddr
+	ethConf.Miner.TestOnlyAllowDuplicateBlocks = true
+	ethConf.TxPool.NoLocals = true
+
+	nodeConf := node.DefaultConfig
+	stack, err := node.New(&nodeConf)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create node")
+	}
+
+	clock := &mockable.Clock{}
+	clock.Set(time.Unix(0, 0))
+	engine := dummy.NewFakerWithModeAndClock(dummy.Mode{ModeSkipCoinbase: true}, clock)
+	backend, err := eth.New(
+		stack, &ethConf, &noopPushGossiper{}, rawdb.NewMemoryDatabase(), eth.Settings{}, common.Hash{},
+		engine, clock,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create chain backend")
+	}
+	server := rpc.NewServer(0)
+	for _, api := range backend.APIs() {
+		if err := server.RegisterName(api.Namespace, api.Service); err != nil {
+			return nil, errors.Wrap(err, "Failed to register API")
+		}
+	}
+	c := &chain{
+		spec:    spec,
+		snowCtx: snowCtx,
+		backend: backend,
+		server:  server,
+		clock:   clock,
+	}
+	c.client = &autoCommitClient{
+		Client: ethclient.NewClient(rpc.DialInProc(server)),
+		chain:  c,
+	}
+	return c, nil
+}
+
+// commit builds a block from the pending transactions, and accepts it. Transactions with Warp
+// predicates are verified against the simulated validator sets.
+func (c *chain) commit() (*types.Block, error) {
+	c.commitMu.Lock()
+	defer c.commitMu.Unlock()
+
+	blockchain := c.backend.BlockChain()
+	if err := c.backend.TxPool().Sync(); err != nil {
+		return nil, errors.Wrap(err, "Failed to sync transaction pool")
+	}
+	parent := blockchain.CurrentBlock()
+	c.clock.Set(time.Unix(int64(parent.Time+blockGap), 0))
+
+	blk, err := c.backend.Miner().GenerateBlock(&precompileconfig.PredicateContext{
+		SnowCtx:            c.snowCtx,
+		ProposerVMBlockCtx: &block.Context{PChainHeight: pChainHeight},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to generate block")
+	}
+	if err := blockchain.InsertBlock(blk); err != nil {
+		return nil, errors.Wrap(err, "Failed to insert block")
+	}
+	if err := blockchain.Accept(blk); err != nil {
+		return nil, errors.Wrap(err, "Failed to accept block")
+	}
+	blockchain.DrainAcceptorQueue()
+	return blk, nil
+}
+
+func (c *chain) close() error {
+	c.client.Close()
+	c.server.Stop()
+	return c.backend.Stop()
+}
+
+// autoCommitClient commits a block after every transaction it sends, so that helpers waiting
+// for receipts behave as they do against a live network.
+type autoCommitClient struct {
+	ethclient.Client
+	chain *chain
+}
+
+func (c *autoCommitClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := c.Client.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	_, err := c.chain.commit()
+	return err
+}
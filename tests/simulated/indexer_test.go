@@ -0,0 +1,139 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulated
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	"github.com/ava-labs/icm-contracts/tests/utils"
+	indexerUtils "github.com/ava-labs/icm-contracts/utils/indexer-utils"
+	relayerUtils "github.com/ava-labs/icm-contracts/utils/relayer-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMessageIndexer indexes the lifecycle of messages relayed between the simulated chains
+func TestMessageIndexer(t *testing.T) {
+	network, teleporter := newTestNetwork(t)
+	ctx := context.Background()
+	cChainInfo, err := network.GetL1Info("C")
+	require.NoError(t, err)
+	l1AInfo, err := network.GetL1Info("A")
+	require.NoError(t, err)
+	fundedAddress, fundedKey := network.GetFundedAccountInfo()
+
+	relayerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	relayer, err := relayerUtils.NewRelayer(
+		relayerUtils.Config{
+			Chains: newRelayerChains(t, network, teleporter, relayerKey),
+			Key:    relayerKey,
+		},
+		network.GetSignatureAggregator(),
+	)
+	require.NoError(t, err)
+
+	store, err := indexerUtils.OpenStore(filepath.Join(t.TempDir(), "index.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+	})
+	var chains []indexerUtils.Chain
+	for _, l1 := range network.GetAllL1Infos() {
+		chains = append(chains, indexerUtils.Chain{
+			BlockchainID:      l1.BlockchainID,
+			Client:            l1.RPCClient,
+			TeleporterAddress: teleporter.TeleporterMessengerAddress(l1),
+		})
+	}
+	indexer, err := indexerUtils.NewIndexer(indexerUtils.Config{Chains: chains}, store)
+	require.NoError(t, err)
+
+	// The message is indexed once sent, and again once delivered. It is sent to an address
+	// without code, so its execution fails.
+	input := teleportermessenger.TeleporterMessageInput{
+		DestinationBlockchainID: cChainInfo.BlockchainID,
+		DestinationAddress:      fundedAddress,
+		FeeInfo: teleportermessenger.TeleporterFeeInfo{
+			Amount: big.NewInt(0),
+		},
+		RequiredGasLimit:        big.NewInt(1),
+		AllowedRelayerAddresses: []common.Address{},
+		Message:                 []byte{1, 2, 3, 4},
+	}
+	receipt, messageID := utils.SendCrossChainMessageAndWaitForAcceptance(
+		ctx, teleporter.TeleporterMessenger(l1AInfo), l1AInfo, cChainInfo, input, fundedKey,
+	)
+	require.NoError(t, indexer.Sync(ctx))
+	message, err := store.Message(ctx, common.Hash(messageID))
+	require.NoError(t, err)
+	require.Equal(t, indexerUtils.MessageSent, message.Status)
+	require.Equal(t, l1AInfo.BlockchainID, message.SourceBlockchainID)
+	require.Equal(t, cChainInfo.BlockchainID, message.DestinationBlockchainID)
+	require.Equal(t, fundedAddress, message.Sender)
+	require.Equal(t, receipt.TxHash, message.SendTxHash)
+
+	_, err = relayer.ProcessBlocks(
+		ctx, l1AInfo.BlockchainID, receipt.BlockNumber.Uint64(), receipt.BlockNumber.Uint64(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, indexer.Sync(ctx))
+	message, err = store.Message(ctx, common.Hash(messageID))
+	require.NoError(t, err)
+	require.Equal(t, indexerUtils.MessageExecutionFailed, message.Status)
+	require.Equal(t, relayer.Address(), message.Deliverer)
+	require.False(t, message.ReceiptReceived)
+
+	// The receipt is returned to A with a message from C
+	input.DestinationBlockchainID = l1AInfo.BlockchainID
+	receipt, _ = utils.SendCrossChainMessageAndWaitForAcceptance(
+		ctx, teleporter.TeleporterMessenger(cChainInfo), cChainInfo, l1AInfo, input, fundedKey,
+	)
+	_, err = relayer.ProcessBlocks(
+		ctx, cChainInfo.BlockchainID, receipt.BlockNumber.Uint64(), receipt.BlockNumber.Uint64(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, indexer.Sync(ctx))
+	message, err = store.Message(ctx, common.Hash(messageID))
+	require.NoError(t, err)
+	require.True(t, message.ReceiptReceived)
+	require.Equal(t, relayer.Address(), message.RewardRedeemer)
+
+	messages, err := store.Messages(ctx, indexerUtils.MessageFilter{Sender: fundedAddress})
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	messages, err = store.Messages(ctx, indexerUtils.MessageFilter{
+		DestinationBlockchainID: l1AInfo.BlockchainID,
+		Status:                  indexerUtils.MessageExecutionFailed,
+	})
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	events, err := store.MessageEvents(ctx, common.Hash(messageID))
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+
+	// A cursor that isn't canonical is rolled back to the last canonical block with events, and
+	// the blocks after it are indexed again
+	cursor, err := store.Cursor(ctx, l1AInfo.BlockchainID)
+	require.NoError(t, err)
+	reorged := *cursor
+	reorged.BlockHash = common.Hash{1}
+	require.NoError(t, store.IndexBlocks(ctx, l1AInfo.BlockchainID, nil, reorged))
+	require.NoError(t, indexer.Sync(ctx))
+	resynced, err := store.Cursor(ctx, l1AInfo.BlockchainID)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, resynced.BlockNumber, cursor.BlockNumber)
+	require.NotEqual(t, reorged.BlockHash, resynced.BlockHash)
+	message, err = store.Message(ctx, common.Hash(messageID))
+	require.NoError(t, err)
+	require.True(t, message.ReceiptReceived)
+	events, err = store.MessageEvents(ctx, common.Hash(messageID))
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+}
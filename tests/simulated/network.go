@@ -0,0 +1,247 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulated
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/icm-contracts/tests/interfaces"
+	"github.com/ava-labs/icm-contracts/tests/utils"
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	subnetEvmUtils "github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/pkg/errors"
+)
+
+var (
+	// defaultFundedBalance is the genesis balance of the funded key on every chain
+	defaultFundedBalance = new(big.Int).Mul(big.NewInt(1e18), big.NewInt(1_000_000))
+
+	// defaultValidatorWeights are the weights of the validators of each simulated subnet
+	defaultValidatorWeights = []uint64{20, 20, 20, 20, 20}
+)
+
+// ChainSpec describes a simulated chain
+type ChainSpec struct {
+	Name       string
+	EVMChainID *big.Int
+	// PrimaryNetwork places the chain on the primary network, as the C-Chain. Otherwise the chain
+	// is the only chain of a new L1.
+	PrimaryNetwork               bool
+	RequirePrimaryNetworkSigners bool
+	// ValidatorWeights are the weights of the subnet's validators. Defaults to five validators of
+	// equal weight. Ignored for primary network chains after the first.
+	ValidatorWeights []uint64
+}
+
+// Network hosts several EVM chains in-process. All chains share a P-Chain view of locally
+// generated BLS validators, so that Warp messages sent on one chain can be signed and delivered
+// to another without running avalanchego nodes.
+type Network struct {
+	validators *validatorState
+	chains     []*chain
+	fundedKey  *ecdsa.PrivateKey
+}
+
+// NewNetwork creates a chain for each of [specs]. A key funded on every chain is generated.
+func NewNetwork(specs ...ChainSpec) (*Network, error) {
+	fundedKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to generate funded key")
+	}
+	alloc := types.GenesisAlloc{
+		crypto.PubkeyToAddress(fundedKey.PublicKey): {Balance: defaultFundedBalance},
+	}
+
+	n := &Network{
+		validators: newValidatorState(),
+		fundedKey:  fundedKey,
+	}
+	for _, spec := range specs {
+		if spec.EVMChainID == nil {
+			n.Close()
+			return nil, errors.Errorf("chain %s has no EVM chain ID", spec.Name)
+		}
+		subnetID := constants.PrimaryNetworkID
+		if !spec.PrimaryNetwork {
+			subnetID = ids.GenerateTestID()
+		}
+		weights := spec.ValidatorWeights
+		if len(weights) == 0 {
+			weights = defaultValidatorWeights
+		}
+		if err := n.validators.addSubnet(subnetID, weights); err != nil {
+			n.Close()
+			return nil, err
+		}
+
+		snowCtx := subnetEvmUtils.TestSnowContext()
+		snowCtx.ChainID = ids.GenerateTestID()
+		snowCtx.SubnetID = subnetID
+		snowCtx.ValidatorState = n.validators.state()
+		n.validators.addChain(snowCtx.ChainID, subnetID)
+
+		c, err := newChain(spec, snowCtx, alloc)
+		if err != nil {
+			n.Close()
+			return nil, errors.Wrapf(err, "Failed to create chain %s", spec.Name)
+		}
+		n.chains = append(n.chains, c)
+		log.Info(
+			"Created simulated chain",
+			"name", spec.Name,
+			"blockchainID", snowCtx.ChainID,
+			"subnetID", subnetID,
+		)
+	}
+	return n, nil
+}
+
+// Close stops all chains. The network can't be used afterwards.
+func (n *Network) Close() error {
+	var errs []error
+	for _, c := range n.chains {
+		if err := c.close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) != 0 {
+		return errors.Errorf("failed to close %d chains: %v", len(errs), errs)
+	}
+	return nil
+}
+
+// GetFundedAccountInfo returns the key funded on every chain
+func (n *Network) GetFundedAccountInfo() (common.Address, *ecdsa.PrivateKey) {
+	return crypto.PubkeyToAddress(n.fundedKey.PublicKey), n.fundedKey
+}
+
+// GetAllL1Infos returns the info of every chain, in the order of the specs. The chains have no
+// node URIs, and their clients commit a block after every transaction.
+func (n *Network) GetAllL1Infos() []interfaces.L1TestInfo {
+	infos := make([]interfaces.L1TestInfo, 0, len(n.chains))
+	for _, c := range n.chains {
+		infos = append(infos, n.l1Info(c))
+	}
+	return infos
+}
+
+// GetL1Info returns the info of the chain named [name]
+func (n *Network) GetL1Info(name string) (interfaces.L1TestInfo, error) {
+	for _, c := range n.chains {
+		if c.spec.Name == name {
+			return n.l1Info(c), nil
+		}
+	}
+	return interfaces.L1TestInfo{}, errors.Errorf("unknown chain %s", name)
+}
+
+func (n *Network) l1Info(c *chain) interfaces.L1TestInfo {
+	return interfaces.L1TestInfo{
+		SubnetID:                     c.snowCtx.SubnetID,
+		BlockchainID:                 c.snowCtx.ChainID,
+		WSClient:                     c.client,
+		RPCClient:                    c.client,
+		EVMChainID:                   new(big.Int).Set(c.spec.EVMChainID),
+		RequirePrimaryNetworkSigners: c.spec.RequirePrimaryNetworkSigners,
+	}
+}
+
+func (n *Network) chain(blockchainID ids.ID) (*chain, error) {
+	for _, c := range n.chains {
+		if c.snowCtx.ChainID == blockchainID {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownChain, blockchainID)
+}
+
+// Commit builds and accepts a block on the chain of [l1], including any pending transactions
+func (n *Network) Commit(l1 interfaces.L1TestInfo) (*types.Block, error) {
+	c, err := n.chain(l1.BlockchainID)
+	if err != nil {
+		return nil, err
+	}
+	return c.commit()
+}
+
+// SignWarpMessage returns [unsignedMessage] signed by a quorum of the validators of the subnet
+// whose signatures [destination] verifies, following utils.GetSignedMessage.
+func (n *Network) SignWarpMessage(
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	source interfaces.L1TestInfo,
+	destination interfaces.L1TestInfo,
+) (*avalancheWarp.Message, error) {
+	signingSubnetID := source.SubnetID
+	if source.SubnetID == constants.PrimaryNetworkID && !destination.RequirePrimaryNetworkSigners {
+		signingSubnetID = destination.SubnetID
+	}
+	return n.validators.aggregator.CreateSignedMessage(
+		unsignedMessage,
+		nil,
+		signingSubnetID,
+		warp.WarpDefaultQuorumNumerator,
+	)
+}
+
+// GetSignatureAggregator returns an aggregator that signs with the simulated validators. Its
+// faults can be changed to test delivery with partial signatures.
+func (n *Network) GetSignatureAggregator() *utils.MockSignatureAggregator {
+	return n.validators.aggregator
+}
+
+// DeployTeleporterMessenger deploys TeleporterMessenger from the forge artifact
+// [byteCodeFileName] to every chain with its keyless transaction, and initializes the blockchain
+// IDs and TeleporterRegistry instances of [teleporter].
+func (n *Network) DeployTeleporterMessenger(
+	ctx context.Context,
+	teleporter utils.TeleporterTestInfo,
+	byteCodeFileName string,
+) error {
+	txBytes, _, deployerAddress, contractAddress, err := deploymentUtils.ConstructKeylessTransaction(
+		byteCodeFileName,
+		false,
+		deploymentUtils.GetDefaultContractCreationGasPrice(),
+	)
+	if err != nil {
+		return errors.Wrap(err, "Failed to construct keyless transaction")
+	}
+	tx, err := deploymentUtils.ParseRawTransaction(hexutil.Encode(txBytes))
+	if err != nil {
+		return err
+	}
+
+	for _, l1 := range n.GetAllL1Infos() {
+		_, err := deploymentUtils.FundAddress(ctx, l1.RPCClient, n.fundedKey, deployerAddress, tx.Cost())
+		if err != nil {
+			return errors.Wrap(err, "Failed to fund keyless deployer")
+		}
+		if err := l1.RPCClient.SendTransaction(ctx, tx); err != nil {
+			return errors.Wrap(err, "Failed to send keyless transaction")
+		}
+		receipt, err := l1.RPCClient.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			return errors.Wrap(err, "Failed to get keyless transaction receipt")
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful || receipt.ContractAddress != contractAddress {
+			return errors.Errorf("failed to deploy TeleporterMessenger to %s", l1.BlockchainID)
+		}
+
+		teleporter.SetTeleporter(contractAddress, l1)
+		teleporter.InitializeBlockchainID(l1, n.fundedKey)
+		teleporter.DeployTeleporterRegistry(l1, n.fundedKey)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulated
+
+import (
+	"context"
+	"crypto/ecdsa"
+
+	"github.com/ava-labs/icm-contracts/tests/interfaces"
+	"github.com/ava-labs/icm-contracts/tests/utils"
+	"github.com/ava-labs/subnet-evm/core/types"
+	. "github.com/onsi/gomega"
+)
+
+// RelayTeleporterMessage delivers the Teleporter message sent in [sourceReceipt] to
+// [destination], as TeleporterTestInfo.RelayTeleporterMessage does, but with the message signed
+// by the simulated validators instead of a signature aggregator.
+func (n *Network) RelayTeleporterMessage(
+	ctx context.Context,
+	teleporter utils.TeleporterTestInfo,
+	sourceReceipt *types.Receipt,
+	source interfaces.L1TestInfo,
+	destination interfaces.L1TestInfo,
+	expectSuccess bool,
+	fundedKey *ecdsa.PrivateKey,
+) *types.Receipt {
+	sendEvent, err := utils.GetEventFromLogs(
+		sourceReceipt.Logs,
+		teleporter.TeleporterMessenger(source).ParseSendCrossChainMessage,
+	)
+	Expect(err).Should(BeNil())
+
+	unsignedMessage := utils.ExtractWarpMessageFromLog(ctx, sourceReceipt, source)
+	signedMessage, err := n.SignWarpMessage(unsignedMessage, source, destination)
+	Expect(err).Should(BeNil())
+
+	signedTx := utils.CreateReceiveCrossChainMessageTransaction(
+		ctx,
+		signedMessage,
+		sendEvent.Message.RequiredGasLimit,
+		teleporter.TeleporterMessengerAddress(source),
+		fundedKey,
+		destination,
+	)
+	if !expectSuccess {
+		return utils.SendTransactionAndWaitForFailure(ctx, destination, signedTx)
+	}
+	receipt := utils.SendTransactionAndWaitForSuccess(ctx, destination, signedTx)
+
+	receiveEvent, err := utils.GetEventFromLogs(
+		receipt.Logs,
+		teleporter.TeleporterMessenger(destination).ParseReceiveCrossChainMessage,
+	)
+	Expect(err).Should(BeNil())
+	Expect(receiveEvent.SourceBlockchainID[:]).Should(Equal(source.BlockchainID[:]))
+	return receipt
+}
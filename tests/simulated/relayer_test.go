@@ -0,0 +1,311 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulated
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	"github.com/ava-labs/icm-contracts/tests/utils"
+	relayerUtils "github.com/ava-labs/icm-contracts/utils/relayer-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// newRelayerChains funds [key] on each chain of [network], and returns the chains to relay
+// between from their next block
+func newRelayerChains(
+	t *testing.T,
+	network *Network,
+	teleporter utils.TeleporterTestInfo,
+	key *ecdsa.PrivateKey,
+) []relayerUtils.Chain {
+	ctx := context.Background()
+	_, fundedKey := network.GetFundedAccountInfo()
+	var chains []relayerUtils.Chain
+	for _, l1 := range network.GetAllL1Infos() {
+		utils.SendNativeTransfer(ctx, l1, fundedKey, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1e18))
+		head, err := l1.RPCClient.BlockNumber(ctx)
+		require.NoError(t, err)
+		chains = append(chains, relayerUtils.Chain{
+			SubnetID:                     l1.SubnetID,
+			BlockchainID:                 l1.BlockchainID,
+			EVMChainID:                   l1.EVMChainID,
+			Client:                       l1.RPCClient,
+			TeleporterAddress:            teleporter.TeleporterMessengerAddress(l1),
+			RequirePrimaryNetworkSigners: l1.RequirePrimaryNetworkSigners,
+			StartBlock:                   head + 1,
+		})
+	}
+	return chains
+}
+
+// TestRelayer relays messages between the simulated chains with the relayer library
+func TestRelayer(t *testing.T) {
+	network, teleporter := newTestNetwork(t)
+	ctx := context.Background()
+	cChainInfo, err := network.GetL1Info("C")
+	require.NoError(t, err)
+	l1AInfo, err := network.GetL1Info("A")
+	require.NoError(t, err)
+	l1BInfo, err := network.GetL1Info("B")
+	require.NoError(t, err)
+	fundedAddress, fundedKey := network.GetFundedAccountInfo()
+
+	relayerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chains := newRelayerChains(t, network, teleporter, relayerKey)
+	relayer, err := relayerUtils.NewRelayer(
+		relayerUtils.Config{
+			Chains:            chains,
+			Key:               relayerKey,
+			ConfirmationDepth: 1,
+			PollInterval:      100 * time.Millisecond,
+		},
+		network.GetSignatureAggregator(),
+	)
+	require.NoError(t, err)
+	messageReceived := func(messageID [32]byte) bool {
+		received, err := teleporter.TeleporterMessenger(cChainInfo).MessageReceived(&bind.CallOpts{}, messageID)
+		require.NoError(t, err)
+		return received
+	}
+
+	// Only the message that allows any relayer is delivered
+	input := teleportermessenger.TeleporterMessageInput{
+		DestinationBlockchainID: cChainInfo.BlockchainID,
+		DestinationAddress:      fundedAddress,
+		FeeInfo: teleportermessenger.TeleporterFeeInfo{
+			Amount: big.NewInt(0),
+		},
+		RequiredGasLimit:        big.NewInt(1),
+		AllowedRelayerAddresses: []common.Address{},
+		Message:                 []byte{1, 2, 3, 4},
+	}
+	receipt, messageID := utils.SendCrossChainMessageAndWaitForAcceptance(
+		ctx, teleporter.TeleporterMessenger(l1AInfo), l1AInfo, cChainInfo, input, fundedKey,
+	)
+	input.AllowedRelayerAddresses = []common.Address{fundedAddress}
+	disallowedReceipt, disallowedID := utils.SendCrossChainMessageAndWaitForAcceptance(
+		ctx, teleporter.TeleporterMessenger(l1AInfo), l1AInfo, cChainInfo, input, fundedKey,
+	)
+
+	deliveries, err := relayer.ProcessBlocks(
+		ctx, l1AInfo.BlockchainID, receipt.BlockNumber.Uint64(), disallowedReceipt.BlockNumber.Uint64(),
+	)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, messageID, deliveries[0].MessageID)
+	require.True(t, messageReceived(messageID))
+	require.False(t, messageReceived(disallowedID))
+
+	// Messages that were already received are skipped
+	deliveries, err = relayer.ProcessBlocks(
+		ctx, l1AInfo.BlockchainID, receipt.BlockNumber.Uint64(), disallowedReceipt.BlockNumber.Uint64(),
+	)
+	require.NoError(t, err)
+	require.Empty(t, deliveries)
+
+	// Run relays messages once they are ConfirmationDepth blocks deep
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan error)
+	go func() {
+		done <- relayer.Run(runCtx)
+	}()
+	input.AllowedRelayerAddresses = []common.Address{relayer.Address()}
+	_, runMessageID := utils.SendCrossChainMessageAndWaitForAcceptance(
+		ctx, teleporter.TeleporterMessenger(l1BInfo), l1BInfo, cChainInfo, input, fundedKey,
+	)
+	time.Sleep(500 * time.Millisecond)
+	require.False(t, messageReceived(runMessageID))
+
+	utils.SendNativeTransfer(ctx, l1BInfo, fundedKey, fundedAddress, big.NewInt(0))
+	require.Eventually(t, func() bool {
+		received, err := teleporter.TeleporterMessenger(cChainInfo).MessageReceived(&bind.CallOpts{}, runMessageID)
+		return err == nil && received
+	}, 10*time.Second, 100*time.Millisecond)
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
+// TestRelayerRewards accounts for and redeems the rewards earned by a relayer
+func TestRelayerRewards(t *testing.T) {
+	network, teleporter := newTestNetwork(t)
+	ctx := context.Background()
+	cChainInfo, err := network.GetL1Info("C")
+	require.NoError(t, err)
+	l1AInfo, err := network.GetL1Info("A")
+	require.NoError(t, err)
+	fundedAddress, fundedKey := network.GetFundedAccountInfo()
+
+	relayerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chains := newRelayerChains(t, network, teleporter, relayerKey)
+	var accountant *relayerUtils.Accountant
+	relayer, err := relayerUtils.NewRelayer(
+		relayerUtils.Config{
+			Chains:     chains,
+			Key:        relayerKey,
+			OnDelivery: func(delivery *relayerUtils.Delivery) { accountant.RecordDelivery(delivery) },
+		},
+		network.GetSignatureAggregator(),
+	)
+	require.NoError(t, err)
+	// The accountant redeems the rewards with the relayer's key, so it shares the relayer's nonces
+	accountant, err = relayerUtils.NewAccountant(relayerUtils.AccountantConfig{
+		Chains:  chains,
+		Key:     relayerKey,
+		Relayer: relayer,
+	})
+	require.NoError(t, err)
+
+	// Send a message from A paying a fee in an ERC20, and relay it
+	feeTokenAddress, feeToken := utils.DeployExampleERC20(ctx, fundedKey, l1AInfo)
+	feeAmount := big.NewInt(100)
+	utils.ERC20Approve(
+		ctx, feeToken, teleporter.TeleporterMessengerAddress(l1AInfo), feeAmount, l1AInfo, fundedKey,
+	)
+	input := teleportermessenger.TeleporterMessageInput{
+		DestinationBlockchainID: cChainInfo.BlockchainID,
+		DestinationAddress:      fundedAddress,
+		FeeInfo: teleportermessenger.TeleporterFeeInfo{
+			FeeTokenAddress: feeTokenAddress,
+			Amount:          feeAmount,
+		},
+		RequiredGasLimit:        big.NewInt(1),
+		AllowedRelayerAddresses: []common.Address{},
+		Message:                 []byte{1, 2, 3, 4},
+	}
+	receipt, messageID := utils.SendCrossChainMessageAndWaitForAcceptance(
+		ctx, teleporter.TeleporterMessenger(l1AInfo), l1AInfo, cChainInfo, input, fundedKey,
+	)
+	deliveries, err := relayer.ProcessBlocks(
+		ctx, l1AInfo.BlockchainID, receipt.BlockNumber.Uint64(), receipt.BlockNumber.Uint64(),
+	)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	rewardToken := relayerUtils.ChainAddress{BlockchainID: l1AInfo.BlockchainID, Address: feeTokenAddress}
+	require.Equal(t, feeAmount, accountant.Balances()[rewardToken].Pending)
+
+	// The reward becomes redeemable once the receipt is returned to A with a message from C
+	input.DestinationBlockchainID = l1AInfo.BlockchainID
+	input.FeeInfo = teleportermessenger.TeleporterFeeInfo{Amount: big.NewInt(0)}
+	receipt, _ = utils.SendCrossChainMessageAndWaitForAcceptance(
+		ctx, teleporter.TeleporterMessenger(cChainInfo), cChainInfo, l1AInfo, input, fundedKey,
+	)
+	deliveries, err = relayer.ProcessBlocks(
+		ctx, cChainInfo.BlockchainID, receipt.BlockNumber.Uint64(), receipt.BlockNumber.Uint64(),
+	)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.True(t, utils.CheckReceiptReceived(
+		deliveries[0].Receipt, messageID, teleporter.TeleporterMessenger(l1AInfo),
+	))
+	deliveryBlock := deliveries[0].Receipt.BlockNumber.Uint64()
+	require.NoError(t, accountant.ProcessBlocks(ctx, l1AInfo.BlockchainID, deliveryBlock, deliveryBlock))
+	balance := accountant.Balances()[rewardToken]
+	require.Zero(t, balance.Pending.Sign())
+	require.Equal(t, feeAmount, balance.Redeemable)
+
+	redemptions, err := accountant.Redeem(ctx)
+	require.NoError(t, err)
+	require.Len(t, redemptions, 1)
+	require.Equal(t, feeAmount, redemptions[0].Amount)
+	rewards, err := feeToken.BalanceOf(&bind.CallOpts{}, accountant.Address())
+	require.NoError(t, err)
+	require.Equal(t, feeAmount, rewards)
+	for _, entry := range accountant.Ledger() {
+		if entry.MessageID == messageID {
+			require.Equal(t, relayerUtils.RewardRedeemed, entry.Status)
+		}
+	}
+	_, ok := accountant.Balances()[rewardToken]
+	require.False(t, ok)
+}
+
+// TestRelayerReevaluatesSkippedMessages relays a message skipped for its fee once the fee is
+// increased with addFeeAmount
+func TestRelayerReevaluatesSkippedMessages(t *testing.T) {
+	network, teleporter := newTestNetwork(t)
+	ctx := context.Background()
+	cChainInfo, err := network.GetL1Info("C")
+	require.NoError(t, err)
+	l1AInfo, err := network.GetL1Info("A")
+	require.NoError(t, err)
+	fundedAddress, fundedKey := network.GetFundedAccountInfo()
+
+	feeTokenAddress, feeToken := utils.DeployExampleERC20(ctx, fundedKey, l1AInfo)
+	feeAmount := big.NewInt(100)
+	utils.ERC20Approve(
+		ctx, feeToken, teleporter.TeleporterMessengerAddress(l1AInfo), big.NewInt(200), l1AInfo, fundedKey,
+	)
+	policy, err := relayerUtils.NewPolicy(relayerUtils.PolicyConfig{
+		MinFees: map[relayerUtils.ChainAddress]*big.Int{
+			{BlockchainID: l1AInfo.BlockchainID, Address: feeTokenAddress}: big.NewInt(200),
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	relayerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	relayer, err := relayerUtils.NewRelayer(
+		relayerUtils.Config{
+			Chains:       newRelayerChains(t, network, teleporter, relayerKey),
+			Key:          relayerKey,
+			PollInterval: 100 * time.Millisecond,
+			Policy:       policy,
+		},
+		network.GetSignatureAggregator(),
+	)
+	require.NoError(t, err)
+
+	input := teleportermessenger.TeleporterMessageInput{
+		DestinationBlockchainID: cChainInfo.BlockchainID,
+		DestinationAddress:      fundedAddress,
+		FeeInfo: teleportermessenger.TeleporterFeeInfo{
+			FeeTokenAddress: feeTokenAddress,
+			Amount:          feeAmount,
+		},
+		RequiredGasLimit:        big.NewInt(1),
+		AllowedRelayerAddresses: []common.Address{},
+		Message:                 []byte{1, 2, 3, 4},
+	}
+	receipt, messageID := utils.SendCrossChainMessageAndWaitForAcceptance(
+		ctx, teleporter.TeleporterMessenger(l1AInfo), l1AInfo, cChainInfo, input, fundedKey,
+	)
+	deliveries, err := relayer.ProcessBlocks(
+		ctx, l1AInfo.BlockchainID, receipt.BlockNumber.Uint64(), receipt.BlockNumber.Uint64(),
+	)
+	require.NoError(t, err)
+	require.Empty(t, deliveries)
+
+	// Run evaluates the skipped message again, with the fee it pays once topped up
+	utils.SendAddFeeAmountAndWaitForAcceptance(
+		ctx,
+		l1AInfo,
+		cChainInfo,
+		messageID,
+		feeAmount,
+		feeTokenAddress,
+		fundedKey,
+		teleporter.TeleporterMessenger(l1AInfo),
+	)
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan error)
+	go func() {
+		done <- relayer.Run(runCtx)
+	}()
+	require.Eventually(t, func() bool {
+		received, err := teleporter.TeleporterMessenger(cChainInfo).MessageReceived(&bind.CallOpts{}, messageID)
+		return err == nil && received
+	}, 10*time.Second, 100*time.Millisecond)
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
@@ -0,0 +1,190 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulated
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"testing"
+
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	"github.com/ava-labs/icm-contracts/tests/utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/require"
+)
+
+// teleporterByteCodeFile is produced by forge build
+const teleporterByteCodeFile = "../../out/TeleporterMessenger.sol/TeleporterMessenger.json"
+
+func newTestNetwork(t *testing.T) (*Network, utils.TeleporterTestInfo) {
+	if _, err := os.Stat(teleporterByteCodeFile); err != nil {
+		t.Skipf("TeleporterMessenger artifact not found, run forge build: %s", err)
+	}
+	// The tests/utils helpers assert with Gomega
+	gomega.RegisterTestingT(t)
+
+	network, err := NewNetwork(
+		ChainSpec{Name: "C", EVMChainID: big.NewInt(43112), PrimaryNetwork: true},
+		ChainSpec{Name: "A", EVMChainID: big.NewInt(12345), RequirePrimaryNetworkSigners: true},
+		ChainSpec{Name: "B", EVMChainID: big.NewInt(54321), RequirePrimaryNetworkSigners: true},
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, network.Close())
+	})
+
+	teleporter := utils.NewTeleporterTestInfo(network.GetAllL1Infos())
+	require.NoError(t, network.DeployTeleporterMessenger(context.Background(), teleporter, teleporterByteCodeFile))
+	return network, teleporter
+}
+
+// TestBasicSendReceive follows the basic_send_receive flow between an L1 and the primary network
+func TestBasicSendReceive(t *testing.T) {
+	network, teleporter := newTestNetwork(t)
+	ctx := context.Background()
+	cChainInfo, err := network.GetL1Info("C")
+	require.NoError(t, err)
+	l1AInfo, err := network.GetL1Info("A")
+	require.NoError(t, err)
+	fundedAddress, fundedKey := network.GetFundedAccountInfo()
+
+	feeAmount := big.NewInt(1)
+	feeTokenAddress, feeToken := utils.DeployExampleERC20(ctx, fundedKey, cChainInfo)
+	utils.ERC20Approve(
+		ctx,
+		feeToken,
+		teleporter.TeleporterMessengerAddress(cChainInfo),
+		big.NewInt(0).Mul(big.NewInt(1e18), big.NewInt(10)),
+		cChainInfo,
+		fundedKey,
+	)
+
+	input := teleportermessenger.TeleporterMessageInput{
+		DestinationBlockchainID: l1AInfo.BlockchainID,
+		DestinationAddress:      fundedAddress,
+		FeeInfo: teleportermessenger.TeleporterFeeInfo{
+			FeeTokenAddress: feeTokenAddress,
+			Amount:          feeAmount,
+		},
+		RequiredGasLimit:        big.NewInt(1),
+		AllowedRelayerAddresses: []common.Address{},
+		Message:                 []byte{1, 2, 3, 4},
+	}
+	receipt, messageID := utils.SendCrossChainMessageAndWaitForAcceptance(
+		ctx, teleporter.TeleporterMessenger(cChainInfo), cChainInfo, l1AInfo, input, fundedKey,
+	)
+	network.RelayTeleporterMessage(ctx, teleporter, receipt, cChainInfo, l1AInfo, true, fundedKey)
+	delivered, err := teleporter.TeleporterMessenger(l1AInfo).MessageReceived(&bind.CallOpts{}, messageID)
+	require.NoError(t, err)
+	require.True(t, delivered)
+
+	// The reply carries the receipt of the first message back to the primary network
+	input.DestinationBlockchainID = cChainInfo.BlockchainID
+	input.FeeInfo.Amount = big.NewInt(0)
+	receipt, replyID := utils.SendCrossChainMessageAndWaitForAcceptance(
+		ctx, teleporter.TeleporterMessenger(l1AInfo), l1AInfo, cChainInfo, input, fundedKey,
+	)
+	deliveryReceipt := network.RelayTeleporterMessage(ctx, teleporter, receipt, l1AInfo, cChainInfo, true, fundedKey)
+	require.True(t, utils.CheckReceiptReceived(deliveryReceipt, messageID, teleporter.TeleporterMessenger(cChainInfo)))
+	delivered, err = teleporter.TeleporterMessenger(cChainInfo).MessageReceived(&bind.CallOpts{}, replyID)
+	require.NoError(t, err)
+	require.True(t, delivered)
+
+	utils.RedeemRelayerRewardsAndConfirm(
+		ctx, teleporter.TeleporterMessenger(cChainInfo), cChainInfo, feeToken, feeTokenAddress, fundedKey, feeAmount,
+	)
+}
+
+// TestRequirePrimaryNetworkSigners checks that the Warp precompile of an L1 that requires
+// primary network signers rejects messages from the primary network signed by its own validators
+func TestRequirePrimaryNetworkSigners(t *testing.T) {
+	network, teleporter := newTestNetwork(t)
+	ctx := context.Background()
+	cChainInfo, err := network.GetL1Info("C")
+	require.NoError(t, err)
+	l1AInfo, err := network.GetL1Info("A")
+	require.NoError(t, err)
+	fundedAddress, fundedKey := network.GetFundedAccountInfo()
+
+	input := teleportermessenger.TeleporterMessageInput{
+		DestinationBlockchainID: l1AInfo.BlockchainID,
+		DestinationAddress:      fundedAddress,
+		FeeInfo: teleportermessenger.TeleporterFeeInfo{
+			Amount: big.NewInt(0),
+		},
+		RequiredGasLimit:        big.NewInt(1),
+		AllowedRelayerAddresses: []common.Address{},
+		Message:                 []byte{1, 2, 3, 4},
+	}
+	receipt, messageID := utils.SendCrossChainMessageAndWaitForAcceptance(
+		ctx, teleporter.TeleporterMessenger(cChainInfo), cChainInfo, l1AInfo, input, fundedKey,
+	)
+
+	unsignedMessage := utils.ExtractWarpMessageFromLog(ctx, receipt, cChainInfo)
+	signedMessage, err := network.GetSignatureAggregator().CreateSignedMessage(
+		unsignedMessage, nil, l1AInfo.SubnetID, warp.WarpDefaultQuorumNumerator,
+	)
+	require.NoError(t, err)
+	signedTx := utils.CreateReceiveCrossChainMessageTransaction(
+		ctx,
+		signedMessage,
+		input.RequiredGasLimit,
+		teleporter.TeleporterMessengerAddress(cChainInfo),
+		fundedKey,
+		l1AInfo,
+	)
+	utils.SendTransactionAndWaitForFailure(ctx, l1AInfo, signedTx)
+
+	network.RelayTeleporterMessage(ctx, teleporter, receipt, cChainInfo, l1AInfo, true, fundedKey)
+	delivered, err := teleporter.TeleporterMessenger(l1AInfo).MessageReceived(&bind.CallOpts{}, messageID)
+	require.NoError(t, err)
+	require.True(t, delivered)
+}
+
+// TestPartialSignatures checks that messages signed by a quorum of the validators are delivered,
+// and that messages are not signed when faulty validators leave the quorum unmet
+func TestPartialSignatures(t *testing.T) {
+	network, teleporter := newTestNetwork(t)
+	ctx := context.Background()
+	cChainInfo, err := network.GetL1Info("C")
+	require.NoError(t, err)
+	l1AInfo, err := network.GetL1Info("A")
+	require.NoError(t, err)
+	fundedAddress, fundedKey := network.GetFundedAccountInfo()
+	aggregator := network.GetSignatureAggregator()
+
+	input := teleportermessenger.TeleporterMessageInput{
+		DestinationBlockchainID: l1AInfo.BlockchainID,
+		DestinationAddress:      fundedAddress,
+		FeeInfo: teleportermessenger.TeleporterFeeInfo{
+			Amount: big.NewInt(0),
+		},
+		RequiredGasLimit:        big.NewInt(1),
+		AllowedRelayerAddresses: []common.Address{},
+		Message:                 []byte{1, 2, 3, 4},
+	}
+	receipt, messageID := utils.SendCrossChainMessageAndWaitForAcceptance(
+		ctx, teleporter.TeleporterMessenger(cChainInfo), cChainInfo, l1AInfo, input, fundedKey,
+	)
+	unsignedMessage := utils.ExtractWarpMessageFromLog(ctx, receipt, cChainInfo)
+
+	// Two of the five primary network validators don't meet the quorum
+	primaryValidators := network.validators.subnetValidators[cChainInfo.SubnetID]
+	aggregator.SetFault(primaryValidators[0].NodeID, utils.SignerOffline)
+	aggregator.SetFault(primaryValidators[1].NodeID, utils.SignerInvalidSignature)
+	_, err = aggregator.CreateSignedMessage(
+		unsignedMessage, nil, cChainInfo.SubnetID, warp.WarpDefaultQuorumNumerator,
+	)
+	require.ErrorIs(t, err, utils.ErrInsufficientWeight)
+
+	aggregator.SetFault(primaryValidators[1].NodeID, utils.SignerHonest)
+	teleporter.RelayTeleporterMessage(ctx, receipt, cChainInfo, l1AInfo, true, fundedKey, nil, aggregator)
+	delivered, err := teleporter.TeleporterMessenger(l1AInfo).MessageReceived(&bind.CallOpts{}, messageID)
+	require.NoError(t, err)
+	require.True(t, delivered)
+}
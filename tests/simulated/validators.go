@@ -0,0 +1,101 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulated
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/snow/validators/validatorstest"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls/signer/localsigner"
+	"github.com/ava-labs/icm-contracts/tests/utils"
+	"github.com/pkg/errors"
+)
+
+// pChainHeight is the P-Chain height of every simulated block. Validator sets never change.
+const pChainHeight uint64 = 1
+
+var (
+	ErrUnknownSubnet = errors.New("unknown subnet")
+	ErrUnknownChain  = errors.New("unknown chain")
+)
+
+// validatorState is the P-Chain view shared by all simulated chains. The BLS keys of the
+// validators are held by a mock signature aggregator.
+type validatorState struct {
+	chainSubnets     map[ids.ID]ids.ID
+	subnetValidators map[ids.ID][]*utils.MockValidator
+	aggregator       *utils.MockSignatureAggregator
+}
+
+func newValidatorState() *validatorState {
+	return &validatorState{
+		chainSubnets:     make(map[ids.ID]ids.ID),
+		subnetValidators: make(map[ids.ID][]*utils.MockValidator),
+		aggregator:       utils.NewMockSignatureAggregator(),
+	}
+}
+
+// addSubnet generates validators with [weights] for [subnetID], unless it already has validators
+func (s *validatorState) addSubnet(subnetID ids.ID, weights []uint64) error {
+	if _, ok := s.subnetValidators[subnetID]; ok {
+		return nil
+	}
+	vdrs := make([]*utils.MockValidator, 0, len(weights))
+	for _, weight := range weights {
+		sk, err := localsigner.New()
+		if err != nil {
+			return errors.Wrap(err, "Failed to generate BLS key")
+		}
+		vdrs = append(vdrs, &utils.MockValidator{
+			NodeID: ids.GenerateTestNodeID(),
+			Signer: sk,
+			Weight: weight,
+		})
+	}
+	s.subnetValidators[subnetID] = vdrs
+	s.aggregator.SetValidators(subnetID, vdrs)
+	return nil
+}
+
+func (s *validatorState) addChain(blockchainID ids.ID, subnetID ids.ID) {
+	s.chainSubnets[blockchainID] = subnetID
+	s.aggregator.AddChain(blockchainID, subnetID)
+}
+
+func (s *validatorState) state() *validatorstest.State {
+	return &validatorstest.State{
+		GetCurrentHeightF: func(context.Context) (uint64, error) {
+			return pChainHeight, nil
+		},
+		GetSubnetIDF: func(_ context.Context, chainID ids.ID) (ids.ID, error) {
+			subnetID, ok := s.chainSubnets[chainID]
+			if !ok {
+				return ids.Empty, fmt.Errorf("%w: %s", ErrUnknownChain, chainID)
+			}
+			return subnetID, nil
+		},
+		GetValidatorSetF: func(
+			_ context.Context,
+			_ uint64,
+			subnetID ids.ID,
+		) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			vdrs, ok := s.subnetValidators[subnetID]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrUnknownSubnet, subnetID)
+			}
+			output := make(map[ids.NodeID]*validators.GetValidatorOutput, len(vdrs))
+			for _, vdr := range vdrs {
+				output[vdr.NodeID] = &validators.GetValidatorOutput{
+					NodeID:    vdr.NodeID,
+					PublicKey: vdr.Signer.PublicKey(),
+					Weight:    vdr.Weight,
+				}
+			}
+			return output, nil
+		},
+	}
+}
@@ -20,10 +20,12 @@ import (
 	. "github.com/onsi/gomega"
 )
 
-const (
-	teleporterByteCodeFile  = "./out/TeleporterMessenger.sol/TeleporterMessenger.json"
-	warpGenesisTemplateFile = "./tests/utils/warp-genesis-template.json"
+var (
+	teleporterByteCodeFile  = utils.RepoPath("out/TeleporterMessenger.sol/TeleporterMessenger.json")
+	warpGenesisTemplateFile = utils.RepoPath("tests/utils/warp-genesis-template.json")
+)
 
+const (
 	teleporterMessengerLabel = "TeleporterMessenger"
 	upgradabilityLabel       = "upgradability"
 	utilsLabel               = "utils"
@@ -32,6 +34,11 @@ const (
 var (
 	LocalNetworkInstance *network.LocalNetwork
 	TeleporterInfo       utils.TeleporterTestInfo
+
+	// The network and Teleporter deployment shared by the specs of this process. When running in
+	// parallel, each spec that isn't Serial gets its own Teleporter deployment instead.
+	suiteNetwork    *network.LocalNetwork
+	suiteTeleporter utils.TeleporterTestInfo
 )
 
 func TestTeleporter(t *testing.T) {
@@ -43,8 +50,46 @@ func TestTeleporter(t *testing.T) {
 	ginkgo.RunSpecs(t, "Teleporter e2e test")
 }
 
+func parallelTotal() int {
+	suiteConfig, _ := ginkgo.GinkgoConfiguration()
+	return suiteConfig.ParallelTotal
+}
+
 // Define the Teleporter before and after suite functions.
-var _ = ginkgo.BeforeSuite(func() {
+// The network is set up by the first Ginkgo process, and the other processes attach to it.
+var _ = ginkgo.SynchronizedBeforeSuite(func() []byte {
+	ctx, cancel := context.WithTimeout(context.Background(), 240*2*time.Second)
+	defer cancel()
+
+	// Reattach to a persistent network started by cmd/local-network, eg from ./topology.yaml
+	if dir, ok := os.LookupEnv(network.LocalNetworkDirEnvVar); ok {
+		suiteNetwork = network.ReadLocalNetwork(ctx, dir)
+		suiteTeleporter = suiteNetwork.GetTeleporterInfo()
+		log.Info("Reattached to local network", "dir", dir)
+	} else {
+		setUpLocalNetwork(ctx)
+	}
+
+	if parallelTotal() > 1 {
+		suiteNetwork.SaveTeleporterInfo(suiteTeleporter)
+		suiteNetwork.FundParallelProcesses(ctx, parallelTotal())
+	}
+	log.Info("Set up ginkgo before suite")
+	return []byte(suiteNetwork.Dir())
+}, func(dir []byte) {
+	if parallelTotal() == 1 {
+		return
+	}
+	if ginkgo.GinkgoParallelProcess() != 1 {
+		ctx, cancel := context.WithTimeout(context.Background(), 240*time.Second)
+		defer cancel()
+		suiteNetwork = network.ReadLocalNetwork(ctx, string(dir))
+		suiteTeleporter = suiteNetwork.GetTeleporterInfo()
+	}
+	suiteNetwork = suiteNetwork.ForParallelProcess(ginkgo.GinkgoParallelProcess())
+})
+
+func setUpLocalNetwork(ctx context.Context) {
 	// Generate the Teleporter deployment values
 	teleporterDeployerTransaction,
 		teleporterDeployedBytecode,
@@ -58,10 +103,7 @@ var _ = ginkgo.BeforeSuite(func() {
 	Expect(err).Should(BeNil())
 
 	// Create the local network instance
-	ctx, cancel := context.WithTimeout(context.Background(), 240*2*time.Second)
-	defer cancel()
-
-	LocalNetworkInstance = network.NewLocalNetwork(
+	suiteNetwork = network.NewLocalNetwork(
 		ctx,
 		"teleporter-test-local-network",
 		warpGenesisTemplateFile,
@@ -88,29 +130,29 @@ var _ = ginkgo.BeforeSuite(func() {
 		2,
 		2,
 	)
-	TeleporterInfo = utils.NewTeleporterTestInfo(LocalNetworkInstance.GetAllL1Infos())
+	suiteTeleporter = utils.NewTeleporterTestInfo(suiteNetwork.GetAllL1Infos())
 	log.Info("Started local network")
 
 	// Only need to deploy Teleporter on the C-Chain since it is included in the genesis of the l1 chains.
-	_, fundedKey := LocalNetworkInstance.GetFundedAccountInfo()
-	TeleporterInfo.DeployTeleporterMessenger(
+	_, fundedKey := suiteNetwork.GetFundedAccountInfo()
+	suiteTeleporter.DeployTeleporterMessenger(
 		ctx,
-		LocalNetworkInstance.GetPrimaryNetworkInfo(),
+		suiteNetwork.GetPrimaryNetworkInfo(),
 		teleporterDeployerTransaction,
 		teleporterDeployerAddress,
 		teleporterContractAddress,
 		fundedKey,
 	)
 
-	for _, l1 := range LocalNetworkInstance.GetAllL1Infos() {
-		TeleporterInfo.SetTeleporter(teleporterContractAddress, l1)
-		TeleporterInfo.InitializeBlockchainID(l1, fundedKey)
-		TeleporterInfo.DeployTeleporterRegistry(l1, fundedKey)
+	for _, l1 := range suiteNetwork.GetAllL1Infos() {
+		suiteTeleporter.SetTeleporter(teleporterContractAddress, l1)
+		suiteTeleporter.InitializeBlockchainID(l1, fundedKey)
+		suiteTeleporter.DeployTeleporterRegistry(l1, fundedKey)
 	}
 
-	for _, subnet := range LocalNetworkInstance.GetL1Infos() {
+	for _, subnet := range suiteNetwork.GetL1Infos() {
 		// Choose weights such that we can test validator churn
-		LocalNetworkInstance.ConvertSubnet(
+		suiteNetwork.ConvertSubnet(
 			ctx,
 			subnet,
 			utils.PoAValidatorManager,
@@ -119,12 +161,27 @@ var _ = ginkgo.BeforeSuite(func() {
 			false,
 		)
 	}
+}
 
-	log.Info("Set up ginkgo before suite")
+var _ = ginkgo.BeforeEach(func() {
+	LocalNetworkInstance, TeleporterInfo = suiteNetwork, suiteTeleporter
+	// Serial specs run on their own after the parallel ones, so they can use the shared deployment
+	if parallelTotal() > 1 && !ginkgo.CurrentSpecReport().IsSerial {
+		ctx, cancel := context.WithTimeout(context.Background(), 240*time.Second)
+		defer cancel()
+		LocalNetworkInstance, TeleporterInfo = suiteNetwork.NewIsolatedTeleporter(
+			ctx,
+			ginkgo.CurrentSpecReport().FullText(),
+		)
+	}
 })
 
-var _ = ginkgo.AfterSuite(func() {
-	LocalNetworkInstance.TearDownNetwork()
+var _ = ginkgo.SynchronizedAfterSuite(func() {}, func() {
+	// Persistent networks are left running
+	if _, ok := os.LookupEnv(network.LocalNetworkDirEnvVar); !ok {
+		suiteNetwork.TearDownNetwork()
+	}
+	suiteNetwork = nil
 	LocalNetworkInstance = nil
 })
 
@@ -185,12 +242,18 @@ var _ = ginkgo.Describe("[Teleporter integration tests]", func() {
 		func() {
 			teleporterFlows.CalculateMessageID(LocalNetworkInstance, TeleporterInfo)
 		})
+	ginkgo.It("Message hash and receipt queue",
+		ginkgo.Label(utilsLabel),
+		func() {
+			teleporterFlows.MessageHashAndReceiptQueue(LocalNetworkInstance, TeleporterInfo)
+		})
 	ginkgo.It("Relayer modifies message",
 		ginkgo.Label(teleporterMessengerLabel),
 		func() {
 			teleporterFlows.RelayerModifiesMessage(LocalNetworkInstance, TeleporterInfo)
 		})
 	ginkgo.It("Validator churn",
+		ginkgo.Serial,
 		ginkgo.Label(teleporterMessengerLabel),
 		func() {
 			teleporterFlows.ValidatorChurn(LocalNetworkInstance, TeleporterInfo)
@@ -198,6 +261,7 @@ var _ = ginkgo.Describe("[Teleporter integration tests]", func() {
 
 	// Teleporter Registry tests
 	ginkgo.It("Teleporter registry",
+		ginkgo.Serial,
 		ginkgo.Label(upgradabilityLabel),
 		func() {
 			registryFlows.TeleporterRegistry(LocalNetworkInstance, TeleporterInfo)
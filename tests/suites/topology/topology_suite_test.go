@@ -0,0 +1,92 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package topology_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	localnetwork "github.com/ava-labs/icm-contracts/tests/network"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	// topologyFileEnvVar overrides the topology deployed by the suite
+	topologyFileEnvVar  = "TOPOLOGY_FILE"
+	defaultTopologyFile = "./tests/suites/topology/topology.yaml"
+
+	topologyLabel = "Topology"
+)
+
+var (
+	Topology   *localnetwork.Topology
+	Deployment *localnetwork.TopologyDeployment
+)
+
+func TestTopology(t *testing.T) {
+	if os.Getenv("RUN_E2E") == "" {
+		t.Skip("Environment variable RUN_E2E not set; skipping E2E tests")
+	}
+
+	RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "Topology e2e test")
+}
+
+var _ = ginkgo.BeforeSuite(func() {
+	topologyFile := defaultTopologyFile
+	if fileName, ok := os.LookupEnv(topologyFileEnvVar); ok {
+		topologyFile = fileName
+	}
+	var err error
+	Topology, err = localnetwork.LoadTopology(topologyFile)
+	Expect(err).Should(BeNil())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 240*2*time.Second)
+	defer cancel()
+	Deployment = localnetwork.DeployTopology(ctx, Topology)
+	log.Info("Deployed topology", "file", topologyFile, "dir", Deployment.Network.Dir())
+})
+
+var _ = ginkgo.AfterSuite(func() {
+	Deployment.Network.TearDownNetwork()
+	Deployment = nil
+})
+
+var _ = ginkgo.Describe("[Topology integration tests]", func() {
+	ginkgo.It("Deploy the topology",
+		ginkgo.Label(topologyLabel),
+		func() {
+			ctx := context.Background()
+			expectCode := func(name string, address common.Address) {
+				code, err := Deployment.ChainInfo(name).RPCClient.CodeAt(ctx, address, nil)
+				Expect(err).Should(BeNil())
+				Expect(code).ShouldNot(BeEmpty(), "no contract at %s on %s", address, name)
+			}
+
+			for _, name := range Topology.Contracts.TeleporterRegistries {
+				l1 := Deployment.ChainInfo(name)
+				expectCode(name, Deployment.Teleporter.TeleporterMessengerAddress(l1))
+				expectCode(name, Deployment.Teleporter.TeleporterRegistryAddress(l1))
+			}
+			for _, l1 := range Topology.L1s {
+				if l1.ValidatorManager == nil {
+					continue
+				}
+				validatorManager, _ := Deployment.Network.GetValidatorManager(Deployment.ChainInfo(l1.Name).SubnetID)
+				expectCode(l1.Name, validatorManager.Address)
+			}
+			for _, pair := range Topology.Contracts.ICTTPairs {
+				contracts, ok := Deployment.ICTTPairs[pair.Name]
+				Expect(ok).Should(BeTrue())
+				expectCode(pair.Home, contracts.Token)
+				expectCode(pair.Home, contracts.TokenHome)
+				expectCode(pair.Remote, contracts.TokenRemote)
+			}
+		})
+})
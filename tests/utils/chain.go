@@ -607,7 +607,7 @@ func ConstructSignedWarpMessage(
 	source interfaces.L1TestInfo,
 	destination interfaces.L1TestInfo,
 	justification []byte,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 ) *avalancheWarp.Message {
 	unsignedMsg := ExtractWarpMessageFromLog(ctx, sourceReceipt, source)
 
@@ -626,7 +626,7 @@ func GetSignedMessage(
 	destination interfaces.L1TestInfo,
 	unsignedWarpMessage *avalancheWarp.UnsignedMessage,
 	justification []byte,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 ) *avalancheWarp.Message {
 	signingSubnetID := source.SubnetID
 	if source.SubnetID == constants.PrimaryNetworkID && !destination.RequirePrimaryNetworkSigners {
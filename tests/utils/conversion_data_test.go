@@ -0,0 +1,229 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	warpMessage "github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	acp99manager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/ACP99Manager"
+	fuzzUtils "github.com/ava-labs/icm-contracts/utils/fuzz-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// conversionDataCorpus holds ValidatorMessages.packConversionData outputs. The same encodings
+// are asserted in contracts/validator-manager/tests/ValidatorMessagesTests.t.sol.
+var conversionDataCorpus = []struct {
+	name     string
+	encoding string
+	data     acp99manager.ConversionData
+}{
+	{
+		name: "no validators",
+		encoding: "0000123456781234567812345678123456781234567812345678123456781234" +
+			"5678abcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdef" +
+			"abcd00000014111111111111111111111111111111111111111100000000",
+		data: acp99manager.ConversionData{
+			SubnetID:                     common.HexToHash("0x1234567812345678123456781234567812345678123456781234567812345678"),
+			ValidatorManagerBlockchainID: common.HexToHash("0xabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcd"),
+			ValidatorManagerAddress:      common.HexToAddress("0x1111111111111111111111111111111111111111"),
+			InitialValidators:            []acp99manager.InitialValidator{},
+		},
+	},
+	{
+		name: "two validators",
+		encoding: "0000123456781234567812345678123456781234567812345678123456781234" +
+			"5678abcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdef" +
+			"abcd000000142222222222222222222222222222222222222222000000020000" +
+			"0014123456781234567812345678123456781234567812345678123456781234" +
+			"5678123456781234567812345678123456781234567812345678123456781234" +
+			"5678123456780000000000000064000000147856341278563412785634127856" +
+			"3412785634127856341278563412785634127856341278563412785634127856" +
+			"34127856341278563412785634127856341278563412ffffffffffffffff",
+		data: acp99manager.ConversionData{
+			SubnetID:                     common.HexToHash("0x1234567812345678123456781234567812345678123456781234567812345678"),
+			ValidatorManagerBlockchainID: common.HexToHash("0xabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcd"),
+			ValidatorManagerAddress:      common.HexToAddress("0x2222222222222222222222222222222222222222"),
+			InitialValidators: []acp99manager.InitialValidator{
+				{
+					NodeID: common.FromHex("0x1234567812345678123456781234567812345678"),
+					BlsPublicKey: common.FromHex(
+						"0x123456781234567812345678123456781234567812345678" +
+							"123456781234567812345678123456781234567812345678",
+					),
+					Weight: 100,
+				},
+				{
+					NodeID: common.FromHex("0x7856341278563412785634127856341278563412"),
+					BlsPublicKey: common.FromHex(
+						"0x785634127856341278563412785634127856341278563412" +
+							"785634127856341278563412785634127856341278563412",
+					),
+					Weight: math.MaxUint64,
+				},
+			},
+		},
+	},
+}
+
+func TestPackSubnetConversionDataSolidityCorpus(t *testing.T) {
+	for _, test := range conversionDataCorpus {
+		t.Run(test.name, func(t *testing.T) {
+			encoding := common.FromHex(test.encoding)
+
+			packed, err := PackSubnetConversionData(test.data)
+			require.NoError(t, err)
+			require.Equal(t, encoding, packed)
+
+			// The P-Chain codec must agree with the Solidity encoding, since the validator
+			// manager checks sha256(packConversionData(data)) against the conversion ID.
+			var parsed warpMessage.SubnetToL1ConversionData
+			_, err = warpMessage.Codec.Unmarshal(encoding, &parsed)
+			require.NoError(t, err)
+			conversionID, err := warpMessage.SubnetToL1ConversionID(parsed)
+			require.NoError(t, err)
+			require.Equal(t, ids.ID(sha256.Sum256(packed)), conversionID)
+			require.Equal(t, test.data, toConversionDataABI(parsed))
+		})
+	}
+}
+
+// TestPackInitialValidatorLayout pins the encoding of a single validator, which must follow
+// the order of ValidatorMessages.packConversionData: the length prefixed node ID, then the BLS
+// public key, then the weight.
+func TestPackInitialValidatorLayout(t *testing.T) {
+	nodeID := common.FromHex("0x0102030405")
+	blsPublicKey := make([]byte, 48)
+	for i := range blsPublicKey {
+		blsPublicKey[i] = 0xbb
+	}
+	weight := uint64(0x1122334455667788)
+
+	packed, err := PackInitialValidator(acp99manager.InitialValidator{
+		NodeID:       nodeID,
+		BlsPublicKey: blsPublicKey,
+		Weight:       weight,
+	})
+	require.NoError(t, err)
+	require.Len(t, packed, 4+len(nodeID)+48+8)
+	require.Equal(t, uint32(len(nodeID)), binary.BigEndian.Uint32(packed[0:4]))
+	require.Equal(t, nodeID, packed[4:9])
+	require.Equal(t, blsPublicKey, packed[9:57])
+	require.Equal(t, weight, binary.BigEndian.Uint64(packed[57:65]))
+}
+
+func TestSubnetConversionDataHugeLengthPrefix(t *testing.T) {
+	encoding := common.FromHex(conversionDataCorpus[1].encoding)
+
+	// The validator count follows the fixed 90 byte header, and the first
+	// validator's node ID length follows the count.
+	tests := []struct {
+		name   string
+		offset int
+	}{
+		{name: "validator count", offset: 90},
+		{name: "node ID length", offset: 94},
+	}
+	for _, test := range tests {
+		for _, length := range []uint32{math.MaxInt32, math.MaxUint32} {
+			malformed := common.CopyBytes(encoding)
+			binary.BigEndian.PutUint32(malformed[test.offset:test.offset+4], length)
+
+			var unmarshalErr error
+			allocated := fuzzUtils.AllocatedBytes(func() {
+				var parsed warpMessage.SubnetToL1ConversionData
+				_, unmarshalErr = warpMessage.Codec.Unmarshal(malformed, &parsed)
+			})
+			require.Error(t, unmarshalErr, test.name)
+			require.Less(t, allocated, uint64(fuzzUtils.MaxUnpackAllocation), test.name)
+		}
+	}
+}
+
+// FuzzPackSubnetConversionData checks that the Go packer produces the same bytes as the P-Chain codec
+// for arbitrary conversion data with EVM validator manager addresses.
+func FuzzPackSubnetConversionData(f *testing.F) {
+	f.Add([]byte{1}, []byte{2}, []byte{3}, []byte{4}, []byte{5}, uint64(6), uint8(1))
+	f.Add([]byte{}, []byte{}, []byte{}, []byte{}, []byte{}, uint64(math.MaxUint64), uint8(0))
+
+	f.Fuzz(func(
+		t *testing.T,
+		subnetID []byte,
+		managerChainID []byte,
+		managerAddress []byte,
+		nodeID []byte,
+		blsPublicKey []byte,
+		weight uint64,
+		validatorCount uint8,
+	) {
+		data := warpMessage.SubnetToL1ConversionData{
+			ManagerAddress: common.BytesToAddress(managerAddress).Bytes(),
+			Validators:     make([]warpMessage.SubnetToL1ConversionValidatorData, validatorCount%8),
+		}
+		copy(data.SubnetID[:], subnetID)
+		copy(data.ManagerChainID[:], managerChainID)
+		for i := range data.Validators {
+			data.Validators[i].NodeID = nodeID
+			copy(data.Validators[i].BLSPublicKey[:], blsPublicKey)
+			data.Validators[i].Weight = weight + uint64(i)
+		}
+
+		expected, err := warpMessage.Codec.Marshal(warpMessage.CodecVersion, &data)
+		require.NoError(t, err)
+		packed, err := PackSubnetConversionData(toConversionDataABI(data))
+		require.NoError(t, err)
+		require.Equal(t, expected, packed)
+	})
+}
+
+// FuzzParseSubnetConversionData feeds adversarial bytes to the P-Chain codec and checks that
+// anything it accepts is re-encoded byte for byte by the Go packer.
+func FuzzParseSubnetConversionData(f *testing.F) {
+	for _, test := range conversionDataCorpus {
+		f.Add(common.FromHex(test.encoding))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var parsed warpMessage.SubnetToL1ConversionData
+		var unmarshalErr error
+		allocated := fuzzUtils.AllocatedBytes(func() {
+			parsed = warpMessage.SubnetToL1ConversionData{}
+			_, unmarshalErr = warpMessage.Codec.Unmarshal(data, &parsed)
+		})
+		require.Less(t, allocated, uint64(fuzzUtils.MaxUnpackAllocation+64*len(data)))
+		if unmarshalErr != nil {
+			return
+		}
+		// packConversionData only supports EVM validator managers.
+		if len(parsed.ManagerAddress) != common.AddressLength {
+			return
+		}
+
+		packed, err := PackSubnetConversionData(toConversionDataABI(parsed))
+		require.NoError(t, err)
+		require.Equal(t, data, packed)
+	})
+}
+
+func toConversionDataABI(data warpMessage.SubnetToL1ConversionData) acp99manager.ConversionData {
+	validators := make([]acp99manager.InitialValidator, len(data.Validators))
+	for i, validator := range data.Validators {
+		validators[i] = acp99manager.InitialValidator{
+			NodeID:       validator.NodeID,
+			BlsPublicKey: validator.BLSPublicKey[:],
+			Weight:       validator.Weight,
+		}
+	}
+	return acp99manager.ConversionData{
+		SubnetID:                     data.SubnetID,
+		ValidatorManagerBlockchainID: data.ManagerChainID,
+		ValidatorManagerAddress:      common.BytesToAddress(data.ManagerAddress),
+		InitialValidators:            validators,
+	}
+}
@@ -45,7 +45,7 @@ func ExecuteValidatorSetSigCallAndVerify(
 	validatorSetSigAddress common.Address,
 	senderKey *ecdsa.PrivateKey,
 	unsignedMessage *avalancheWarp.UnsignedMessage,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 	expectSuccess bool,
 ) *types.Receipt {
 	signedWarpMsg := GetSignedMessage(source, destination, unsignedMessage, nil, signatureAggregator)
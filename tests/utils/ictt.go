@@ -307,7 +307,7 @@ func RegisterERC20TokenRemoteOnHome(
 	remoteL1 interfaces.L1TestInfo,
 	remoteAddress common.Address,
 	fundedKey *ecdsa.PrivateKey,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 ) {
 	RegisterTokenRemoteOnHome(
 		ctx,
@@ -335,7 +335,7 @@ func RegisterTokenRemoteOnHome(
 	expectedTokenMultiplier *big.Int,
 	expectedmultiplyOnRemote bool,
 	fundedKey *ecdsa.PrivateKey,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 ) *big.Int {
 	// Call the remote to send a register message to the home
 	tokenRemote, err := tokenremote.NewTokenRemote(
@@ -848,7 +848,7 @@ func SendNativeMultiHopAndVerify(
 	cChainInfo interfaces.L1TestInfo,
 	amount *big.Int,
 	secondaryFeeAmount *big.Int,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 ) {
 	input := nativetokenremote.SendTokensInput{
 		DestinationBlockchainID:            toL1.BlockchainID,
@@ -926,7 +926,7 @@ func SendERC20TokenMultiHopAndVerify(
 	cChainInfo interfaces.L1TestInfo,
 	amount *big.Int,
 	secondaryFeeAmount *big.Int,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 ) {
 	// Send tokens to the sender address to have gas for submitting the send tokens transaction
 	SendNativeTransfer(
@@ -0,0 +1,302 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/snow/validators/validatorstest"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+)
+
+var (
+	_ Aggregator   = (*SignatureAggregator)(nil)
+	_ Aggregator   = (*MockSignatureAggregator)(nil)
+	_ http.Handler = (*MockSignatureAggregator)(nil)
+
+	ErrUnknownSigningSubnet  = errors.New("unknown signing subnet")
+	ErrUnknownSourceChain    = errors.New("unknown source chain")
+	ErrInsufficientWeight    = errors.New("insufficient signing weight")
+	ErrInvalidQuorumPercent  = errors.New("quorum percentage must be between 1 and 100")
+	ErrInvalidAggregateInput = errors.New("invalid aggregate signatures request")
+)
+
+// Aggregator creates Warp messages signed by a quorum of the validators of a subnet. It is
+// implemented by SignatureAggregator, which wraps the signature aggregator binary, and by
+// MockSignatureAggregator, which signs in-process.
+type Aggregator interface {
+	CreateSignedMessage(
+		unsignedMessage *avalancheWarp.UnsignedMessage,
+		justification []byte,
+		inputSigningSubnet ids.ID,
+		quorumPercentage uint64,
+	) (*avalancheWarp.Message, error)
+	Shutdown()
+}
+
+// SignerFault is the simulated misbehaviour of a MockValidator
+type SignerFault int
+
+const (
+	// SignerHonest signs every message
+	SignerHonest SignerFault = iota
+	// SignerOffline never responds to signature requests
+	SignerOffline
+	// SignerInvalidSignature responds with a signature over a different message
+	SignerInvalidSignature
+)
+
+// MockValidator is a validator whose BLS key is held by a MockSignatureAggregator
+type MockValidator struct {
+	NodeID ids.NodeID
+	Signer bls.Signer
+	Weight uint64
+	Fault  SignerFault
+}
+
+// MockSignatureAggregator implements the signature aggregator with local BLS keys, both as a Go
+// Aggregator and as the aggregator's HTTP API. Like the signature aggregator, it requests
+// signatures from the validators of the signing subnet in their canonical order, discards
+// invalid signatures, and fails if the valid signatures don't meet the quorum. Justifications
+// are ignored, since every validator is willing to sign every message.
+type MockSignatureAggregator struct {
+	lock         sync.RWMutex
+	subnets      map[ids.ID][]*MockValidator
+	chainSubnets map[ids.ID]ids.ID
+}
+
+func NewMockSignatureAggregator() *MockSignatureAggregator {
+	return &MockSignatureAggregator{
+		subnets:      make(map[ids.ID][]*MockValidator),
+		chainSubnets: make(map[ids.ID]ids.ID),
+	}
+}
+
+// SetValidators replaces the validators of [subnetID]
+func (a *MockSignatureAggregator) SetValidators(subnetID ids.ID, vdrs []*MockValidator) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.subnets[subnetID] = vdrs
+}
+
+// AddChain registers [blockchainID] as a chain of [subnetID]. HTTP requests without a signing
+// subnet for messages from the chain are signed by the validators of [subnetID].
+func (a *MockSignatureAggregator) AddChain(blockchainID ids.ID, subnetID ids.ID) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.chainSubnets[blockchainID] = subnetID
+}
+
+// SetFault changes the simulated misbehaviour of the validator [nodeID] of every subnet
+func (a *MockSignatureAggregator) SetFault(nodeID ids.NodeID, fault SignerFault) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	for _, vdrs := range a.subnets {
+		for _, vdr := range vdrs {
+			if vdr.NodeID == nodeID {
+				vdr.Fault = fault
+			}
+		}
+	}
+}
+
+// Shutdown is a no-op, since the aggregator holds no resources
+func (*MockSignatureAggregator) Shutdown() {}
+
+func (a *MockSignatureAggregator) CreateSignedMessage(
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	_ []byte,
+	inputSigningSubnet ids.ID,
+	quorumPercentage uint64,
+) (*avalancheWarp.Message, error) {
+	if quorumPercentage == 0 {
+		quorumPercentage = warp.WarpDefaultQuorumNumerator
+	}
+	if quorumPercentage > 100 {
+		return nil, ErrInvalidQuorumPercent
+	}
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	vdrs, ok := a.subnets[inputSigningSubnet]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSigningSubnet, inputSigningSubnet)
+	}
+
+	// The signers bit set indexes the canonical validator set, which orders validators by public
+	// key and merges validators sharing a key
+	validatorSet, err := avalancheWarp.GetCanonicalValidatorSetFromChainID(
+		context.Background(),
+		mockValidatorState(inputSigningSubnet, vdrs),
+		0,
+		unsignedMessage.SourceChainID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	byPublicKey := make(map[string]*MockValidator, len(vdrs))
+	for _, vdr := range vdrs {
+		byPublicKey[string(bls.PublicKeyToUncompressedBytes(vdr.Signer.PublicKey()))] = vdr
+	}
+
+	messageBytes := unsignedMessage.Bytes()
+	signers := set.NewBits()
+	signatures := make([]*bls.Signature, 0, len(validatorSet.Validators))
+	signedWeight := uint64(0)
+	for i, canonical := range validatorSet.Validators {
+		vdr := byPublicKey[string(canonical.PublicKeyBytes)]
+		signature, ok := vdr.sign(messageBytes)
+		if !ok || !bls.Verify(canonical.PublicKey, signature, messageBytes) {
+			continue
+		}
+		signers.Add(i)
+		signatures = append(signatures, signature)
+		signedWeight += canonical.Weight
+	}
+
+	// signedWeight / totalWeight >= quorumPercentage / 100
+	if len(signatures) == 0 || signedWeight*100 < validatorSet.TotalWeight*quorumPercentage {
+		return nil, fmt.Errorf(
+			"%w: %d of %d signed, %d%% required",
+			ErrInsufficientWeight,
+			signedWeight,
+			validatorSet.TotalWeight,
+			quorumPercentage,
+		)
+	}
+	aggregateSignature, err := bls.AggregateSignatures(signatures)
+	if err != nil {
+		return nil, err
+	}
+	signature := &avalancheWarp.BitSetSignature{Signers: signers.Bytes()}
+	copy(signature.Signature[:], bls.SignatureToBytes(aggregateSignature))
+	return avalancheWarp.NewMessage(unsignedMessage, signature)
+}
+
+// ServeHTTP implements the signature aggregator's aggregate signatures endpoint
+func (a *MockSignatureAggregator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != SIG_AGG_API_PATH {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var request AggregateSignaturesRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("%s: %s", ErrInvalidAggregateInput, err), http.StatusBadRequest)
+		return
+	}
+	unsignedMessage, justification, err := parseAggregateSignaturesRequest(request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Without a signing subnet, the message is signed by the subnet of its source chain
+	var signingSubnetID ids.ID
+	if request.SigningSubnetID != "" {
+		signingSubnetID, err = ids.FromString(request.SigningSubnetID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("%s: signing subnet ID: %s", ErrInvalidAggregateInput, err), http.StatusBadRequest)
+			return
+		}
+	} else {
+		var ok bool
+		signingSubnetID, ok = a.sourceSubnet(unsignedMessage.SourceChainID)
+		if !ok {
+			http.Error(w, fmt.Sprintf("%s: %s", ErrUnknownSourceChain, unsignedMessage.SourceChainID), http.StatusBadRequest)
+			return
+		}
+	}
+
+	signedMessage, err := a.CreateSignedMessage(
+		unsignedMessage,
+		justification,
+		signingSubnetID,
+		request.QuorumPercentage,
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(SignatureAggregatorResponse{
+		SignedMessage: hex.EncodeToString(signedMessage.Bytes()),
+	})
+}
+
+func parseAggregateSignaturesRequest(
+	request AggregateSignaturesRequest,
+) (*avalancheWarp.UnsignedMessage, []byte, error) {
+	messageBytes, err := hex.DecodeString(strings.TrimPrefix(request.Message, "0x"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: message: %w", ErrInvalidAggregateInput, err)
+	}
+	unsignedMessage, err := avalancheWarp.ParseUnsignedMessage(messageBytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: message: %w", ErrInvalidAggregateInput, err)
+	}
+	justification, err := hex.DecodeString(strings.TrimPrefix(request.Justification, "0x"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: justification: %w", ErrInvalidAggregateInput, err)
+	}
+	return unsignedMessage, justification, nil
+}
+
+func (a *MockSignatureAggregator) sourceSubnet(blockchainID ids.ID) (ids.ID, bool) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	subnetID, ok := a.chainSubnets[blockchainID]
+	return subnetID, ok
+}
+
+// sign returns the validator's response to a signature request over [message], if any
+func (v *MockValidator) sign(message []byte) (*bls.Signature, bool) {
+	if v == nil {
+		return nil, false
+	}
+	switch v.Fault {
+	case SignerOffline:
+		return nil, false
+	case SignerInvalidSignature:
+		return v.Signer.Sign(append([]byte{0xff}, message...)), true
+	default:
+		return v.Signer.Sign(message), true
+	}
+}
+
+// mockValidatorState is a P-Chain view in which every chain belongs to [subnetID]
+func mockValidatorState(subnetID ids.ID, vdrs []*MockValidator) *validatorstest.State {
+	return &validatorstest.State{
+		GetSubnetIDF: func(context.Context, ids.ID) (ids.ID, error) {
+			return subnetID, nil
+		},
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			output := make(map[ids.NodeID]*validators.GetValidatorOutput, len(vdrs))
+			for _, vdr := range vdrs {
+				output[vdr.NodeID] = &validators.GetValidatorOutput{
+					NodeID:    vdr.NodeID,
+					PublicKey: vdr.Signer.PublicKey(),
+					Weight:    vdr.Weight,
+				}
+			}
+			return output, nil
+		},
+	}
+}
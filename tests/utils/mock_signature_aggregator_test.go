@@ -0,0 +1,168 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls/signer/localsigner"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMockAggregator(t *testing.T, subnetID ids.ID, weights []uint64) (
+	*MockSignatureAggregator,
+	[]*MockValidator,
+) {
+	vdrs := make([]*MockValidator, 0, len(weights))
+	for _, weight := range weights {
+		sk, err := localsigner.New()
+		require.NoError(t, err)
+		vdrs = append(vdrs, &MockValidator{
+			NodeID: ids.GenerateTestNodeID(),
+			Signer: sk,
+			Weight: weight,
+		})
+	}
+	aggregator := NewMockSignatureAggregator()
+	aggregator.SetValidators(subnetID, vdrs)
+	return aggregator, vdrs
+}
+
+// verifyMessage verifies [message] as the Warp precompile of a chain of [subnetID] does
+func verifyMessage(
+	t *testing.T,
+	message *avalancheWarp.Message,
+	subnetID ids.ID,
+	vdrs []*MockValidator,
+	quorumPercentage uint64,
+) error {
+	validatorSet, err := avalancheWarp.GetCanonicalValidatorSetFromChainID(
+		context.Background(),
+		mockValidatorState(subnetID, vdrs),
+		0,
+		message.SourceChainID,
+	)
+	require.NoError(t, err)
+	return message.Signature.Verify(
+		&message.UnsignedMessage,
+		constants.UnitTestID,
+		validatorSet,
+		quorumPercentage,
+		100,
+	)
+}
+
+func TestMockSignatureAggregatorQuorum(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	aggregator, vdrs := newTestMockAggregator(t, subnetID, []uint64{40, 30, 20, 10})
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(constants.UnitTestID, ids.GenerateTestID(), []byte{1})
+	require.NoError(t, err)
+
+	message, err := aggregator.CreateSignedMessage(unsignedMessage, nil, subnetID, 0)
+	require.NoError(t, err)
+	require.NoError(t, verifyMessage(t, message, subnetID, vdrs, 100))
+
+	// 70% of the weight signs
+	aggregator.SetFault(vdrs[2].NodeID, SignerOffline)
+	aggregator.SetFault(vdrs[3].NodeID, SignerInvalidSignature)
+	message, err = aggregator.CreateSignedMessage(unsignedMessage, nil, subnetID, warp.WarpDefaultQuorumNumerator)
+	require.NoError(t, err)
+	numSigners, err := message.Signature.NumSigners()
+	require.NoError(t, err)
+	require.Equal(t, 2, numSigners)
+	require.NoError(t, verifyMessage(t, message, subnetID, vdrs, warp.WarpDefaultQuorumNumerator))
+	require.Error(t, verifyMessage(t, message, subnetID, vdrs, 71))
+
+	_, err = aggregator.CreateSignedMessage(unsignedMessage, nil, subnetID, 71)
+	require.ErrorIs(t, err, ErrInsufficientWeight)
+	_, err = aggregator.CreateSignedMessage(unsignedMessage, nil, subnetID, 101)
+	require.ErrorIs(t, err, ErrInvalidQuorumPercent)
+	_, err = aggregator.CreateSignedMessage(unsignedMessage, nil, ids.GenerateTestID(), 0)
+	require.ErrorIs(t, err, ErrUnknownSigningSubnet)
+}
+
+func TestMockSignatureAggregatorHTTP(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	sourceChainID := ids.GenerateTestID()
+	aggregator, vdrs := newTestMockAggregator(t, subnetID, []uint64{1, 1, 1})
+	aggregator.AddChain(sourceChainID, subnetID)
+	server := httptest.NewServer(aggregator)
+	defer server.Close()
+
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(constants.UnitTestID, sourceChainID, []byte{1})
+	require.NoError(t, err)
+	unknownSourceMessage, err := avalancheWarp.NewUnsignedMessage(constants.UnitTestID, ids.GenerateTestID(), []byte{1})
+	require.NoError(t, err)
+
+	post := func(request AggregateSignaturesRequest) *http.Response {
+		body, err := json.Marshal(request)
+		require.NoError(t, err)
+		res, err := http.Post(server.URL+SIG_AGG_API_PATH, "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+		return res
+	}
+
+	// Without a signing subnet, the subnet of the source chain signs
+	for _, signingSubnetID := range []string{"", subnetID.String()} {
+		res := post(AggregateSignaturesRequest{
+			Message:         hex.EncodeToString(unsignedMessage.Bytes()),
+			SigningSubnetID: signingSubnetID,
+		})
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		var response SignatureAggregatorResponse
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&response))
+		require.NoError(t, res.Body.Close())
+
+		messageBytes, err := hex.DecodeString(response.SignedMessage)
+		require.NoError(t, err)
+		message, err := avalancheWarp.ParseMessage(messageBytes)
+		require.NoError(t, err)
+		require.NoError(t, verifyMessage(t, message, subnetID, vdrs, 100))
+	}
+
+	tests := []struct {
+		name       string
+		request    AggregateSignaturesRequest
+		statusCode int
+	}{
+		{
+			name:       "invalid message",
+			request:    AggregateSignaturesRequest{Message: "zz"},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "unknown source chain",
+			request: AggregateSignaturesRequest{
+				Message: hex.EncodeToString(unknownSourceMessage.Bytes()),
+			},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "insufficient weight",
+			request: AggregateSignaturesRequest{
+				Message:          hex.EncodeToString(unsignedMessage.Bytes()),
+				QuorumPercentage: 100,
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+	aggregator.SetFault(vdrs[0].NodeID, SignerOffline)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := post(test.request)
+			require.Equal(t, test.statusCode, res.StatusCode)
+			require.NoError(t, res.Body.Close())
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// RepoRootEnvVar overrides the repository root that RepoPath resolves paths against
+const RepoRootEnvVar = "ICM_CONTRACTS_PATH"
+
+// RepoPath returns [path], relative to the repository root, as an absolute path. Ginkgo runs
+// parallel processes from the suite's package directory, so paths relative to the working
+// directory only resolve when a suite binary is run from the repository root.
+func RepoPath(path string) string {
+	if root, ok := os.LookupEnv(RepoRootEnvVar); ok {
+		return filepath.Join(root, path)
+	}
+	// This file is in tests/utils
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", path)
+}
@@ -8,28 +8,125 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"os"
 	"os/exec"
+	"sync"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/icm-contracts/tests/interfaces"
 	"github.com/ethereum/go-ethereum/log"
-
-	. "github.com/onsi/gomega"
 )
 
 const (
-	DEFAULT_API_PORT = 8080
-	SIG_AGG_API_PATH = "/aggregate-signatures"
+	SIG_AGG_API_PATH    = "/aggregate-signatures"
+	SIG_AGG_HEALTH_PATH = "/health"
+
+	// stderrTailSize is the number of bytes of the aggregator's stderr included in errors
+	stderrTailSize = 4096
+)
+
+var (
+	ErrSignatureAggregatorPathNotSet = errors.New("SIG_AGG_PATH is not set")
+	ErrSignatureAggregatorNotReady   = errors.New("signature aggregator did not become ready")
+	ErrSignatureAggregatorExited     = errors.New("signature aggregator exited")
 )
 
 // This is a wrapper around a signature aggregator binary instead of importing the package directly
 // to avoid cyclic dependencies
 type SignatureAggregator struct {
+	baseURL    string
+	client     *http.Client
+	options    SignatureAggregatorOptions
 	cmd        *exec.Cmd
 	cancelFunc context.CancelFunc
+	configFile string
+	stderr     *tailBuffer
+	// exited is closed once the process exits
+	exited chan struct{}
+}
+
+// SignatureAggregatorOptions configures the signature aggregator process and its client. Zero
+// values are replaced by the defaults.
+type SignatureAggregatorOptions struct {
+	// APIPort of the aggregator. Defaults to a free port, so that several aggregators can run
+	// concurrently.
+	APIPort int
+	// MetricsPort of the aggregator. Defaults to a free port.
+	MetricsPort int
+	// ReadinessTimeout bounds the time waited for the aggregator to become ready
+	ReadinessTimeout time.Duration
+	// ReadinessProbes are aggregated once the health endpoint reports ready, and the aggregator
+	// is only ready once each of them succeeded. The health endpoint reports ready before the
+	// aggregator is connected to the validators, so it alone does not guarantee that requests
+	// can be served.
+	ReadinessProbes []ReadinessProbe
+	// RequestTimeout bounds each aggregate signatures request
+	RequestTimeout time.Duration
+	// MaxRetries of requests failing with transient errors. Negative values disable retries.
+	MaxRetries int
+	// InitialBackoff is doubled after each failed attempt, up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+var DefaultSignatureAggregatorOptions = SignatureAggregatorOptions{
+	ReadinessTimeout: 30 * time.Second,
+	RequestTimeout:   20 * time.Second,
+	MaxRetries:       5,
+	InitialBackoff:   250 * time.Millisecond,
+	MaxBackoff:       5 * time.Second,
+}
+
+func (o SignatureAggregatorOptions) withDefaults() SignatureAggregatorOptions {
+	if o.ReadinessTimeout == 0 {
+		o.ReadinessTimeout = DefaultSignatureAggregatorOptions.ReadinessTimeout
+	}
+	if o.RequestTimeout == 0 {
+		o.RequestTimeout = DefaultSignatureAggregatorOptions.RequestTimeout
+	}
+	if o.MaxRetries == 0 {
+		o.MaxRetries = DefaultSignatureAggregatorOptions.MaxRetries
+	}
+	if o.InitialBackoff == 0 {
+		o.InitialBackoff = DefaultSignatureAggregatorOptions.InitialBackoff
+	}
+	if o.MaxBackoff == 0 {
+		o.MaxBackoff = DefaultSignatureAggregatorOptions.MaxBackoff
+	}
+	return o
+}
+
+// ReadinessProbe is a message that the validators of [SigningSubnetID] sign once they are reachable
+type ReadinessProbe struct {
+	Message         *avalancheWarp.UnsignedMessage
+	SigningSubnetID ids.ID
+}
+
+// NewBlockHashReadinessProbe returns a probe for the hash of the last accepted block of [l1],
+// which its validators sign without a justification
+func NewBlockHashReadinessProbe(
+	ctx context.Context,
+	networkID uint32,
+	l1 interfaces.L1TestInfo,
+) (ReadinessProbe, error) {
+	header, err := l1.RPCClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return ReadinessProbe{}, fmt.Errorf("failed to get last accepted block: %w", err)
+	}
+	blockHash, err := payload.NewHash(ids.ID(header.Hash()))
+	if err != nil {
+		return ReadinessProbe{}, err
+	}
+	message, err := avalancheWarp.NewUnsignedMessage(networkID, l1.BlockchainID, blockHash.Bytes())
+	if err != nil {
+		return ReadinessProbe{}, err
+	}
+	return ReadinessProbe{Message: message, SigningSubnetID: l1.SubnetID}, nil
 }
 
 type SignatureAggregatorConfig struct {
@@ -37,6 +134,7 @@ type SignatureAggregatorConfig struct {
 	InfoAPI         ApiConfig `json:"info-api"`
 	SubnetIDs       []string  `json:"tracked-subnet-ids"`
 	ApiPort         int       `json:"api-port"`
+	MetricsPort     int       `json:"metrics-port"`
 	AllowPrivateIPs bool      `json:"allow-private-ips"`
 }
 
@@ -57,14 +155,73 @@ type SignatureAggregatorResponse struct {
 	SignedMessage string `json:"signed-message"`
 }
 
+// aggregatorStatusError is returned for responses other than 200 OK
+type aggregatorStatusError struct {
+	statusCode int
+	body       string
+}
+
+func (e *aggregatorStatusError) Error() string {
+	return fmt.Sprintf("expected status code 200, got %d: %s", e.statusCode, e.body)
+}
+
+// Shutdown stops the aggregator process, if any, and waits for it to exit
 func (s *SignatureAggregator) Shutdown() {
+	if s.cmd == nil {
+		return
+	}
 	s.cancelFunc()
+	<-s.exited
+	if err := os.Remove(s.configFile); err != nil {
+		log.Warn("Failed to remove signature aggregator config", "file", s.configFile, "err", err)
+	}
 }
 
-// Aggregator utils
-func NewSignatureAggregator(apiUri string, subnetIDs []ids.ID) *SignatureAggregator {
+// NewSignatureAggregator starts a signature aggregator tracking the subnets of [l1s], and waits
+// until it can aggregate signatures over a block hash of each of them
+func NewSignatureAggregator(
+	apiUri string,
+	networkID uint32,
+	l1s []interfaces.L1TestInfo,
+) (*SignatureAggregator, error) {
+	options := DefaultSignatureAggregatorOptions
+	ctx, cancel := context.WithTimeout(context.Background(), options.RequestTimeout)
+	defer cancel()
+	subnetIDs := make([]ids.ID, 0, len(l1s))
+	for _, l1 := range l1s {
+		probe, err := NewBlockHashReadinessProbe(ctx, networkID, l1)
+		if err != nil {
+			return nil, err
+		}
+		options.ReadinessProbes = append(options.ReadinessProbes, probe)
+		subnetIDs = append(subnetIDs, l1.SubnetID)
+	}
+	return NewSignatureAggregatorWithOptions(apiUri, subnetIDs, options)
+}
+
+// NewSignatureAggregatorWithOptions starts the signature aggregator binary at SIG_AGG_PATH,
+// tracking [subnetIDs] of the network served at [apiUri], and waits until it is ready.
+func NewSignatureAggregatorWithOptions(
+	apiUri string,
+	subnetIDs []ids.ID,
+	options SignatureAggregatorOptions,
+) (*SignatureAggregator, error) {
+	options = options.withDefaults()
 	sigAggPath := os.Getenv("SIG_AGG_PATH")
-	Expect(sigAggPath).ShouldNot(BeEmpty())
+	if sigAggPath == "" {
+		return nil, ErrSignatureAggregatorPathNotSet
+	}
+	for _, port := range []*int{&options.APIPort, &options.MetricsPort} {
+		if *port != 0 {
+			continue
+		}
+		freePort, err := getFreePort()
+		if err != nil {
+			return nil, err
+		}
+		*port = freePort
+	}
+
 	subnetIDStrings := make([]string, 0, len(subnetIDs))
 	for _, subnetID := range subnetIDs {
 		subnetIDStrings = append(subnetIDStrings, subnetID.String())
@@ -77,84 +234,184 @@ func NewSignatureAggregator(apiUri string, subnetIDs []ids.ID) *SignatureAggrega
 			BaseURL: apiUri,
 		},
 		SubnetIDs:       subnetIDStrings,
-		ApiPort:         DEFAULT_API_PORT,
+		ApiPort:         options.APIPort,
+		MetricsPort:     options.MetricsPort,
 		AllowPrivateIPs: true,
 	}
-	// write config to a JSON file in /tmp directory
-	configFile, err := os.CreateTemp("/tmp", "sig_agg_config_*.json")
-	Expect(err).Should(BeNil())
-	defer configFile.Close()
-
-	encoder := json.NewEncoder(configFile)
-	err = encoder.Encode(cfg)
-	Expect(err).Should(BeNil())
+	configFile, err := writeSignatureAggregatorConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
-
-	cmd := exec.CommandContext(ctx, sigAggPath, "--config-file", configFile.Name())
+	stderr := newTailBuffer(stderrTailSize)
+	cmd := exec.CommandContext(ctx, sigAggPath, "--config-file", configFile)
 	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	err = cmd.Start()
-	Expect(err).Should(BeNil())
+	cmd.Stderr = io.MultiWriter(os.Stderr, stderr)
+	if err := cmd.Start(); err != nil {
+		cancel()
+		os.Remove(configFile)
+		return nil, fmt.Errorf("failed to start signature aggregator: %w", err)
+	}
+
+	s := NewSignatureAggregatorClient(fmt.Sprintf("http://localhost:%d", options.APIPort), options)
+	s.cmd = cmd
+	s.cancelFunc = cancel
+	s.configFile = configFile
+	s.stderr = stderr
+	s.exited = make(chan struct{})
 	go func() {
 		err := cmd.Wait()
+		close(s.exited)
 		// Context cancellation is the only expected way for the process to exit, otherwise log an error
 		// Don't panic to allow for easier cleanup
 		if !errors.Is(ctx.Err(), context.Canceled) {
-			log.Error("Signature aggregator exited abnormally", err)
+			log.Error("Signature aggregator exited abnormally", "err", err, "stderr", stderr.String())
 		}
 	}()
 
-	// TODO: when the signature aggregator health check endpoint is improved to not return
-	// before ready to serve requests replace this sleep.
-	time.Sleep(time.Second * 5)
+	if err := s.waitUntilReady(); err != nil {
+		s.Shutdown()
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewSignatureAggregatorClient returns a client of an aggregator that is already serving at
+// [baseURL], such as a MockSignatureAggregator
+func NewSignatureAggregatorClient(baseURL string, options SignatureAggregatorOptions) *SignatureAggregator {
+	options = options.withDefaults()
 	return &SignatureAggregator{
-		cancelFunc: cancel,
-		cmd:        cmd,
+		baseURL: baseURL,
+		client:  &http.Client{Timeout: options.RequestTimeout},
+		options: options,
+	}
+}
+
+// waitUntilReady polls the health endpoint and then the readiness probes with exponential
+// backoff, until all of them succeed
+func (s *SignatureAggregator) waitUntilReady() error {
+	deadline := time.Now().Add(s.options.ReadinessTimeout)
+	backoff := s.options.InitialBackoff
+	probes := s.options.ReadinessProbes
+	for {
+		if err := s.exitError(); err != nil {
+			return err
+		}
+		err := s.checkHealth()
+		if err == nil {
+			for len(probes) > 0 {
+				if err = s.probe(probes[0]); err != nil {
+					break
+				}
+				probes = probes[1:]
+			}
+		}
+		if err == nil {
+			return nil
+		}
+		if time.Now().Add(backoff).After(deadline) {
+			return fmt.Errorf(
+				"%w after %s: %w%s",
+				ErrSignatureAggregatorNotReady,
+				s.options.ReadinessTimeout,
+				err,
+				s.stderrTail(),
+			)
+		}
+		s.sleep(backoff)
+		backoff = min(2*backoff, s.options.MaxBackoff)
+	}
+}
+
+func (s *SignatureAggregator) checkHealth() error {
+	res, err := s.client.Get(s.baseURL + SIG_AGG_HEALTH_PATH)
+	if err != nil {
+		return err
 	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return &aggregatorStatusError{statusCode: res.StatusCode}
+	}
+	return nil
+}
+
+// probe aggregates signatures over [probe] once, without retries
+func (s *SignatureAggregator) probe(probe ReadinessProbe) error {
+	reqBody, err := newAggregateSignaturesRequest(probe.Message, nil, probe.SigningSubnetID, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := s.aggregateSignatures(reqBody); err != nil {
+		return fmt.Errorf("readiness probe for subnet %s failed: %w", probe.SigningSubnetID, err)
+	}
+	return nil
 }
 
+// CreateSignedMessage requests signatures over [unsignedMessage], retrying transient failures
+// with exponential backoff
 func (s *SignatureAggregator) CreateSignedMessage(
 	unsignedMessage *avalancheWarp.UnsignedMessage,
 	justification []byte,
 	inputSigningSubnet ids.ID,
 	quorumPercentage uint64,
 ) (*avalancheWarp.Message, error) {
-	client := &http.Client{
-		Timeout: 20 * time.Second,
+	b, err := newAggregateSignaturesRequest(unsignedMessage, justification, inputSigningSubnet, quorumPercentage)
+	if err != nil {
+		return nil, err
+	}
+
+	backoff := s.options.InitialBackoff
+	for attempt := 0; ; attempt++ {
+		if err := s.exitError(); err != nil {
+			return nil, err
+		}
+		signedMessage, err := s.aggregateSignatures(b)
+		if err == nil {
+			return signedMessage, nil
+		}
+		if !isTransientAggregatorError(err) || attempt >= s.options.MaxRetries {
+			return nil, fmt.Errorf("failed to aggregate signatures after %d attempts: %w%s", attempt+1, err, s.stderrTail())
+		}
+		log.Warn("Retrying signature aggregation", "attempt", attempt+1, "backoff", backoff, "err", err)
+		s.sleep(backoff)
+		backoff = min(2*backoff, s.options.MaxBackoff)
 	}
-	requestURL := fmt.Sprintf("http://localhost:%d%s", DEFAULT_API_PORT, SIG_AGG_API_PATH)
-	reqBody := AggregateSignaturesRequest{
+}
+
+func newAggregateSignaturesRequest(
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	justification []byte,
+	signingSubnetID ids.ID,
+	quorumPercentage uint64,
+) ([]byte, error) {
+	return json.Marshal(AggregateSignaturesRequest{
 		Message:          hex.EncodeToString(unsignedMessage.Bytes()),
 		Justification:    hex.EncodeToString(justification),
-		SigningSubnetID:  inputSigningSubnet.String(),
+		SigningSubnetID:  signingSubnetID.String(),
 		QuorumPercentage: quorumPercentage,
-	}
-
-	b, err := json.Marshal(reqBody)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader := bytes.NewReader(b)
+	})
+}
 
-	req, err := http.NewRequest(http.MethodPost, requestURL, bodyReader)
+func (s *SignatureAggregator) aggregateSignatures(reqBody []byte) (*avalancheWarp.Message, error) {
+	req, err := http.NewRequest(http.MethodPost, s.baseURL+SIG_AGG_API_PATH, bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := client.Do(req)
+	res, err := s.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer res.Body.Close()
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("expected status code 200, got %d", res.StatusCode)
-	}
 
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
 		return nil, err
 	}
+	if res.StatusCode != http.StatusOK {
+		return nil, &aggregatorStatusError{statusCode: res.StatusCode, body: string(bytes.TrimSpace(body))}
+	}
 
 	var response SignatureAggregatorResponse
 	err = json.Unmarshal(body, &response)
@@ -174,3 +431,99 @@ func (s *SignatureAggregator) CreateSignedMessage(
 
 	return signedMessage, nil
 }
+
+// isTransientAggregatorError returns true for connection failures and 503 Service Unavailable.
+// Other errors, including other server errors, are not expected to resolve on their own.
+func isTransientAggregatorError(err error) bool {
+	var statusErr *aggregatorStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.statusCode == http.StatusServiceUnavailable
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// exitError returns an error including the aggregator's stderr if its process exited
+func (s *SignatureAggregator) exitError() error {
+	if s.exited == nil {
+		return nil
+	}
+	select {
+	case <-s.exited:
+		return fmt.Errorf("%w: %s%s", ErrSignatureAggregatorExited, s.cmd.ProcessState, s.stderrTail())
+	default:
+		return nil
+	}
+}
+
+// sleep waits for [d], or until the aggregator process exits
+func (s *SignatureAggregator) sleep(d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-s.exited:
+	}
+}
+
+func (s *SignatureAggregator) stderrTail() string {
+	if s.stderr == nil {
+		return ""
+	}
+	tail := s.stderr.String()
+	if tail == "" {
+		return ""
+	}
+	return "\nsignature aggregator stderr:\n" + tail
+}
+
+func writeSignatureAggregatorConfig(cfg SignatureAggregatorConfig) (string, error) {
+	configFile, err := os.CreateTemp("", "sig_agg_config_*.json")
+	if err != nil {
+		return "", fmt.Errorf("failed to create signature aggregator config: %w", err)
+	}
+	defer configFile.Close()
+
+	if err := json.NewEncoder(configFile).Encode(cfg); err != nil {
+		os.Remove(configFile.Name())
+		return "", fmt.Errorf("failed to write signature aggregator config: %w", err)
+	}
+	return configFile.Name(), nil
+}
+
+// getFreePort returns a port that was free when checked
+func getFreePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free port: %w", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// tailBuffer is an io.Writer that keeps the last [size] bytes written to it
+type tailBuffer struct {
+	lock sync.Mutex
+	size int
+	buf  []byte
+}
+
+func newTailBuffer(size int) *tailBuffer {
+	return &tailBuffer{size: size}
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > b.size {
+		b.buf = b.buf[len(b.buf)-b.size:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return string(b.buf)
+}
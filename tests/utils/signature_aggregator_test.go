@@ -0,0 +1,177 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyHandler fails the first [failures] requests with [statusCode]
+type flakyHandler struct {
+	http.Handler
+	failures   int32
+	statusCode int
+	requests   atomic.Int32
+}
+
+func (h *flakyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.requests.Add(1) <= h.failures {
+		http.Error(w, "unavailable", h.statusCode)
+		return
+	}
+	h.Handler.ServeHTTP(w, r)
+}
+
+func TestSignatureAggregatorClientRetries(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	aggregator, vdrs := newTestMockAggregator(t, subnetID, []uint64{1, 1, 1})
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(constants.UnitTestID, ids.GenerateTestID(), []byte{1})
+	require.NoError(t, err)
+	options := SignatureAggregatorOptions{
+		MaxRetries:     2,
+		InitialBackoff: time.Millisecond,
+	}
+
+	tests := []struct {
+		name             string
+		failures         int32
+		statusCode       int
+		expectedRequests int32
+		expectSuccess    bool
+	}{
+		{
+			name:             "transient failures",
+			failures:         2,
+			statusCode:       http.StatusServiceUnavailable,
+			expectedRequests: 3,
+			expectSuccess:    true,
+		},
+		{
+			name:             "retries exhausted",
+			failures:         3,
+			statusCode:       http.StatusServiceUnavailable,
+			expectedRequests: 3,
+		},
+		{
+			name:             "server error",
+			failures:         1,
+			statusCode:       http.StatusInternalServerError,
+			expectedRequests: 1,
+		},
+		{
+			name:             "client error",
+			failures:         1,
+			statusCode:       http.StatusBadRequest,
+			expectedRequests: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := &flakyHandler{Handler: aggregator, failures: test.failures, statusCode: test.statusCode}
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			client := NewSignatureAggregatorClient(server.URL, options)
+			message, err := client.CreateSignedMessage(unsignedMessage, nil, subnetID, 0)
+			require.Equal(t, test.expectedRequests, handler.requests.Load())
+			if !test.expectSuccess {
+				var statusErr *aggregatorStatusError
+				require.ErrorAs(t, err, &statusErr)
+				require.Equal(t, test.statusCode, statusErr.statusCode)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, verifyMessage(t, message, subnetID, vdrs, 100))
+		})
+	}
+}
+
+func TestSignatureAggregatorReadinessProbes(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	aggregator, _ := newTestMockAggregator(t, subnetID, []uint64{1})
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(constants.UnitTestID, ids.GenerateTestID(), []byte{1})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		failures      int32
+		expectedReady bool
+	}{
+		{
+			name:          "probe succeeds after failures",
+			failures:      2,
+			expectedReady: true,
+		},
+		{
+			name:     "probe never succeeds",
+			failures: math.MaxInt32,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The health endpoint is ready immediately, while aggregation fails with server errors
+			handler := &flakyHandler{
+				Handler:    aggregator,
+				failures:   test.failures,
+				statusCode: http.StatusInternalServerError,
+			}
+			mux := http.NewServeMux()
+			mux.HandleFunc(SIG_AGG_HEALTH_PATH, func(http.ResponseWriter, *http.Request) {})
+			mux.Handle(SIG_AGG_API_PATH, handler)
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			client := NewSignatureAggregatorClient(server.URL, SignatureAggregatorOptions{
+				ReadinessTimeout: 100 * time.Millisecond,
+				InitialBackoff:   time.Millisecond,
+				MaxBackoff:       10 * time.Millisecond,
+				ReadinessProbes: []ReadinessProbe{
+					{Message: unsignedMessage, SigningSubnetID: subnetID},
+				},
+			})
+			err := client.waitUntilReady()
+			if !test.expectedReady {
+				require.ErrorIs(t, err, ErrSignatureAggregatorNotReady)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.failures+1, handler.requests.Load())
+		})
+	}
+}
+
+func TestSignatureAggregatorClientConnectionError(t *testing.T) {
+	port, err := getFreePort()
+	require.NoError(t, err)
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(constants.UnitTestID, ids.GenerateTestID(), []byte{1})
+	require.NoError(t, err)
+
+	client := NewSignatureAggregatorClient(
+		"http://127.0.0.1:"+strconv.Itoa(port),
+		SignatureAggregatorOptions{MaxRetries: -1},
+	)
+	_, err = client.CreateSignedMessage(unsignedMessage, nil, ids.GenerateTestID(), 0)
+	require.True(t, isTransientAggregatorError(err))
+}
+
+func TestTailBuffer(t *testing.T) {
+	buf := newTailBuffer(4)
+	n, err := buf.Write([]byte("abc"))
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	_, err = buf.Write([]byte("defg"))
+	require.NoError(t, err)
+	require.Equal(t, "defg", buf.String())
+}
@@ -82,6 +82,16 @@ func (t TeleporterTestInfo) SetTeleporter(address common.Address, l1 interfaces.
 	info.TeleporterMessenger = teleporterMessenger
 }
 
+func (t TeleporterTestInfo) SetTeleporterRegistry(address common.Address, l1 interfaces.L1TestInfo) {
+	teleporterRegistry, err := teleporterregistry.NewTeleporterRegistry(
+		address, l1.RPCClient,
+	)
+	Expect(err).Should(BeNil())
+	info := t[l1.BlockchainID]
+	info.TeleporterRegistryAddress = address
+	info.TeleporterRegistry = teleporterRegistry
+}
+
 func (t TeleporterTestInfo) InitializeBlockchainID(l1 interfaces.L1TestInfo, fundedKey *ecdsa.PrivateKey) {
 	opts, err := bind.NewKeyedTransactorWithChainID(fundedKey, l1.EVMChainID)
 	Expect(err).Should(BeNil())
@@ -155,7 +165,7 @@ func (t TeleporterTestInfo) RelayTeleporterMessage(
 	expectSuccess bool,
 	fundedKey *ecdsa.PrivateKey,
 	justification []byte,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 ) *types.Receipt {
 	// Fetch the Teleporter message from the logs
 	sendEvent, err := GetEventFromLogs(sourceReceipt.Logs, t.TeleporterMessenger(source).ParseSendCrossChainMessage)
@@ -206,7 +216,7 @@ func (t TeleporterTestInfo) SendExampleCrossChainMessageAndVerify(
 	destExampleMessenger *testmessenger.TestMessenger,
 	senderKey *ecdsa.PrivateKey,
 	message string,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 	expectSuccess bool,
 ) {
 	// Call the example messenger contract on L1 A
@@ -285,7 +295,7 @@ func (t TeleporterTestInfo) AddProtocolVersionAndWaitForAcceptance(
 	newTeleporterAddress common.Address,
 	senderKey *ecdsa.PrivateKey,
 	unsignedMessage *avalancheWarp.UnsignedMessage,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 ) {
 	signedWarpMsg := GetSignedMessage(l1, l1, unsignedMessage, nil, signatureAggregator)
 	log.Info("Got signed warp message", "messageID", signedWarpMsg.ID())
@@ -327,7 +337,7 @@ func (t TeleporterTestInfo) ClearReceiptQueue(
 	fundedKey *ecdsa.PrivateKey,
 	source interfaces.L1TestInfo,
 	destination interfaces.L1TestInfo,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 ) {
 	sourceTeleporterMessenger := t.TeleporterMessenger(source)
 	outstandReceiptCount := GetOutstandingReceiptCount(
@@ -6,6 +6,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	erc20tokenhome "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenHome/ERC20TokenHome"
 	nativetokenhome "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenHome/NativeTokenHome"
+	icttUtils "github.com/ava-labs/icm-contracts/utils/ictt-utils"
 	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 
@@ -19,7 +20,10 @@ func ApplyTokenScaling(
 	multiplyOnRemote bool,
 	homeTokenAmount *big.Int,
 ) *big.Int {
-	return scaleTokens(tokenMultiplier, multiplyOnRemote, homeTokenAmount, true)
+	scaling := icttUtils.TokenScaling{TokenMultiplier: tokenMultiplier, MultiplyOnRemote: multiplyOnRemote}
+	scaled, err := scaling.ApplyTokenScale(homeTokenAmount)
+	Expect(err).Should(BeNil())
+	return scaled
 }
 
 // RemoveTokenScaling removes token scaling from the given amount of remote tokens.
@@ -29,22 +33,10 @@ func RemoveTokenScaling(
 	multiplyOnRemote bool,
 	remoteTokenAmount *big.Int,
 ) *big.Int {
-	return scaleTokens(tokenMultiplier, multiplyOnRemote, remoteTokenAmount, false)
-}
-
-func scaleTokens(
-	tokenMultiplier *big.Int,
-	multiplyOnRemote bool,
-	amount *big.Int,
-	isSendToRemote bool,
-) *big.Int {
-	// Multiply when multiplyOnRemote and isSendToRemote are
-	// both true or both false.
-	if multiplyOnRemote == isSendToRemote {
-		return big.NewInt(0).Mul(amount, tokenMultiplier)
-	}
-
-	return big.NewInt(0).Div(amount, tokenMultiplier)
+	scaling := icttUtils.TokenScaling{TokenMultiplier: tokenMultiplier, MultiplyOnRemote: multiplyOnRemote}
+	scaled, err := scaling.RemoveTokenScale(remoteTokenAmount)
+	Expect(err).Should(BeNil())
+	return scaled
 }
 
 // GetScaledAmountFromERC20TokenHome returns the scaled amount of remote tokens that
@@ -96,11 +88,8 @@ func calculateCollateralNeeded(
 	tokenMultiplier *big.Int,
 	multiplyOnRemote bool,
 ) *big.Int {
-	collateralNeeded := RemoveTokenScaling(tokenMultiplier, multiplyOnRemote, initialReserveImbalance)
-
-	remainder := big.NewInt(0).Mod(collateralNeeded, tokenMultiplier)
-	if multiplyOnRemote && (remainder.Cmp(big.NewInt(0)) != 0) {
-		collateralNeeded.Add(collateralNeeded, big.NewInt(1))
-	}
+	scaling := icttUtils.TokenScaling{TokenMultiplier: tokenMultiplier, MultiplyOnRemote: multiplyOnRemote}
+	collateralNeeded, err := scaling.CollateralNeeded(initialReserveImbalance)
+	Expect(err).Should(BeNil())
 	return collateralNeeded
 }
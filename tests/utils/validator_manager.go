@@ -274,7 +274,7 @@ func InitializeValidatorSet(
 	pChainInfo interfaces.L1TestInfo,
 	validatorManagerAddress common.Address,
 	networkID uint32,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 	nodes []Node,
 ) []ids.ID {
 	log.Println("Initializing validator set", "subnetID", l1Info.SubnetID)
@@ -538,7 +538,7 @@ func CallWarpReceiver(
 
 func InitiateAndCompleteNativeValidatorRegistration(
 	ctx context.Context,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 	fundedKey *ecdsa.PrivateKey,
 	l1Info interfaces.L1TestInfo,
 	pChainInfo interfaces.L1TestInfo,
@@ -615,7 +615,7 @@ func InitiateAndCompleteNativeValidatorRegistration(
 
 func InitiateAndCompleteERC20ValidatorRegistration(
 	ctx context.Context,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 	fundedKey *ecdsa.PrivateKey,
 	l1Info interfaces.L1TestInfo,
 	pChainInfo interfaces.L1TestInfo,
@@ -696,7 +696,7 @@ func InitiateAndCompleteERC20ValidatorRegistration(
 
 func InitiateAndCompletePoAValidatorRegistration(
 	ctx context.Context,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 	ownerKey *ecdsa.PrivateKey,
 	l1Info interfaces.L1TestInfo,
 	pChainInfo interfaces.L1TestInfo,
@@ -807,7 +807,7 @@ func ConstructUptimeProofMessage(
 	uptime uint64,
 	l1 interfaces.L1TestInfo,
 	networkID uint32,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 ) *avalancheWarp.Message {
 	uptimePayload, err := messages.NewValidatorUptime(validationID, uptime)
 	Expect(err).Should(BeNil())
@@ -833,7 +833,7 @@ func ConstructUptimeProofMessage(
 func ForceInitiateEndPoSValidationWithUptime(
 	ctx context.Context,
 	networkID uint32,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 	senderKey *ecdsa.PrivateKey,
 	l1 interfaces.L1TestInfo,
 	stakingManagerAddress common.Address,
@@ -865,7 +865,7 @@ func ForceInitiateEndPoSValidationWithUptime(
 func InitiateEndPoSValidationWithUptime(
 	ctx context.Context,
 	networkID uint32,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 	senderKey *ecdsa.PrivateKey,
 	l1 interfaces.L1TestInfo,
 	stakingManagerAddress common.Address,
@@ -1086,7 +1086,7 @@ func CompleteDelegatorRemoval(
 
 func InitiateAndCompleteEndInitialPoSValidation(
 	ctx context.Context,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 	fundedKey *ecdsa.PrivateKey,
 	l1Info interfaces.L1TestInfo,
 	pChainInfo interfaces.L1TestInfo,
@@ -1166,7 +1166,7 @@ func InitiateAndCompleteEndInitialPoSValidation(
 
 func InitiateAndCompleteEndPoSValidation(
 	ctx context.Context,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 	fundedKey *ecdsa.PrivateKey,
 	l1Info interfaces.L1TestInfo,
 	pChainInfo interfaces.L1TestInfo,
@@ -1266,7 +1266,7 @@ func InitiateAndCompleteEndPoSValidation(
 
 func InitiateAndCompleteEndInitialPoAValidation(
 	ctx context.Context,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 	ownerKey *ecdsa.PrivateKey,
 	l1Info interfaces.L1TestInfo,
 	pChainInfo interfaces.L1TestInfo,
@@ -1345,7 +1345,7 @@ func InitiateAndCompleteEndInitialPoAValidation(
 
 func InitiateAndCompleteEndPoAValidation(
 	ctx context.Context,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 	ownerKey *ecdsa.PrivateKey,
 	l1Info interfaces.L1TestInfo,
 	pChainInfo interfaces.L1TestInfo,
@@ -1422,7 +1422,7 @@ func ConstructL1ValidatorRegistrationMessageForInitialValidator(
 	l1 interfaces.L1TestInfo,
 	pChainInfo interfaces.L1TestInfo,
 	networkID uint32,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 ) *avalancheWarp.Message {
 	justification := platformvm.L1ValidatorRegistrationJustification{
 		Preimage: &platformvm.L1ValidatorRegistrationJustification_ConvertSubnetToL1TxData{
@@ -1465,7 +1465,7 @@ func ConstructL1ValidatorRegistrationMessage(
 	l1 interfaces.L1TestInfo,
 	pChainInfo interfaces.L1TestInfo,
 	networkID uint32,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 ) *avalancheWarp.Message {
 	msg, err := warpMessage.NewRegisterL1Validator(
 		l1.SubnetID,
@@ -1513,7 +1513,7 @@ func ConstructL1ValidatorWeightMessage(
 	weight uint64,
 	l1 interfaces.L1TestInfo,
 	pChainInfo interfaces.L1TestInfo,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 	networkID uint32,
 ) *avalancheWarp.Message {
 	payload, err := warpMessage.NewL1ValidatorWeight(validationID, nonce, weight)
@@ -1542,7 +1542,7 @@ func ConstructL1ConversionMessage(
 	l1 interfaces.L1TestInfo,
 	pChainInfo interfaces.L1TestInfo,
 	networkID uint32,
-	signatureAggregator *SignatureAggregator,
+	signatureAggregator Aggregator,
 ) *avalancheWarp.Message {
 	l1ConversionPayload, err := warpMessage.NewSubnetToL1Conversion(l1ConversionID)
 	Expect(err).Should(BeNil())
@@ -1752,11 +1752,13 @@ func PackInitialValidator(iv interface{}) ([]byte, error) {
 	weight := v.FieldByName("Weight").Interface().(uint64)
 	blsPublicKey := v.FieldByName("BlsPublicKey").Interface().([]byte)
 
+	// Matches the field order of ValidatorMessages.packConversionData and the P-Chain codec:
+	// nodeID, blsPublicKey, weight.
 	b := make([]byte, 60+len(nodeID))
 	binary.BigEndian.PutUint32(b[0:4], uint32(len(nodeID)))
 	copy(b[4:4+len(nodeID)], nodeID[:])
-	binary.BigEndian.PutUint64(b[4+len(nodeID):4+len(nodeID)+8], weight)
-	copy(b[4+len(nodeID)+8:4+len(nodeID)+8+48], blsPublicKey)
+	copy(b[4+len(nodeID):4+len(nodeID)+48], blsPublicKey)
+	binary.BigEndian.PutUint64(b[4+len(nodeID)+48:4+len(nodeID)+48+8], weight)
 	return b, nil
 }
 
@@ -0,0 +1,337 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	inativeminter "github.com/ava-labs/icm-contracts/abi-bindings/go/INativeMinter"
+	ownableupgradeable "github.com/ava-labs/icm-contracts/abi-bindings/go/OwnableUpgradeable"
+	proxyadmin "github.com/ava-labs/icm-contracts/abi-bindings/go/ProxyAdmin"
+	transparentupgradeableproxy "github.com/ava-labs/icm-contracts/abi-bindings/go/TransparentUpgradeableProxy"
+	validatorsetsig "github.com/ava-labs/icm-contracts/abi-bindings/go/governance/ValidatorSetSig"
+	erc20tokenhome "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenHome/ERC20TokenHome"
+	erc20tokenhomeupgradeable "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenHome/ERC20TokenHomeUpgradeable"
+	nativetokenhome "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenHome/NativeTokenHome"
+	nativetokenhomeupgradeable "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenHome/NativeTokenHomeUpgradeable"
+	tokenhome "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenHome/TokenHome"
+	erc20tokenremote "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenRemote/ERC20TokenRemote"
+	erc20tokenremoteupgradeable "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenRemote/ERC20TokenRemoteUpgradeable"
+	nativetokenremote "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenRemote/NativeTokenRemote"
+	nativetokenremoteupgradeable "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenRemote/NativeTokenRemoteUpgradeable"
+	tokenremote "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenRemote/TokenRemote"
+	wrappednativetoken "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/WrappedNativeToken"
+	exampleerc20decimals "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/mocks/ExampleERC20Decimals"
+	mockerc20sendandcallreceiver "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/mocks/MockERC20SendAndCallReceiver"
+	mocknativesendandcallreceiver "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/mocks/MockNativeSendAndCallReceiver"
+	exampleerc20 "github.com/ava-labs/icm-contracts/abi-bindings/go/mocks/ExampleERC20"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	teleporterregistry "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/registry/TeleporterRegistry"
+	testmessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/tests/TestMessenger"
+	acp99manager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/ACP99Manager"
+	erc20tokenstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/ERC20TokenStakingManager"
+	examplerewardcalculator "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/ExampleRewardCalculator"
+	nativetokenstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/NativeTokenStakingManager"
+	validatormanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/ValidatorManager"
+	istakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/interfaces/IStakingManager"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Names of the contracts in the registry, as used in address books
+const (
+	TeleporterMessenger           = "TeleporterMessenger"
+	TeleporterRegistry            = "TeleporterRegistry"
+	TestMessenger                 = "TestMessenger"
+	TokenHome                     = "TokenHome"
+	ERC20TokenHome                = "ERC20TokenHome"
+	ERC20TokenHomeUpgradeable     = "ERC20TokenHomeUpgradeable"
+	NativeTokenHome               = "NativeTokenHome"
+	NativeTokenHomeUpgradeable    = "NativeTokenHomeUpgradeable"
+	TokenRemote                   = "TokenRemote"
+	ERC20TokenRemote              = "ERC20TokenRemote"
+	ERC20TokenRemoteUpgradeable   = "ERC20TokenRemoteUpgradeable"
+	NativeTokenRemote             = "NativeTokenRemote"
+	NativeTokenRemoteUpgradeable  = "NativeTokenRemoteUpgradeable"
+	WrappedNativeToken            = "WrappedNativeToken"
+	ExampleERC20                  = "ExampleERC20"
+	ExampleERC20Decimals          = "ExampleERC20Decimals"
+	MockERC20SendAndCallReceiver  = "MockERC20SendAndCallReceiver"
+	MockNativeSendAndCallReceiver = "MockNativeSendAndCallReceiver"
+	ValidatorSetSig               = "ValidatorSetSig"
+	ACP99Manager                  = "ACP99Manager"
+	ValidatorManager              = "ValidatorManager"
+	IStakingManager               = "IStakingManager"
+	ERC20TokenStakingManager      = "ERC20TokenStakingManager"
+	NativeTokenStakingManager     = "NativeTokenStakingManager"
+	ExampleRewardCalculator       = "ExampleRewardCalculator"
+	ValidatorMessages             = "ValidatorMessages"
+	INativeMinter                 = "INativeMinter"
+	OwnableUpgradeable            = "OwnableUpgradeable"
+	ProxyAdmin                    = "ProxyAdmin"
+	TransparentUpgradeableProxy   = "TransparentUpgradeableProxy"
+)
+
+var (
+	ErrUnknownContract = errors.New("unknown contract")
+	ErrUnknownAddress  = errors.New("address is not in the address book")
+	ErrUnknownEvent    = errors.New("unknown event")
+	ErrUnknownMethod   = errors.New("unknown method")
+)
+
+var contractMetaData = map[string]*bind.MetaData{
+	TeleporterMessenger:           teleportermessenger.TeleporterMessengerMetaData,
+	TeleporterRegistry:            teleporterregistry.TeleporterRegistryMetaData,
+	TestMessenger:                 testmessenger.TestMessengerMetaData,
+	TokenHome:                     tokenhome.TokenHomeMetaData,
+	ERC20TokenHome:                erc20tokenhome.ERC20TokenHomeMetaData,
+	ERC20TokenHomeUpgradeable:     erc20tokenhomeupgradeable.ERC20TokenHomeUpgradeableMetaData,
+	NativeTokenHome:               nativetokenhome.NativeTokenHomeMetaData,
+	NativeTokenHomeUpgradeable:    nativetokenhomeupgradeable.NativeTokenHomeUpgradeableMetaData,
+	TokenRemote:                   tokenremote.TokenRemoteMetaData,
+	ERC20TokenRemote:              erc20tokenremote.ERC20TokenRemoteMetaData,
+	ERC20TokenRemoteUpgradeable:   erc20tokenremoteupgradeable.ERC20TokenRemoteUpgradeableMetaData,
+	NativeTokenRemote:             nativetokenremote.NativeTokenRemoteMetaData,
+	NativeTokenRemoteUpgradeable:  nativetokenremoteupgradeable.NativeTokenRemoteUpgradeableMetaData,
+	WrappedNativeToken:            wrappednativetoken.WrappedNativeTokenMetaData,
+	ExampleERC20:                  exampleerc20.ExampleERC20MetaData,
+	ExampleERC20Decimals:          exampleerc20decimals.ExampleERC20DecimalsMetaData,
+	MockERC20SendAndCallReceiver:  mockerc20sendandcallreceiver.MockERC20SendAndCallReceiverMetaData,
+	MockNativeSendAndCallReceiver: mocknativesendandcallreceiver.MockNativeSendAndCallReceiverMetaData,
+	ValidatorSetSig:               validatorsetsig.ValidatorSetSigMetaData,
+	ACP99Manager:                  acp99manager.ACP99ManagerMetaData,
+	ValidatorManager:              validatormanager.ValidatorManagerMetaData,
+	IStakingManager:               istakingmanager.IStakingManagerMetaData,
+	ERC20TokenStakingManager:      erc20tokenstakingmanager.ERC20TokenStakingManagerMetaData,
+	NativeTokenStakingManager:     nativetokenstakingmanager.NativeTokenStakingManagerMetaData,
+	ExampleRewardCalculator:       examplerewardcalculator.ExampleRewardCalculatorMetaData,
+	ValidatorMessages:             validatormanager.ValidatorMessagesMetaData,
+	INativeMinter:                 inativeminter.INativeMinterMetaData,
+	OwnableUpgradeable:            ownableupgradeable.OwnableUpgradeableMetaData,
+	ProxyAdmin:                    proxyadmin.ProxyAdminMetaData,
+	TransparentUpgradeableProxy:   transparentupgradeableproxy.TransparentUpgradeableProxyMetaData,
+}
+
+// EventMatch is an event with a given topic, and the contracts that declare it
+type EventMatch struct {
+	Event     abi.Event
+	Contracts []string
+}
+
+// MethodMatch is a method with a given selector, and the contracts that declare it
+type MethodMatch struct {
+	Method    abi.Method
+	Contracts []string
+}
+
+// ErrorMatch is a custom error with a given selector, and the contracts that declare it
+type ErrorMatch struct {
+	Error     abi.Error
+	Contracts []string
+}
+
+// Registry indexes the ABIs of all ICM contracts. Contracts often share events, methods and
+// errors, for example through inheritance, so each lookup returns every distinct declaration
+// along with the contracts declaring it. Declarations that only differ in parameter names or
+// indexing are returned separately.
+type Registry struct {
+	abis    map[string]*abi.ABI
+	events  map[common.Hash][]EventMatch
+	methods map[[4]byte][]MethodMatch
+	errors  map[[4]byte][]ErrorMatch
+}
+
+var loadRegistry = sync.OnceValues(newRegistry)
+
+// LoadRegistry parses the ABIs of all ICM contracts the first time it's called, and returns the
+// same registry on every call.
+func LoadRegistry() (*Registry, error) {
+	return loadRegistry()
+}
+
+// ContractABI returns the parsed ABI of [contract], one of the names declared in this package.
+// Only that ABI is parsed, so the registry isn't loaded.
+func ContractABI(contract string) (*abi.ABI, error) {
+	metaData, ok := contractMetaData[contract]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownContract, contract)
+	}
+	parsed, err := metaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s ABI: %w", contract, err)
+	}
+	return parsed, nil
+}
+
+func newRegistry() (*Registry, error) {
+	r := &Registry{
+		abis:    make(map[string]*abi.ABI, len(contractMetaData)),
+		events:  make(map[common.Hash][]EventMatch),
+		methods: make(map[[4]byte][]MethodMatch),
+		errors:  make(map[[4]byte][]ErrorMatch),
+	}
+	// Index contracts in a fixed order, so that lookups list contracts deterministically
+	for _, contract := range sortedContracts() {
+		parsed, err := contractMetaData[contract].GetAbi()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s ABI: %w", contract, err)
+		}
+		r.abis[contract] = parsed
+		for _, event := range parsed.Events {
+			r.events[event.ID] = addEvent(r.events[event.ID], event, contract)
+		}
+		for _, method := range parsed.Methods {
+			selector := [4]byte(method.ID)
+			r.methods[selector] = addMethod(r.methods[selector], method, contract)
+		}
+		for _, abiError := range parsed.Errors {
+			selector := [4]byte(abiError.ID[:4])
+			r.errors[selector] = addError(r.errors[selector], abiError, contract)
+		}
+	}
+	return r, nil
+}
+
+func sortedContracts() []string {
+	contracts := make([]string, 0, len(contractMetaData))
+	for contract := range contractMetaData {
+		contracts = append(contracts, contract)
+	}
+	sort.Strings(contracts)
+	return contracts
+}
+
+func addEvent(matches []EventMatch, event abi.Event, contract string) []EventMatch {
+	for i := range matches {
+		if matches[i].Event.String() == event.String() {
+			matches[i].Contracts = append(matches[i].Contracts, contract)
+			return matches
+		}
+	}
+	return append(matches, EventMatch{Event: event, Contracts: []string{contract}})
+}
+
+func addMethod(matches []MethodMatch, method abi.Method, contract string) []MethodMatch {
+	for i := range matches {
+		if matches[i].Method.String() == method.String() {
+			matches[i].Contracts = append(matches[i].Contracts, contract)
+			return matches
+		}
+	}
+	return append(matches, MethodMatch{Method: method, Contracts: []string{contract}})
+}
+
+func addError(matches []ErrorMatch, abiError abi.Error, contract string) []ErrorMatch {
+	for i := range matches {
+		if matches[i].Error.String() == abiError.String() {
+			matches[i].Contracts = append(matches[i].Contracts, contract)
+			return matches
+		}
+	}
+	return append(matches, ErrorMatch{Error: abiError, Contracts: []string{contract}})
+}
+
+// Contracts returns the names of all contracts in the registry, sorted
+func (r *Registry) Contracts() []string {
+	return sortedContracts()
+}
+
+// ABI returns the parsed ABI of [contract]
+func (r *Registry) ABI(contract string) (*abi.ABI, error) {
+	parsed, ok := r.abis[contract]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownContract, contract)
+	}
+	return parsed, nil
+}
+
+// EventsByTopic returns the events whose ID is [topic]
+func (r *Registry) EventsByTopic(topic common.Hash) []EventMatch {
+	return r.events[topic]
+}
+
+// MethodsBySelector returns the methods whose selector is the first 4 bytes of [data]
+func (r *Registry) MethodsBySelector(data []byte) []MethodMatch {
+	if len(data) < 4 {
+		return nil
+	}
+	return r.methods[[4]byte(data[:4])]
+}
+
+// ErrorsBySelector returns the custom errors whose selector is the first 4 bytes of [data]
+func (r *Registry) ErrorsBySelector(data []byte) []ErrorMatch {
+	if len(data) < 4 {
+		return nil
+	}
+	return r.errors[[4]byte(data[:4])]
+}
+
+// AddressBook maps contract addresses to the names of the contracts deployed there
+type AddressBook map[common.Address]string
+
+// ReadAddressBook reads a JSON object mapping addresses to contract names, such as
+// {"0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf": "TeleporterMessenger"}
+func ReadAddressBook(fileName string) (AddressBook, error) {
+	contents, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read address book: %w", err)
+	}
+	var book AddressBook
+	if err := json.Unmarshal(contents, &book); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal address book: %w", err)
+	}
+	return book, nil
+}
+
+// ContractAt returns the name and ABI of the contract at [address] according to [book]
+func (r *Registry) ContractAt(book AddressBook, address common.Address) (string, *abi.ABI, error) {
+	contract, ok := book[address]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: %s", ErrUnknownAddress, address)
+	}
+	parsed, err := r.ABI(contract)
+	if err != nil {
+		return "", nil, err
+	}
+	return contract, parsed, nil
+}
+
+// EventForLog returns the event emitted in [log]. If the emitting contract is in [book], its ABI
+// is used. Otherwise the first event with a matching topic is returned.
+func (r *Registry) EventForLog(book AddressBook, log *types.Log) (*abi.Event, error) {
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("%w: anonymous event", ErrUnknownEvent)
+	}
+	if _, parsed, err := r.ContractAt(book, log.Address); err == nil {
+		return parsed.EventByID(log.Topics[0])
+	}
+	matches := r.EventsByTopic(log.Topics[0])
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, log.Topics[0])
+	}
+	return &matches[0].Event, nil
+}
+
+// MethodForCall returns the method called by [data] on [to]. If [to] is in [book], its ABI is
+// used. Otherwise the first method with a matching selector is returned.
+func (r *Registry) MethodForCall(book AddressBook, to common.Address, data []byte) (*abi.Method, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("%w: calldata shorter than a selector", ErrUnknownMethod)
+	}
+	if _, parsed, err := r.ContractAt(book, to); err == nil {
+		return parsed.MethodById(data[:4])
+	}
+	matches := r.MethodsBySelector(data)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: %x", ErrUnknownMethod, data[:4])
+	}
+	return &matches[0].Method, nil
+}
@@ -0,0 +1,105 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRegistry(t *testing.T) {
+	registry, err := LoadRegistry()
+	require.NoError(t, err)
+	cached, err := LoadRegistry()
+	require.NoError(t, err)
+	require.Same(t, registry, cached)
+
+	require.Len(t, registry.Contracts(), len(contractMetaData))
+	for _, contract := range registry.Contracts() {
+		parsed, err := ContractABI(contract)
+		require.NoError(t, err)
+		require.NotEmpty(t, parsed.Methods, contract)
+		indexed, err := registry.ABI(contract)
+		require.NoError(t, err)
+		require.Same(t, indexed, parsed)
+	}
+	_, err = ContractABI("Unknown")
+	require.ErrorIs(t, err, ErrUnknownContract)
+}
+
+func TestRegistryLookups(t *testing.T) {
+	registry, err := LoadRegistry()
+	require.NoError(t, err)
+
+	teleporterABI, err := registry.ABI(TeleporterMessenger)
+	require.NoError(t, err)
+	sendEvent := teleporterABI.Events["SendCrossChainMessage"]
+	events := registry.EventsByTopic(sendEvent.ID)
+	require.Len(t, events, 1)
+	require.Equal(t, sendEvent.String(), events[0].Event.String())
+	require.Equal(t, []string{TeleporterMessenger}, events[0].Contracts)
+	require.Empty(t, registry.EventsByTopic(common.Hash{}))
+
+	// ERC20 methods are declared by several contracts
+	transferSelector := crypto.Keccak256([]byte("transfer(address,uint256)"))[:4]
+	methods := registry.MethodsBySelector(append(transferSelector, make([]byte, 64)...))
+	require.Len(t, methods, 1)
+	require.Equal(t, "transfer", methods[0].Method.Name)
+	require.Contains(t, methods[0].Contracts, ExampleERC20)
+	require.Contains(t, methods[0].Contracts, WrappedNativeToken)
+	require.IsIncreasing(t, methods[0].Contracts)
+	require.Empty(t, registry.MethodsBySelector([]byte{0x01}))
+
+	errorSelector := crypto.Keccak256([]byte("AddressEmptyCode(address)"))[:4]
+	abiErrors := registry.ErrorsBySelector(errorSelector)
+	require.Len(t, abiErrors, 1)
+	require.Equal(t, "AddressEmptyCode", abiErrors[0].Error.Name)
+	require.Contains(t, abiErrors[0].Contracts, TeleporterMessenger)
+}
+
+func TestAddressBook(t *testing.T) {
+	registry, err := LoadRegistry()
+	require.NoError(t, err)
+
+	teleporterAddress := common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf")
+	unknownAddress := common.HexToAddress("0x01")
+	fileName := filepath.Join(t.TempDir(), "addresses.json")
+	require.NoError(t, os.WriteFile(fileName, []byte(`{
+		"0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf": "TeleporterMessenger",
+		"0x0000000000000000000000000000000000000002": "Unknown"
+	}`), 0o600))
+	book, err := ReadAddressBook(fileName)
+	require.NoError(t, err)
+
+	contract, _, err := registry.ContractAt(book, teleporterAddress)
+	require.NoError(t, err)
+	require.Equal(t, TeleporterMessenger, contract)
+	_, _, err = registry.ContractAt(book, unknownAddress)
+	require.ErrorIs(t, err, ErrUnknownAddress)
+	_, _, err = registry.ContractAt(book, common.HexToAddress("0x02"))
+	require.ErrorIs(t, err, ErrUnknownContract)
+
+	teleporterABI, err := registry.ABI(TeleporterMessenger)
+	require.NoError(t, err)
+	topic := teleporterABI.Events["MessageExecuted"].ID
+	for _, address := range []common.Address{teleporterAddress, unknownAddress} {
+		event, err := registry.EventForLog(book, &types.Log{Address: address, Topics: []common.Hash{topic}})
+		require.NoError(t, err)
+		require.Equal(t, "MessageExecuted", event.Name)
+	}
+	_, err = registry.EventForLog(book, &types.Log{Address: teleporterAddress})
+	require.ErrorIs(t, err, ErrUnknownEvent)
+
+	method, err := registry.MethodForCall(book, teleporterAddress, teleporterABI.Methods["messageNonce"].ID)
+	require.NoError(t, err)
+	require.Equal(t, "messageNonce", method.Name)
+	_, err = registry.MethodForCall(book, unknownAddress, []byte{0xff, 0xff, 0xff, 0xff})
+	require.ErrorIs(t, err, ErrUnknownMethod)
+}
@@ -0,0 +1,113 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type contractAddressOutput struct {
+	Address common.Address `json:"address"`
+}
+
+type create2AddressOutput struct {
+	Factory      common.Address `json:"factory"`
+	Salt         common.Hash    `json:"salt"`
+	InitCodeHash common.Hash    `json:"initCodeHash"`
+	Address      common.Address `json:"address"`
+}
+
+var deriveContractAddressCmd = &cobra.Command{
+	Use:   "deriveContractAddress DEPLOYER_ADDRESS NONCE",
+	Short: "Derives the address of a contract created by an account",
+	Long: `Given a deployer address and the nonce of its contract creation transaction,
+this command derives the address of the created contract.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !common.IsHexAddress(args[0]) {
+			return errors.Errorf("invalid deployer address %s", args[0])
+		}
+		nonce, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse nonce as uint")
+		}
+		return printJSON(cmd, contractAddressOutput{
+			Address: crypto.CreateAddress(common.HexToAddress(args[0]), nonce),
+		})
+	},
+}
+
+var (
+	create2Factory         string
+	create2ConstructorArgs string
+)
+
+var deriveCreate2AddressCmd = &cobra.Command{
+	Use:   "deriveCreate2Address SALT INIT_CODE",
+	Short: "Derives the address of a contract created through a CREATE2 factory",
+	Long: `Given a 32 byte hex encoded salt and the contract's init code, this command derives the
+address of the contract created through a CREATE2 factory. The init code is either hex
+encoded or read from a forge build artifact, in which case the hex encoded constructor
+arguments can be appended with --constructor-args.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		saltBytes, err := hexutil.Decode(args[0])
+		if err != nil || len(saltBytes) != common.HashLength {
+			return errors.Errorf("salt must be 32 hex encoded bytes, got %s", args[0])
+		}
+		if !common.IsHexAddress(create2Factory) {
+			return errors.Errorf("invalid factory address %s", create2Factory)
+		}
+
+		var initCode []byte
+		if strings.HasPrefix(args[1], "0x") {
+			initCode, err = hexutil.Decode(args[1])
+		} else {
+			initCode, err = deploymentUtils.ReadBytecodeFile(args[1])
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to read init code")
+		}
+		if create2ConstructorArgs != "" {
+			constructorArgs, err := hexutil.Decode(create2ConstructorArgs)
+			if err != nil {
+				return errors.Wrap(err, "failed to decode constructor arguments")
+			}
+			initCode = append(initCode, constructorArgs...)
+		}
+
+		output := create2AddressOutput{
+			Factory:      common.HexToAddress(create2Factory),
+			Salt:         common.BytesToHash(saltBytes),
+			InitCodeHash: crypto.Keccak256Hash(initCode),
+		}
+		output.Address = crypto.CreateAddress2(output.Factory, output.Salt, output.InitCodeHash.Bytes())
+		return printJSON(cmd, output)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(deriveContractAddressCmd)
+	rootCmd.AddCommand(deriveCreate2AddressCmd)
+	deriveCreate2AddressCmd.Flags().StringVar(
+		&create2Factory,
+		"factory",
+		deploymentUtils.DeterministicDeploymentProxyAddress.Hex(),
+		"Address of the CREATE2 factory",
+	)
+	deriveCreate2AddressCmd.Flags().StringVar(
+		&create2ConstructorArgs,
+		"constructor-args",
+		"",
+		"Hex encoded constructor arguments appended to the init code",
+	)
+}
@@ -0,0 +1,83 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type broadcastOutput struct {
+	TransactionHash common.Hash     `json:"transactionHash"`
+	Status          uint64          `json:"status"`
+	BlockNumber     *big.Int        `json:"blockNumber"`
+	GasUsed         uint64          `json:"gasUsed"`
+	ContractAddress *common.Address `json:"contractAddress,omitempty"`
+}
+
+var (
+	broadcastRPCEndpoint string
+	broadcastTimeout     time.Duration
+)
+
+var broadcastCmd = &cobra.Command{
+	Use:   "broadcast --rpc RPC_URL TRANSACTION",
+	Short: "Sends a signed transaction and waits for its receipt",
+	Long: `Given a signed transaction, either hex encoded or as the path to a file containing it,
+this command sends it to the chain and waits for its receipt. The command fails if the
+transaction is not accepted before the timeout, or if it reverts.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tx, err := readRawTransaction(args[0])
+		if err != nil {
+			return err
+		}
+		client, err := ethclient.Dial(broadcastRPCEndpoint)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		ctx, cancel := context.WithTimeout(cmd.Context(), broadcastTimeout)
+		defer cancel()
+
+		if err := client.SendTransaction(ctx, tx); err != nil {
+			return errors.Wrap(err, "failed to send transaction")
+		}
+		receipt, err := bind.WaitMined(ctx, client, tx)
+		if err != nil {
+			return errors.Wrapf(err, "failed to wait for transaction %s", tx.Hash())
+		}
+		output := broadcastOutput{
+			TransactionHash: tx.Hash(),
+			Status:          receipt.Status,
+			BlockNumber:     receipt.BlockNumber,
+			GasUsed:         receipt.GasUsed,
+		}
+		if tx.To() == nil {
+			output.ContractAddress = &receipt.ContractAddress
+		}
+		if err := printJSON(cmd, output); err != nil {
+			return err
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return errors.Errorf("transaction %s failed", tx.Hash())
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(broadcastCmd)
+	broadcastCmd.Flags().StringVar(&broadcastRPCEndpoint, "rpc", "", "RPC endpoint to send the transaction to")
+	broadcastCmd.Flags().DurationVar(&broadcastTimeout, "timeout", 2*time.Minute, "Timeout waiting for the receipt")
+	cobra.CheckErr(broadcastCmd.MarkFlagRequired("rpc"))
+}
@@ -0,0 +1,90 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkRPCEndpoints map[string]string
+	checkAddress      string
+	checkTimeout      time.Duration
+)
+
+var checkDeployedCmd = &cobra.Command{
+	Use:   "checkDeployed --rpc NAME=RPC_URL [--rpc NAME=RPC_URL...] BYTECODE_FILE",
+	Short: "Verifies that a contract is deployed with the expected bytecode on multiple chains",
+	Long: `Given a forge build artifact, this command compares the code deployed on each chain to the
+artifact's deployed bytecode, ignoring immutable variables and the CBOR metadata appended by
+the compiler. The code is read at --address, or at the universal address of the contract's
+keyless deployment with the default gas price if it is not set. The command fails if the
+code on any chain does not match.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		artifact, err := deploymentUtils.ReadDeployedBytecodeArtifact(args[0])
+		if err != nil {
+			return err
+		}
+		var address common.Address
+		if checkAddress != "" {
+			if !common.IsHexAddress(checkAddress) {
+				return errors.Errorf("invalid address %s", checkAddress)
+			}
+			address = common.HexToAddress(checkAddress)
+		} else {
+			_, _, _, address, err = deploymentUtils.ConstructKeylessTransaction(
+				args[0],
+				false,
+				deploymentUtils.GetDefaultContractCreationGasPrice(),
+			)
+			if err != nil {
+				return errors.Wrap(err, "failed to derive universal contract address")
+			}
+		}
+
+		clients := make(map[string]deploymentUtils.CodeReader, len(checkRPCEndpoints))
+		for chain, rpcEndpoint := range checkRPCEndpoints {
+			client, err := ethclient.Dial(rpcEndpoint)
+			if err != nil {
+				return errors.Wrapf(err, "failed to dial %s", chain)
+			}
+			defer client.Close()
+			clients[chain] = client
+		}
+		ctx, cancel := context.WithTimeout(cmd.Context(), checkTimeout)
+		defer cancel()
+
+		results := deploymentUtils.VerifyDeployedBytecode(ctx, artifact, address, clients)
+		if err := printJSON(cmd, results); err != nil {
+			return err
+		}
+		for _, result := range results {
+			if !result.Match {
+				return errors.Errorf("bytecode at %s does not match on %s", address, result.Chain)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(checkDeployedCmd)
+	checkDeployedCmd.Flags().StringToStringVar(
+		&checkRPCEndpoints,
+		"rpc",
+		nil,
+		"Chain name and RPC endpoint to check, as NAME=RPC_URL. May be repeated",
+	)
+	checkDeployedCmd.Flags().StringVar(&checkAddress, "address", "", "Address of the deployed contract")
+	checkDeployedCmd.Flags().DurationVar(&checkTimeout, "timeout", time.Minute, "Timeout of the command")
+	cobra.CheckErr(checkDeployedCmd.MarkFlagRequired("rpc"))
+}
@@ -4,51 +4,71 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
-	"log"
 	"os"
-	"strconv"
+	"strings"
 
 	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
 )
 
-func main() {
-	if len(os.Args) < 2 {
-		log.Panic("Invalid argument count. Must provide at least one argument to specify command type.")
+var rootCmd = &cobra.Command{
+	Use:   "contract-deployment",
+	Short: "Tools to deploy the ICM contracts to the same address on every chain",
+	Long: `Tools to construct, fund, broadcast and verify deployments of the ICM contracts.
+TeleporterMessenger is deployed using Nick's method, so that it has the same address on
+every EVM based chain. All commands write their results to standard output as JSON.`,
+	SilenceUsage: true,
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main(). It only needs to happen once to the rootCmd.
+func Execute() {
+	err := rootCmd.Execute()
+	if err != nil {
+		os.Exit(1)
 	}
-	commandType := os.Args[1]
+}
 
-	switch commandType {
-	case "constructKeylessTx":
-		// Get the byte code of the teleporter contract to be deployed.
-		if len(os.Args) != 3 {
-			log.Panic("Invalid argument count. Must provide JSON file containing contract bytecode.")
-		}
-		_, _, _, _, err := deploymentUtils.ConstructKeylessTransaction(
-			os.Args[2],
-			true,
-			deploymentUtils.GetDefaultContractCreationGasPrice(),
-		)
-		if err != nil {
-			log.Panic("Failed to construct keyless transaction.", err)
-		}
-	case "deriveContractAddress":
-		// Get the byte code of the teleporter contract to be deployed.
-		if len(os.Args) != 4 {
-			log.Panic("Invalid argument count. Must provide address and nonce.")
-		}
+func init() {
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+}
 
-		deployerAddress := common.HexToAddress(os.Args[2])
-		nonce, err := strconv.ParseUint(os.Args[3], 10, 64)
+func printJSON(cmd *cobra.Command, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), string(b))
+	return err
+}
+
+// readRawTransaction decodes a hex encoded signed transaction, given either directly or as the
+// path to a file containing it, such as the one written by constructKeylessTx.
+func readRawTransaction(arg string) (*types.Transaction, error) {
+	encoded := strings.TrimSpace(arg)
+	if !strings.HasPrefix(encoded, "0x") {
+		contents, err := os.ReadFile(arg)
 		if err != nil {
-			log.Panic("Failed to parse nonce as uint", err)
+			return nil, errors.Wrap(err, "argument is neither a 0x prefixed transaction nor a readable file")
 		}
+		encoded = strings.TrimSpace(string(contents))
+	}
+	return deploymentUtils.ParseRawTransaction(encoded)
+}
 
-		resultAddress := crypto.CreateAddress(deployerAddress, nonce)
-		fmt.Println(resultAddress.Hex())
-	default:
-		log.Panic("Invalid command type. Supported options are \"constructKeylessTx\" and \"deriveContractAddress\".")
+// transactionSender recovers the sender of [tx], including keyless transactions without replay protection
+func transactionSender(tx *types.Transaction) (common.Address, error) {
+	if !tx.Protected() {
+		return types.HomesteadSigner{}.Sender(tx)
 	}
+	return types.LatestSignerForChainID(tx.ChainId()).Sender(tx)
+}
+
+func main() {
+	Execute()
 }
@@ -0,0 +1,147 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func executeTestCmd(t *testing.T, c *cobra.Command, args ...string) (string, error) {
+	buf := new(bytes.Buffer)
+	c.SetOut(buf)
+	c.SetErr(buf)
+	c.SetArgs(args)
+
+	err := c.Execute()
+	return strings.TrimSpace(buf.String()), err
+}
+
+func writeTestArtifact(t *testing.T) string {
+	fileName := filepath.Join(t.TempDir(), "Contract.json")
+	err := os.WriteFile(fileName, []byte(`{
+		"bytecode": {"object": "0x6080604052"},
+		"deployedBytecode": {"object": "0x60806040"}
+	}`), 0o600)
+	require.NoError(t, err)
+	return fileName
+}
+
+func TestCmdErrors(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{
+			name: "invalid",
+			args: []string{"invalid"},
+			err:  "unknown command",
+		},
+		{
+			name: "invalid nonce",
+			args: []string{"deriveContractAddress", "0x38545c4b331D8BFb3bee94C62D77a6735b5eF8c0", "one"},
+			err:  "failed to parse nonce",
+		},
+		{
+			name: "short salt",
+			args: []string{"deriveCreate2Address", "0x01", "0x6080"},
+			err:  "salt must be 32 hex encoded bytes",
+		},
+		{
+			name: "fundDeployer without rpc",
+			args: []string{"fundDeployer", "0x00"},
+			err:  "required flag(s) \"rpc\" not set",
+		},
+		{
+			name: "broadcast without rpc",
+			args: []string{"broadcast", "0x00"},
+			err:  "required flag(s) \"rpc\" not set",
+		},
+		{
+			name: "checkDeployed without rpc",
+			args: []string{"checkDeployed", "Contract.json"},
+			err:  "required flag(s) \"rpc\" not set",
+		},
+		{
+			name: "deployTeleporter without rpc",
+			args: []string{"deployTeleporter", "--private-key", "0x01"},
+			err:  "required flag(s) \"rpc\" not set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := executeTestCmd(t, rootCmd, tt.args...)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestDeriveContractAddressCmd(t *testing.T) {
+	deployer := common.HexToAddress("0x38545c4b331D8BFb3bee94C62D77a6735b5eF8c0")
+	out, err := executeTestCmd(t, rootCmd, "deriveContractAddress", deployer.Hex(), "1")
+	require.NoError(t, err)
+
+	var output contractAddressOutput
+	require.NoError(t, json.Unmarshal([]byte(out), &output))
+	require.Equal(t, crypto.CreateAddress(deployer, 1), output.Address)
+}
+
+func TestDeriveCreate2AddressCmd(t *testing.T) {
+	salt := common.Hash{1, 2, 3}
+	initCode := []byte{0x60, 0x80, 0x60, 0x40, 0x52}
+	constructorArgs := common.LeftPadBytes([]byte{1}, 32)
+	out, err := executeTestCmd(
+		t,
+		rootCmd,
+		"deriveCreate2Address",
+		salt.Hex(),
+		writeTestArtifact(t),
+		"--constructor-args",
+		hexutil.Encode(constructorArgs),
+	)
+	require.NoError(t, err)
+
+	var output create2AddressOutput
+	require.NoError(t, json.Unmarshal([]byte(out), &output))
+	expectedInitCodeHash := crypto.Keccak256(append(initCode, constructorArgs...))
+	require.Equal(t, deploymentUtils.DeterministicDeploymentProxyAddress, output.Factory)
+	require.Equal(t, common.BytesToHash(expectedInitCodeHash), output.InitCodeHash)
+	require.Equal(
+		t,
+		crypto.CreateAddress2(deploymentUtils.DeterministicDeploymentProxyAddress, salt, expectedInitCodeHash),
+		output.Address,
+	)
+}
+
+func TestConstructKeylessTxCmd(t *testing.T) {
+	out, err := executeTestCmd(t, rootCmd, "constructKeylessTx", writeTestArtifact(t), "--write-files=false")
+	require.NoError(t, err)
+
+	var output keylessTxOutput
+	require.NoError(t, json.Unmarshal([]byte(out), &output))
+	require.Equal(t, crypto.CreateAddress(output.DeployerAddress, 0), output.ContractAddress)
+
+	// The transaction can be read back from a file, and is signed by the keyless deployer.
+	txFileName := filepath.Join(t.TempDir(), "tx.txt")
+	require.NoError(t, os.WriteFile(txFileName, []byte(output.Transaction.String()+"\n"), 0o600))
+	tx, err := readRawTransaction(txFileName)
+	require.NoError(t, err)
+	sender, err := transactionSender(tx)
+	require.NoError(t, err)
+	require.Equal(t, output.DeployerAddress, sender)
+	require.Equal(t, []byte{0x60, 0x80, 0x60, 0x40, 0x52}, tx.Data())
+}
@@ -0,0 +1,141 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deployRPCEndpoints      map[string]string
+	deployPrivateKey        string
+	deployKeylessTx         string
+	deployTeleporterFile    string
+	deployRegistryFile      string
+	deployReportFile        string
+	deployTimeoutPerChainTx time.Duration
+)
+
+var deployTeleporterCmd = &cobra.Command{
+	Use:   "deployTeleporter --rpc NAME=RPC_URL [--rpc NAME=RPC_URL...] --private-key KEY",
+	Short: "Deploys TeleporterMessenger and TeleporterRegistry to a list of chains",
+	Long: `Deploys TeleporterMessenger to its universal address on each chain by funding the keyless
+deployer and sending the keyless transaction, deploys TeleporterRegistry with TeleporterMessenger
+registered as version 1, and initializes the TeleporterMessenger blockchain ID.
+
+Steps that were already performed are skipped, so the command can be rerun safely. Since
+TeleporterRegistry is not deployed to a universal address, it is only reused if the report
+file of an earlier run records it. The report is updated after each chain, keeping the
+steps recorded by earlier runs.
+
+The keyless transaction is taken from --keyless-tx, or constructed from --teleporter-artifact.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config := deploymentUtils.TeleporterDeploymentConfig{}
+		var err error
+		switch {
+		case deployKeylessTx != "":
+			config.KeylessTransaction, err = readRawTransaction(deployKeylessTx)
+		case deployTeleporterFile != "":
+			var txBytes []byte
+			txBytes, _, _, _, err = deploymentUtils.ConstructKeylessTransaction(
+				deployTeleporterFile,
+				false,
+				deploymentUtils.GetDefaultContractCreationGasPrice(),
+			)
+			if err == nil {
+				config.KeylessTransaction, err = deploymentUtils.ParseRawTransaction(hexutil.Encode(txBytes))
+			}
+		default:
+			err = errors.New("one of --keyless-tx or --teleporter-artifact is required")
+		}
+		if err != nil {
+			return err
+		}
+		if deployRegistryFile != "" {
+			config.RegistryBytecode, err = deploymentUtils.ReadBytecodeFile(deployRegistryFile)
+			if err != nil {
+				return err
+			}
+		}
+		config.FunderKey, err = deploymentUtils.ParsePrivateKey(deployPrivateKey)
+		if err != nil {
+			return err
+		}
+
+		report, err := deploymentUtils.ReadDeploymentReport(deployReportFile)
+		if err != nil {
+			return err
+		}
+		chains := make([]string, 0, len(deployRPCEndpoints))
+		for chain := range deployRPCEndpoints {
+			chains = append(chains, chain)
+		}
+		sort.Strings(chains)
+
+		var failed []string
+		for _, chain := range chains {
+			chainReport, err := deployToChain(cmd.Context(), chain, deployRPCEndpoints[chain], config, report.Chain(chain))
+			if err != nil {
+				chainReport.Error = err.Error()
+				failed = append(failed, chain)
+			}
+			report.SetChain(*chainReport)
+			if err := deploymentUtils.WriteDeploymentReport(deployReportFile, report); err != nil {
+				return err
+			}
+		}
+		if err := printJSON(cmd, report); err != nil {
+			return err
+		}
+		if len(failed) > 0 {
+			return errors.Errorf("deployment failed on %v", failed)
+		}
+		return nil
+	},
+}
+
+func deployToChain(
+	ctx context.Context,
+	chain string,
+	rpcEndpoint string,
+	config deploymentUtils.TeleporterDeploymentConfig,
+	previous *deploymentUtils.ChainDeploymentReport,
+) (*deploymentUtils.ChainDeploymentReport, error) {
+	ctx, cancel := context.WithTimeout(ctx, deployTimeoutPerChainTx)
+	defer cancel()
+	client, err := ethclient.DialContext(ctx, rpcEndpoint)
+	if err != nil {
+		return &deploymentUtils.ChainDeploymentReport{Chain: chain}, errors.Wrap(err, "failed to dial chain")
+	}
+	defer client.Close()
+	return deploymentUtils.DeployTeleporter(ctx, client, chain, config, previous)
+}
+
+func init() {
+	rootCmd.AddCommand(deployTeleporterCmd)
+	flags := deployTeleporterCmd.Flags()
+	flags.StringToStringVar(
+		&deployRPCEndpoints,
+		"rpc",
+		nil,
+		"Chain name and RPC endpoint to deploy to, as NAME=RPC_URL. May be repeated",
+	)
+	flags.StringVar(&deployPrivateKey, "private-key", "", "Hex encoded private key of the account paying for the deployment")
+	flags.StringVar(&deployKeylessTx, "keyless-tx", "", "Keyless TeleporterMessenger deployment transaction, or a file containing it")
+	flags.StringVar(&deployTeleporterFile, "teleporter-artifact", "", "TeleporterMessenger forge build artifact")
+	flags.StringVar(&deployRegistryFile, "registry-artifact", "", "TeleporterRegistry forge build artifact")
+	flags.StringVar(&deployReportFile, "report", "deployment-report.json", "File the deployment report is read from and written to")
+	flags.DurationVar(&deployTimeoutPerChainTx, "timeout", 5*time.Minute, "Timeout of the deployment on each chain")
+	cobra.CheckErr(deployTeleporterCmd.MarkFlagRequired("rpc"))
+	cobra.CheckErr(deployTeleporterCmd.MarkFlagRequired("private-key"))
+}
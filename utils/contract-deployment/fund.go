@@ -0,0 +1,100 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type fundDeployerOutput struct {
+	DeployerAddress    common.Address `json:"deployerAddress"`
+	RequiredBalance    *big.Int       `json:"requiredBalance"`
+	Balance            *big.Int       `json:"balance"`
+	Shortfall          *big.Int       `json:"shortfall"`
+	FundingTransaction *common.Hash   `json:"fundingTransaction,omitempty"`
+}
+
+var (
+	fundRPCEndpoint string
+	fundPrivateKey  string
+	fundTimeout     time.Duration
+)
+
+var fundDeployerCmd = &cobra.Command{
+	Use:   "fundDeployer --rpc RPC_URL [--private-key KEY] TRANSACTION",
+	Short: "Computes and optionally sends the funds needed to send a deployment transaction",
+	Long: `Given a signed deployment transaction, such as a keyless transaction built by
+constructKeylessTx, either hex encoded or as the path to a file containing it, this command
+computes the exact balance its sender needs to send it, gas price times gas limit plus value,
+and the shortfall from its current balance. If --private-key is passed, the shortfall is
+transferred to the sender and the command waits for the transfer to be accepted.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tx, err := readRawTransaction(args[0])
+		if err != nil {
+			return err
+		}
+		sender, err := transactionSender(tx)
+		if err != nil {
+			return errors.Wrap(err, "failed to recover transaction sender")
+		}
+		client, err := ethclient.Dial(fundRPCEndpoint)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		ctx, cancel := context.WithTimeout(cmd.Context(), fundTimeout)
+		defer cancel()
+
+		nonce, err := client.NonceAt(ctx, sender, nil)
+		if err != nil {
+			return errors.Wrap(err, "failed to get deployer nonce")
+		}
+		if nonce > tx.Nonce() {
+			return errors.Errorf("nonce %d of %s is already used, the transaction can no longer be sent", tx.Nonce(), sender)
+		}
+		balance, err := client.BalanceAt(ctx, sender, nil)
+		if err != nil {
+			return errors.Wrap(err, "failed to get deployer balance")
+		}
+		output := fundDeployerOutput{
+			DeployerAddress: sender,
+			RequiredBalance: tx.Cost(),
+			Balance:         balance,
+			Shortfall:       new(big.Int).Sub(tx.Cost(), balance),
+		}
+		if output.Shortfall.Sign() < 0 {
+			output.Shortfall.SetUint64(0)
+		}
+
+		if fundPrivateKey != "" && output.Shortfall.Sign() > 0 {
+			funderKey, err := deploymentUtils.ParsePrivateKey(fundPrivateKey)
+			if err != nil {
+				return err
+			}
+			fundingTxHash, err := deploymentUtils.FundAddress(ctx, client, funderKey, sender, output.Shortfall)
+			if err != nil {
+				return err
+			}
+			output.FundingTransaction = &fundingTxHash
+		}
+		return printJSON(cmd, output)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(fundDeployerCmd)
+	fundDeployerCmd.Flags().StringVar(&fundRPCEndpoint, "rpc", "", "RPC endpoint of the chain to deploy to")
+	fundDeployerCmd.Flags().StringVar(&fundPrivateKey, "private-key", "", "Hex encoded private key of the funding account")
+	fundDeployerCmd.Flags().DurationVar(&fundTimeout, "timeout", 2*time.Minute, "Timeout of the command")
+	cobra.CheckErr(fundDeployerCmd.MarkFlagRequired("rpc"))
+}
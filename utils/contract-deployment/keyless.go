@@ -0,0 +1,71 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"math/big"
+
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type keylessTxOutput struct {
+	Transaction     hexutil.Bytes  `json:"transaction"`
+	DeployerAddress common.Address `json:"deployerAddress"`
+	ContractAddress common.Address `json:"contractAddress"`
+}
+
+var (
+	keylessGasPrice   string
+	keylessWriteFiles bool
+)
+
+var constructKeylessTxCmd = &cobra.Command{
+	Use:   "constructKeylessTx BYTECODE_FILE",
+	Short: "Constructs a keyless transaction deploying a contract using Nick's method",
+	Long: `Given a forge build artifact, this command constructs a keyless transaction deploying
+the contract using Nick's method, and derives the single use deployer address that must be
+funded to send it, and the address of the deployed contract. The results are also written to
+UniversalTeleporterDeployerTransaction.txt, UniversalTeleporterDeployerAddress.txt and
+UniversalTeleporterMessengerContractAddress.txt unless --write-files=false is passed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gasPrice, ok := new(big.Int).SetString(keylessGasPrice, 10)
+		if !ok {
+			return errors.Errorf("invalid gas price %s", keylessGasPrice)
+		}
+		txBytes, _, deployerAddress, contractAddress, err := deploymentUtils.ConstructKeylessTransaction(
+			args[0],
+			keylessWriteFiles,
+			gasPrice,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to construct keyless transaction")
+		}
+		return printJSON(cmd, keylessTxOutput{
+			Transaction:     txBytes,
+			DeployerAddress: deployerAddress,
+			ContractAddress: contractAddress,
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(constructKeylessTxCmd)
+	constructKeylessTxCmd.Flags().StringVar(
+		&keylessGasPrice,
+		"gas-price",
+		deploymentUtils.GetDefaultContractCreationGasPrice().String(),
+		"Gas price of the keyless transaction in wei. Changing it changes the deployer and contract addresses",
+	)
+	constructKeylessTxCmd.Flags().BoolVar(
+		&keylessWriteFiles,
+		"write-files",
+		true,
+		"Write the results to files in the current directory",
+	)
+}
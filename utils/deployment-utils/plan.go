@@ -0,0 +1,298 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	transparentupgradeableproxy "github.com/ava-labs/icm-contracts/abi-bindings/go/TransparentUpgradeableProxy"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultGasLimitPaddingPercent = 20
+	// Gas of a plain transfer funding a keyless deployer
+	fundKeylessDeployerGasLimit = uint64(21000)
+)
+
+// DeterministicDeploymentProxyAddress is the address of the widely deployed CREATE2 factory
+// (https://github.com/Arachnid/deterministic-deployment-proxy), which deploys the init code
+// following a 32 byte salt in its calldata.
+var DeterministicDeploymentProxyAddress = common.HexToAddress("0x4e59b44847b379578588920ca78fbf26c0b4956c")
+
+// DeploymentMethod selects how a contract's address is determined
+type DeploymentMethod int
+
+const (
+	// Keyless deploys the contract using Nick's method, from a single use sender derived from the
+	// transaction itself, so that the contract has the same address on every chain.
+	Keyless DeploymentMethod = iota
+	// Create deploys the contract from the deployer key, at an address derived from its nonce.
+	Create
+	// Create2 deploys the contract through the CREATE2 factory, at an address derived from the
+	// salt and init code.
+	Create2
+)
+
+func (m DeploymentMethod) String() string {
+	switch m {
+	case Keyless:
+		return "keyless"
+	case Create:
+		return "create"
+	case Create2:
+		return "create2"
+	default:
+		return fmt.Sprintf("DeploymentMethod(%d)", int(m))
+	}
+}
+
+// ConstructorArgsFunc returns the ABI encoded constructor arguments of a contract, given the
+// addresses of the contracts planned before it, keyed by name.
+type ConstructorArgsFunc func(addresses map[string]common.Address) ([]byte, error)
+
+// ContractSpec is an entry of a deployment manifest
+type ContractSpec struct {
+	// Name identifies the contract in the manifest, and must be unique.
+	Name string
+	// Bytecode is the contract's creation bytecode, without constructor arguments.
+	Bytecode []byte
+	// ConstructorArgs is optional.
+	ConstructorArgs ConstructorArgsFunc
+	Method          DeploymentMethod
+	// Salt is only used by Create2.
+	Salt common.Hash
+	// GasLimit is estimated if zero. Estimation runs against the current chain state, so contracts
+	// whose constructors call contracts planned before them must set it.
+	GasLimit uint64
+}
+
+// TransparentProxySpec returns a ContractSpec for a TransparentUpgradeableProxy owned by [admin]
+// that delegates to the contract named [implementation] and is initialized with [initData].
+func TransparentProxySpec(
+	name string,
+	implementation string,
+	method DeploymentMethod,
+	salt common.Hash,
+	admin common.Address,
+	initData []byte,
+) (ContractSpec, error) {
+	bytecode, err := hex.DecodeString(
+		strings.TrimPrefix(transparentupgradeableproxy.TransparentUpgradeableProxyMetaData.Bin, "0x"),
+	)
+	if err != nil {
+		return ContractSpec{}, errors.Wrap(err, "failed to decode proxy bytecode")
+	}
+	proxyABI, err := transparentupgradeableproxy.TransparentUpgradeableProxyMetaData.GetAbi()
+	if err != nil {
+		return ContractSpec{}, errors.Wrap(err, "failed to parse proxy ABI")
+	}
+	if initData == nil {
+		initData = []byte{}
+	}
+	return ContractSpec{
+		Name:     name,
+		Bytecode: bytecode,
+		ConstructorArgs: func(addresses map[string]common.Address) ([]byte, error) {
+			implementationAddress, ok := addresses[implementation]
+			if !ok {
+				return nil, fmt.Errorf("implementation %s must be planned before proxy %s", implementation, name)
+			}
+			return proxyABI.Pack("", implementationAddress, admin, initData)
+		},
+		Method: method,
+		Salt:   salt,
+	}, nil
+}
+
+// ReadBytecodeFile returns the creation bytecode from a forge build artifact
+func ReadBytecodeFile(byteCodeFileName string) ([]byte, error) {
+	byteCodeFile, err := extractByteCode(byteCodeFileName)
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(strings.TrimPrefix(byteCodeFile.ByteCode.Object, "0x"))
+}
+
+// DeploymentPlanConfig configures how a manifest is turned into transactions
+type DeploymentPlanConfig struct {
+	ChainID *big.Int
+	// Deployer signs the Create and Create2 transactions, and the transfers funding keyless deployers.
+	Deployer      *ecdsa.PrivateKey
+	DeployerNonce uint64
+	GasFeeCap     *big.Int
+	GasTipCap     *big.Int
+	// KeylessGasPrice is the gas price of keyless transactions, which determines the keyless
+	// deployer addresses. Defaults to GetDefaultContractCreationGasPrice.
+	KeylessGasPrice *big.Int
+	// Create2Factory defaults to DeterministicDeploymentProxyAddress, and must already be deployed.
+	Create2Factory common.Address
+	// GasLimitPaddingPercent is added to estimated gas limits. Defaults to 20.
+	GasLimitPaddingPercent uint64
+}
+
+// PlannedDeployment is the outcome of planning a single ContractSpec
+type PlannedDeployment struct {
+	Name    string         `json:"name"`
+	Method  string         `json:"method"`
+	Address common.Address `json:"address"`
+	// Sender is the address sending the deployment transaction. For keyless deployments it must
+	// hold no funds other than those transferred by the bundle, and have nonce 0.
+	Sender   common.Address `json:"sender"`
+	GasLimit uint64         `json:"gasLimit"`
+}
+
+// DeploymentBundle holds all contract addresses of a manifest, and the signed transactions
+// deploying them. The transactions must be broadcast in order.
+type DeploymentBundle struct {
+	Deployments  []PlannedDeployment `json:"deployments"`
+	Transactions []hexutil.Bytes     `json:"transactions"`
+}
+
+// Addresses returns the planned contract addresses keyed by name
+func (b *DeploymentBundle) Addresses() map[string]common.Address {
+	addresses := make(map[string]common.Address, len(b.Deployments))
+	for _, deployment := range b.Deployments {
+		addresses[deployment.Name] = deployment.Address
+	}
+	return addresses
+}
+
+// PlanDeployment computes the address of every contract in [manifest] and signs the transactions
+// deploying them in manifest order. Gas limits not set in the manifest are estimated with [estimator].
+func PlanDeployment(
+	ctx context.Context,
+	estimator interfaces.GasEstimator,
+	config DeploymentPlanConfig,
+	manifest []ContractSpec,
+) (*DeploymentBundle, error) {
+	if config.Deployer == nil {
+		return nil, errors.New("deployer key is required")
+	}
+	if config.ChainID == nil || config.GasFeeCap == nil || config.GasTipCap == nil {
+		return nil, errors.New("chain ID and gas prices are required")
+	}
+	if config.KeylessGasPrice == nil {
+		config.KeylessGasPrice = GetDefaultContractCreationGasPrice()
+	}
+	if config.Create2Factory == (common.Address{}) {
+		config.Create2Factory = DeterministicDeploymentProxyAddress
+	}
+	if config.GasLimitPaddingPercent == 0 {
+		config.GasLimitPaddingPercent = defaultGasLimitPaddingPercent
+	}
+
+	deployerAddress := crypto.PubkeyToAddress(config.Deployer.PublicKey)
+	signer := types.LatestSignerForChainID(config.ChainID)
+	nonce := config.DeployerNonce
+	signDeployerTx := func(to *common.Address, value *big.Int, gasLimit uint64, data []byte) (hexutil.Bytes, error) {
+		tx, err := types.SignNewTx(config.Deployer, signer, &types.DynamicFeeTx{
+			ChainID:   config.ChainID,
+			Nonce:     nonce,
+			GasTipCap: config.GasTipCap,
+			GasFeeCap: config.GasFeeCap,
+			Gas:       gasLimit,
+			To:        to,
+			Value:     value,
+			Data:      data,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to sign transaction")
+		}
+		nonce++
+		return tx.MarshalBinary()
+	}
+
+	bundle := &DeploymentBundle{}
+	addresses := make(map[string]common.Address)
+	for _, spec := range manifest {
+		if _, ok := addresses[spec.Name]; ok || spec.Name == "" {
+			return nil, fmt.Errorf("contract name %q is empty or not unique", spec.Name)
+		}
+		initCode := append([]byte{}, spec.Bytecode...)
+		if spec.ConstructorArgs != nil {
+			args, err := spec.ConstructorArgs(addresses)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to encode constructor arguments of %s", spec.Name)
+			}
+			initCode = append(initCode, args...)
+		}
+
+		// Deployments through the factory are calls to it, and the others are contract creations.
+		var to *common.Address
+		data := initCode
+		if spec.Method == Create2 {
+			to = &config.Create2Factory
+			data = append(spec.Salt.Bytes(), initCode...)
+		}
+		gasLimit := spec.GasLimit
+		if gasLimit == 0 {
+			estimated, err := estimator.EstimateGas(ctx, interfaces.CallMsg{
+				From: deployerAddress,
+				To:   to,
+				Data: data,
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to estimate gas of %s, set its gas limit explicitly", spec.Name)
+			}
+			gasLimit = estimated + estimated*config.GasLimitPaddingPercent/100
+		}
+
+		deployment := PlannedDeployment{
+			Name:     spec.Name,
+			Method:   spec.Method.String(),
+			GasLimit: gasLimit,
+		}
+		switch spec.Method {
+		case Keyless:
+			keylessTx, sender, err := newKeylessTransaction(initCode, gasLimit, config.KeylessGasPrice)
+			if err != nil {
+				return nil, err
+			}
+			funding := new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), config.KeylessGasPrice)
+			fundingTx, err := signDeployerTx(&sender, funding, fundKeylessDeployerGasLimit, nil)
+			if err != nil {
+				return nil, err
+			}
+			keylessTxBytes, err := keylessTx.MarshalBinary()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to serialize keyless transaction")
+			}
+			bundle.Transactions = append(bundle.Transactions, fundingTx, keylessTxBytes)
+			deployment.Sender = sender
+			deployment.Address = crypto.CreateAddress(sender, 0)
+		case Create:
+			deployment.Sender = deployerAddress
+			deployment.Address = crypto.CreateAddress(deployerAddress, nonce)
+			tx, err := signDeployerTx(nil, common.Big0, gasLimit, data)
+			if err != nil {
+				return nil, err
+			}
+			bundle.Transactions = append(bundle.Transactions, tx)
+		case Create2:
+			tx, err := signDeployerTx(to, common.Big0, gasLimit, data)
+			if err != nil {
+				return nil, err
+			}
+			bundle.Transactions = append(bundle.Transactions, tx)
+			deployment.Sender = deployerAddress
+			deployment.Address = crypto.CreateAddress2(config.Create2Factory, spec.Salt, crypto.Keccak256(initCode))
+		default:
+			return nil, fmt.Errorf("unknown deployment method %d for %s", int(spec.Method), spec.Name)
+		}
+		addresses[spec.Name] = deployment.Address
+		bundle.Deployments = append(bundle.Deployments, deployment)
+	}
+	return bundle, nil
+}
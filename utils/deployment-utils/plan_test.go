@@ -0,0 +1,179 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+type mockGasEstimator struct {
+	gas   uint64
+	calls []interfaces.CallMsg
+}
+
+func (m *mockGasEstimator) EstimateGas(_ context.Context, call interfaces.CallMsg) (uint64, error) {
+	m.calls = append(m.calls, call)
+	return m.gas, nil
+}
+
+func TestPlanDeployment(t *testing.T) {
+	deployerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	deployerAddress := crypto.PubkeyToAddress(deployerKey.PublicKey)
+	chainID := big.NewInt(43112)
+	salt := common.Hash{1}
+	admin := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+	config := DeploymentPlanConfig{
+		ChainID:       chainID,
+		Deployer:      deployerKey,
+		DeployerNonce: 5,
+		GasFeeCap:     big.NewInt(50e9),
+		GasTipCap:     big.NewInt(1e9),
+	}
+
+	proxySpec, err := TransparentProxySpec("ValidatorManagerProxy", "ValidatorManager", Create, common.Hash{}, admin, nil)
+	require.NoError(t, err)
+	manifest := []ContractSpec{
+		{
+			Name:     "TeleporterMessenger",
+			Bytecode: []byte{0x60, 0x80, 0x01},
+			Method:   Keyless,
+		},
+		{
+			Name:     "TeleporterRegistry",
+			Bytecode: []byte{0x60, 0x80, 0x02},
+			ConstructorArgs: func(addresses map[string]common.Address) ([]byte, error) {
+				return common.LeftPadBytes(addresses["TeleporterMessenger"].Bytes(), 32), nil
+			},
+			Method: Create,
+		},
+		{
+			Name:     "ValidatorManager",
+			Bytecode: []byte{0x60, 0x80, 0x03},
+			Method:   Create2,
+			Salt:     salt,
+			GasLimit: 3_000_000,
+		},
+		proxySpec,
+	}
+
+	estimator := &mockGasEstimator{gas: 1_000_000}
+	bundle, err := PlanDeployment(context.Background(), estimator, config, manifest)
+	require.NoError(t, err)
+	require.Len(t, bundle.Deployments, len(manifest))
+	// The keyless deployment is funded by a separate transaction.
+	require.Len(t, bundle.Transactions, len(manifest)+1)
+	// Only the contract with an explicit gas limit is not estimated.
+	require.Len(t, estimator.calls, len(manifest)-1)
+
+	txs := make([]*types.Transaction, len(bundle.Transactions))
+	for i, txBytes := range bundle.Transactions {
+		txs[i] = new(types.Transaction)
+		require.NoError(t, txs[i].UnmarshalBinary(txBytes))
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	expectedNonce := config.DeployerNonce
+	for _, tx := range []*types.Transaction{txs[0], txs[2], txs[3], txs[4]} {
+		sender, err := types.Sender(signer, tx)
+		require.NoError(t, err)
+		require.Equal(t, deployerAddress, sender)
+		require.Equal(t, expectedNonce, tx.Nonce())
+		expectedNonce++
+	}
+
+	// Keyless deployment
+	messenger := bundle.Deployments[0]
+	keylessSender, err := types.HomesteadSigner{}.Sender(txs[1])
+	require.NoError(t, err)
+	require.Equal(t, keylessSender, messenger.Sender)
+	require.Equal(t, crypto.CreateAddress(keylessSender, 0), messenger.Address)
+	require.Equal(t, uint64(1_200_000), messenger.GasLimit)
+	require.Equal(t, uint64(1_200_000), txs[1].Gas())
+	require.Equal(t, keylessSender, *txs[0].To())
+	require.Equal(t, new(big.Int).Mul(big.NewInt(1_200_000), GetDefaultContractCreationGasPrice()), txs[0].Value())
+
+	// Create deployment, with constructor arguments depending on the keyless deployment
+	registry := bundle.Deployments[1]
+	require.Equal(t, crypto.CreateAddress(deployerAddress, config.DeployerNonce+1), registry.Address)
+	require.Nil(t, txs[2].To())
+	require.Equal(
+		t,
+		append([]byte{0x60, 0x80, 0x02}, common.LeftPadBytes(messenger.Address.Bytes(), 32)...),
+		txs[2].Data(),
+	)
+
+	// Create2 deployment
+	validatorManager := bundle.Deployments[2]
+	require.Equal(
+		t,
+		crypto.CreateAddress2(DeterministicDeploymentProxyAddress, salt, crypto.Keccak256([]byte{0x60, 0x80, 0x03})),
+		validatorManager.Address,
+	)
+	require.Equal(t, DeterministicDeploymentProxyAddress, *txs[3].To())
+	require.Equal(t, append(salt.Bytes(), 0x60, 0x80, 0x03), txs[3].Data())
+	require.Equal(t, uint64(3_000_000), txs[3].Gas())
+
+	// Proxy delegating to the Create2 deployment
+	proxy := bundle.Deployments[3]
+	require.Equal(t, crypto.CreateAddress(deployerAddress, config.DeployerNonce+3), proxy.Address)
+	require.Equal(
+		t,
+		common.LeftPadBytes(validatorManager.Address.Bytes(), 32),
+		txs[4].Data()[len(proxySpec.Bytecode):len(proxySpec.Bytecode)+32],
+	)
+
+	require.Equal(t, map[string]common.Address{
+		"TeleporterMessenger":   messenger.Address,
+		"TeleporterRegistry":    registry.Address,
+		"ValidatorManager":      validatorManager.Address,
+		"ValidatorManagerProxy": proxy.Address,
+	}, bundle.Addresses())
+}
+
+func TestPlanDeploymentInvalidManifest(t *testing.T) {
+	deployerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	config := DeploymentPlanConfig{
+		ChainID:   big.NewInt(1),
+		Deployer:  deployerKey,
+		GasFeeCap: big.NewInt(1),
+		GasTipCap: big.NewInt(1),
+	}
+	proxySpec, err := TransparentProxySpec("Proxy", "Implementation", Create, common.Hash{}, common.Address{}, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		manifest []ContractSpec
+		errMsg   string
+	}{
+		{
+			name: "duplicate name",
+			manifest: []ContractSpec{
+				{Name: "Implementation", Method: Create},
+				{Name: "Implementation", Method: Create},
+			},
+			errMsg: "not unique",
+		},
+		{
+			name:     "proxy before implementation",
+			manifest: []ContractSpec{proxySpec, {Name: "Implementation", Method: Create}},
+			errMsg:   "must be planned before proxy",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := PlanDeployment(context.Background(), &mockGasEstimator{gas: 1}, config, test.manifest)
+			require.ErrorContains(t, err, test.errMsg)
+		})
+	}
+}
@@ -0,0 +1,447 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	teleporterregistry "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/registry/TeleporterRegistry"
+	gasUtils "github.com/ava-labs/icm-contracts/utils/gas-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+const fundingTransferGasLimit = uint64(21000)
+
+// DeploymentStatus records whether a contract was found on chain or deployed by the orchestrator
+type DeploymentStatus string
+
+const (
+	StatusExisting DeploymentStatus = "existing"
+	StatusDeployed DeploymentStatus = "deployed"
+)
+
+// TeleporterDeploymentConfig is the deployment to perform on every chain
+type TeleporterDeploymentConfig struct {
+	// KeylessTransaction deploys TeleporterMessenger using Nick's method, as built by
+	// ConstructKeylessTransaction or published with each release.
+	KeylessTransaction *types.Transaction
+	// RegistryBytecode is the creation bytecode of TeleporterRegistry. Defaults to the bytecode
+	// of the generated bindings.
+	RegistryBytecode []byte
+	// InitialRegistryEntries defaults to version 1 at the TeleporterMessenger address.
+	InitialRegistryEntries []teleporterregistry.ProtocolRegistryEntry
+	// FunderKey pays for funding the keyless deployer, deploying TeleporterRegistry and
+	// initializing the blockchain ID.
+	FunderKey *ecdsa.PrivateKey
+}
+
+// ChainDeploymentReport records the deployment on a single chain. Each field is only set once
+// the step it describes succeeded. The report of a run only holds the transactions sent by that
+// run, and DeploymentReport.SetChain keeps those of earlier runs.
+type ChainDeploymentReport struct {
+	Chain        string `json:"chain"`
+	BlockchainID ids.ID `json:"blockchainID"`
+
+	TeleporterMessengerAddress      common.Address   `json:"teleporterMessengerAddress"`
+	TeleporterMessengerStatus       DeploymentStatus `json:"teleporterMessengerStatus,omitempty"`
+	KeylessDeployerFundingTx        *common.Hash     `json:"keylessDeployerFundingTx,omitempty"`
+	TeleporterMessengerDeploymentTx *common.Hash     `json:"teleporterMessengerDeploymentTx,omitempty"`
+
+	TeleporterRegistryAddress      common.Address   `json:"teleporterRegistryAddress"`
+	TeleporterRegistryStatus       DeploymentStatus `json:"teleporterRegistryStatus,omitempty"`
+	TeleporterRegistryDeploymentTx *common.Hash     `json:"teleporterRegistryDeploymentTx,omitempty"`
+
+	BlockchainIDStatus           DeploymentStatus `json:"blockchainIDStatus,omitempty"`
+	BlockchainIDInitializationTx *common.Hash     `json:"blockchainIDInitializationTx,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// DeploymentReport is the per chain outcome of deploying Teleporter to several chains
+type DeploymentReport struct {
+	Chains []ChainDeploymentReport `json:"chains"`
+}
+
+// Chain returns the report of [chain], or nil if there is none
+func (r *DeploymentReport) Chain(chain string) *ChainDeploymentReport {
+	for i := range r.Chains {
+		if r.Chains[i].Chain == chain {
+			return &r.Chains[i]
+		}
+	}
+	return nil
+}
+
+// SetChain adds the report of a chain, or merges it into the chain's existing report. Only the
+// fields set in [report] replace existing ones, so that a run that fails partway keeps the
+// outcome of the steps performed by earlier runs. The error is always replaced.
+func (r *DeploymentReport) SetChain(report ChainDeploymentReport) {
+	existing := r.Chain(report.Chain)
+	if existing == nil {
+		r.Chains = append(r.Chains, report)
+		return
+	}
+	if report.BlockchainID != ids.Empty {
+		existing.BlockchainID = report.BlockchainID
+	}
+	if report.TeleporterMessengerAddress != (common.Address{}) {
+		existing.TeleporterMessengerAddress = report.TeleporterMessengerAddress
+	}
+	if report.TeleporterMessengerStatus != "" {
+		existing.TeleporterMessengerStatus = report.TeleporterMessengerStatus
+	}
+	if report.KeylessDeployerFundingTx != nil {
+		existing.KeylessDeployerFundingTx = report.KeylessDeployerFundingTx
+	}
+	if report.TeleporterMessengerDeploymentTx != nil {
+		existing.TeleporterMessengerDeploymentTx = report.TeleporterMessengerDeploymentTx
+	}
+	if report.TeleporterRegistryAddress != (common.Address{}) {
+		existing.TeleporterRegistryAddress = report.TeleporterRegistryAddress
+	}
+	if report.TeleporterRegistryStatus != "" {
+		existing.TeleporterRegistryStatus = report.TeleporterRegistryStatus
+	}
+	if report.TeleporterRegistryDeploymentTx != nil {
+		existing.TeleporterRegistryDeploymentTx = report.TeleporterRegistryDeploymentTx
+	}
+	if report.BlockchainIDStatus != "" {
+		existing.BlockchainIDStatus = report.BlockchainIDStatus
+	}
+	if report.BlockchainIDInitializationTx != nil {
+		existing.BlockchainIDInitializationTx = report.BlockchainIDInitializationTx
+	}
+	existing.Error = report.Error
+}
+
+// ReadDeploymentReport reads a report written by WriteDeploymentReport. A missing file is
+// read as an empty report.
+func ReadDeploymentReport(fileName string) (*DeploymentReport, error) {
+	contents, err := os.ReadFile(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return &DeploymentReport{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to read deployment report")
+	}
+	var report DeploymentReport
+	if err := json.Unmarshal(contents, &report); err != nil {
+		return nil, errors.Wrap(err, "Failed to unmarshal deployment report")
+	}
+	return &report, nil
+}
+
+// WriteDeploymentReport writes [report] to [fileName] as indented JSON
+func WriteDeploymentReport(fileName string, report *DeploymentReport) error {
+	contents, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, contents, 0o644)
+}
+
+// DeployTeleporter deploys TeleporterMessenger and TeleporterRegistry to the chain of [client],
+// and initializes the messenger's blockchain ID. Each step is skipped if it was already performed,
+// so it is safe to rerun after a failure. TeleporterMessenger is found at its universal address.
+// TeleporterRegistry is not, so it is only reused if [previous], the report of an earlier run on
+// the same chain, records an address with code.
+//
+// The returned report describes the steps performed before any error.
+func DeployTeleporter(
+	ctx context.Context,
+	client ethclient.Client,
+	chain string,
+	config TeleporterDeploymentConfig,
+	previous *ChainDeploymentReport,
+) (*ChainDeploymentReport, error) {
+	report := &ChainDeploymentReport{Chain: chain}
+
+	keylessDeployer, err := types.HomesteadSigner{}.Sender(config.KeylessTransaction)
+	if err != nil {
+		return report, errors.Wrap(err, "Failed to recover the sender address of the keyless transaction")
+	}
+	messengerAddress := crypto.CreateAddress(keylessDeployer, 0)
+	if err := deployTeleporterMessenger(ctx, client, config, keylessDeployer, messengerAddress, report); err != nil {
+		return report, err
+	}
+
+	var previousRegistry common.Address
+	if previous != nil {
+		previousRegistry = previous.TeleporterRegistryAddress
+	}
+	if err := deployTeleporterRegistry(ctx, client, config, previousRegistry, report); err != nil {
+		return report, err
+	}
+
+	if err := initializeBlockchainID(ctx, client, config, report); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+func deployTeleporterMessenger(
+	ctx context.Context,
+	client ethclient.Client,
+	config TeleporterDeploymentConfig,
+	keylessDeployer common.Address,
+	messengerAddress common.Address,
+	report *ChainDeploymentReport,
+) error {
+	code, err := client.CodeAt(ctx, messengerAddress, nil)
+	if err != nil {
+		return errors.Wrap(err, "Failed to get TeleporterMessenger code")
+	}
+	if len(code) > 0 {
+		report.TeleporterMessengerAddress = messengerAddress
+		report.TeleporterMessengerStatus = StatusExisting
+		return nil
+	}
+
+	nonce, err := client.NonceAt(ctx, keylessDeployer, nil)
+	if err != nil {
+		return errors.Wrap(err, "Failed to get keyless deployer nonce")
+	}
+	if nonce != 0 {
+		return errors.Errorf(
+			"keyless deployer %s has already sent a transaction, but TeleporterMessenger is not deployed",
+			keylessDeployer,
+		)
+	}
+	balance, err := client.BalanceAt(ctx, keylessDeployer, nil)
+	if err != nil {
+		return errors.Wrap(err, "Failed to get keyless deployer balance")
+	}
+	if shortfall := new(big.Int).Sub(config.KeylessTransaction.Cost(), balance); shortfall.Sign() > 0 {
+		fundingTxHash, err := FundAddress(ctx, client, config.FunderKey, keylessDeployer, shortfall)
+		if err != nil {
+			return errors.Wrap(err, "Failed to fund keyless deployer")
+		}
+		report.KeylessDeployerFundingTx = &fundingTxHash
+	}
+
+	if err := client.SendTransaction(ctx, config.KeylessTransaction); err != nil {
+		return errors.Wrap(err, "Failed to send keyless transaction")
+	}
+	if _, err := waitForSuccess(ctx, client, config.KeylessTransaction); err != nil {
+		return err
+	}
+	txHash := config.KeylessTransaction.Hash()
+	report.TeleporterMessengerAddress = messengerAddress
+	report.TeleporterMessengerDeploymentTx = &txHash
+	report.TeleporterMessengerStatus = StatusDeployed
+	return nil
+}
+
+func deployTeleporterRegistry(
+	ctx context.Context,
+	client ethclient.Client,
+	config TeleporterDeploymentConfig,
+	previousRegistry common.Address,
+	report *ChainDeploymentReport,
+) error {
+	if previousRegistry != (common.Address{}) {
+		code, err := client.CodeAt(ctx, previousRegistry, nil)
+		if err != nil {
+			return errors.Wrap(err, "Failed to get TeleporterRegistry code")
+		}
+		if len(code) > 0 {
+			report.TeleporterRegistryAddress = previousRegistry
+			report.TeleporterRegistryStatus = StatusExisting
+			return nil
+		}
+	}
+
+	registryABI, err := teleporterregistry.TeleporterRegistryMetaData.GetAbi()
+	if err != nil {
+		return errors.Wrap(err, "Failed to parse TeleporterRegistry ABI")
+	}
+	bytecode := config.RegistryBytecode
+	if bytecode == nil {
+		bytecode = common.FromHex(teleporterregistry.TeleporterRegistryMetaData.Bin)
+	}
+	entries := config.InitialRegistryEntries
+	if entries == nil {
+		entries = []teleporterregistry.ProtocolRegistryEntry{
+			{
+				Version:         big.NewInt(1),
+				ProtocolAddress: report.TeleporterMessengerAddress,
+			},
+		}
+	}
+
+	opts, err := newTransactOpts(ctx, client, config.FunderKey)
+	if err != nil {
+		return err
+	}
+	address, tx, _, err := bind.DeployContract(opts, *registryABI, bytecode, client, entries)
+	if err != nil {
+		return errors.Wrap(err, "Failed to deploy TeleporterRegistry")
+	}
+	if _, err := waitForSuccess(ctx, client, tx); err != nil {
+		return err
+	}
+	txHash := tx.Hash()
+	report.TeleporterRegistryAddress = address
+	report.TeleporterRegistryDeploymentTx = &txHash
+	report.TeleporterRegistryStatus = StatusDeployed
+	return nil
+}
+
+func initializeBlockchainID(
+	ctx context.Context,
+	client ethclient.Client,
+	config TeleporterDeploymentConfig,
+	report *ChainDeploymentReport,
+) error {
+	messenger, err := teleportermessenger.NewTeleporterMessenger(report.TeleporterMessengerAddress, client)
+	if err != nil {
+		return errors.Wrap(err, "Failed to bind TeleporterMessenger")
+	}
+	blockchainID, err := messenger.BlockchainID(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return errors.Wrap(err, "Failed to get TeleporterMessenger blockchain ID")
+	}
+	if blockchainID != [32]byte{} {
+		report.BlockchainID = ids.ID(blockchainID)
+		report.BlockchainIDStatus = StatusExisting
+		return nil
+	}
+
+	opts, err := newTransactOpts(ctx, client, config.FunderKey)
+	if err != nil {
+		return err
+	}
+	tx, err := messenger.InitializeBlockchainID(opts)
+	if err != nil {
+		return errors.Wrap(err, "Failed to initialize blockchain ID")
+	}
+	if _, err := waitForSuccess(ctx, client, tx); err != nil {
+		return err
+	}
+	blockchainID, err = messenger.BlockchainID(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return errors.Wrap(err, "Failed to get TeleporterMessenger blockchain ID")
+	}
+	txHash := tx.Hash()
+	report.BlockchainID = ids.ID(blockchainID)
+	report.BlockchainIDInitializationTx = &txHash
+	report.BlockchainIDStatus = StatusDeployed
+	return nil
+}
+
+// FundAddress transfers [amount] to [recipient] from the account of [funderKey], and waits
+// for the transfer to succeed.
+func FundAddress(
+	ctx context.Context,
+	client ethclient.Client,
+	funderKey *ecdsa.PrivateKey,
+	recipient common.Address,
+	amount *big.Int,
+) (common.Hash, error) {
+	if funderKey == nil {
+		return common.Hash{}, errors.Errorf("no funder key to transfer %s wei to %s", amount, recipient)
+	}
+	opts, err := newTransactOpts(ctx, client, funderKey)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "Failed to get chain ID")
+	}
+	nonce, err := client.NonceAt(ctx, opts.From, nil)
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "Failed to get funder nonce")
+	}
+	tx, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: opts.GasTipCap,
+		GasFeeCap: opts.GasFeeCap,
+		Gas:       fundingTransferGasLimit,
+		To:        &recipient,
+		Value:     amount,
+	}))
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "Failed to sign funding transaction")
+	}
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		return common.Hash{}, errors.Wrap(err, "Failed to send funding transaction")
+	}
+	if _, err := waitForSuccess(ctx, client, tx); err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), nil
+}
+
+// ParsePrivateKey parses a hex encoded private key, with or without the 0x prefix
+func ParsePrivateKey(privateKeyHex string) (*ecdsa.PrivateKey, error) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to parse private key")
+	}
+	return privateKey, nil
+}
+
+// ParseRawTransaction decodes a hex encoded signed transaction
+func ParseRawTransaction(encoded string) (*types.Transaction, error) {
+	txBytes, err := hexutil.Decode(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to decode transaction")
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(txBytes); err != nil {
+		return nil, errors.Wrap(err, "Failed to unmarshal transaction")
+	}
+	return tx, nil
+}
+
+func newTransactOpts(ctx context.Context, client ethclient.Client, key *ecdsa.PrivateKey) (*bind.TransactOpts, error) {
+	if key == nil {
+		return nil, errors.New("a funded private key is required to send transactions")
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to get chain ID")
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	if err != nil {
+		return nil, err
+	}
+	price, err := gasUtils.NewTxPricer(
+		gasUtils.NewFeeMarketClient(client),
+		gasUtils.TxPricerConfig{Strategy: gasUtils.Normal},
+	).Price(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	opts.Context = ctx
+	opts.GasFeeCap = price.GasFeeCap
+	opts.GasTipCap = price.GasTipCap
+	return opts, nil
+}
+
+func waitForSuccess(ctx context.Context, client ethclient.Client, tx *types.Transaction) (*types.Receipt, error) {
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to wait for transaction %s", tx.Hash())
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, errors.Errorf("transaction %s failed", tx.Hash())
+	}
+	return receipt, nil
+}
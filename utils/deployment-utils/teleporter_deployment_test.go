@@ -0,0 +1,153 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	gasUtils "github.com/ava-labs/icm-contracts/utils/gas-utils"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// cChainService serves the eth namespace of a chain without subnet-evm's eth_feeConfig,
+// such as the C-Chain
+type cChainService struct{}
+
+func (cChainService) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(43114))
+}
+
+func (cChainService) BaseFee() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(25_000_000_000))
+}
+
+func (cChainService) MaxPriorityFeePerGas() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(1))
+}
+
+// cChainFundingService additionally accepts transactions and reports them as successful
+type cChainFundingService struct {
+	cChainService
+	sent []*types.Transaction
+}
+
+func (*cChainFundingService) GetTransactionCount(common.Address, string) hexutil.Uint64 {
+	return 0
+}
+
+func (s *cChainFundingService) SendRawTransaction(input hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	s.sent = append(s.sent, tx)
+	return tx.Hash(), nil
+}
+
+func (*cChainFundingService) GetTransactionReceipt(hash common.Hash) *types.Receipt {
+	return &types.Receipt{
+		Status: types.ReceiptStatusSuccessful,
+		TxHash: hash,
+		Logs:   []*types.Log{},
+	}
+}
+
+func newCChainClient(t *testing.T, service any) ethclient.Client {
+	server := rpc.NewServer(0)
+	t.Cleanup(server.Stop)
+	require.NoError(t, server.RegisterName("eth", service))
+	return ethclient.NewClient(rpc.DialInProc(server))
+}
+
+func TestNewTransactOptsWithoutFeeConfig(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	opts, err := newTransactOpts(context.Background(), newCChainClient(t, cChainService{}), key)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), opts.From)
+	expectedGasFeeCap := big.NewInt(2*25_000_000_000 + gasUtils.MaxPriorityFeePerGas)
+	require.Equal(t, expectedGasFeeCap, opts.GasFeeCap)
+	require.Equal(t, big.NewInt(1), opts.GasTipCap)
+}
+
+func TestDeploymentReport(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "report.json")
+
+	// A missing report is read as empty
+	report, err := ReadDeploymentReport(fileName)
+	require.NoError(t, err)
+	require.Empty(t, report.Chains)
+	require.Nil(t, report.Chain("a"))
+
+	txHash := common.Hash{1}
+	report.SetChain(ChainDeploymentReport{Chain: "a", Error: "failed"})
+	report.SetChain(ChainDeploymentReport{Chain: "b", TeleporterMessengerStatus: StatusExisting})
+	report.SetChain(ChainDeploymentReport{
+		Chain:                          "a",
+		BlockchainID:                   ids.ID{2},
+		TeleporterRegistryAddress:      common.Address{3},
+		TeleporterRegistryStatus:       StatusDeployed,
+		TeleporterRegistryDeploymentTx: &txHash,
+	})
+	require.Len(t, report.Chains, 2)
+	require.NoError(t, WriteDeploymentReport(fileName, report))
+
+	read, err := ReadDeploymentReport(fileName)
+	require.NoError(t, err)
+	require.Equal(t, report, read)
+	chain := read.Chain("a")
+	require.NotNil(t, chain)
+	require.Empty(t, chain.Error)
+	require.Equal(t, common.Address{3}, chain.TeleporterRegistryAddress)
+	require.Equal(t, StatusExisting, read.Chain("b").TeleporterMessengerStatus)
+
+	// A rerun that fails before reaching the registry keeps the registry of the earlier run
+	read.SetChain(ChainDeploymentReport{
+		Chain:                      "a",
+		TeleporterMessengerAddress: common.Address{4},
+		TeleporterMessengerStatus:  StatusExisting,
+		Error:                      "failed",
+	})
+	chain = read.Chain("a")
+	require.Equal(t, "failed", chain.Error)
+	require.Equal(t, common.Address{4}, chain.TeleporterMessengerAddress)
+	require.Equal(t, StatusExisting, chain.TeleporterMessengerStatus)
+	require.Equal(t, ids.ID{2}, chain.BlockchainID)
+	require.Equal(t, common.Address{3}, chain.TeleporterRegistryAddress)
+	require.Equal(t, StatusDeployed, chain.TeleporterRegistryStatus)
+	require.Equal(t, &txHash, chain.TeleporterRegistryDeploymentTx)
+}
+
+func TestFundAddressWithoutFeeConfig(t *testing.T) {
+	funderKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	service := &cChainFundingService{}
+	recipient := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+
+	txHash, err := FundAddress(
+		context.Background(),
+		newCChainClient(t, service),
+		funderKey,
+		recipient,
+		big.NewInt(1_000),
+	)
+	require.NoError(t, err)
+	require.Len(t, service.sent, 1)
+	tx := service.sent[0]
+	require.Equal(t, tx.Hash(), txHash)
+	require.Equal(t, recipient, *tx.To())
+	require.Equal(t, big.NewInt(1_000), tx.Value())
+	require.Equal(t, big.NewInt(2*25_000_000_000+gasUtils.MaxPriorityFeePerGas), tx.GasFeeCap())
+}
@@ -0,0 +1,178 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// cborMapMajorType is the CBOR major type of maps, such as solc's metadata
+const cborMapMajorType = 5
+
+var (
+	ErrNoCode           = errors.New("no code at address")
+	ErrBytecodeMismatch = errors.New("deployed bytecode does not match artifact")
+)
+
+// ByteRange is a range of bytes in deployed bytecode, such as the location of an immutable variable
+type ByteRange struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// DeployedBytecodeArtifact is the runtime bytecode of a contract as compiled, together with the
+// ranges the constructor fills in with immutable variables.
+type DeployedBytecodeArtifact struct {
+	Bytecode            []byte
+	ImmutableReferences []ByteRange
+}
+
+// ReadDeployedBytecodeArtifact reads the deployed bytecode and its immutable references from
+// a forge build artifact.
+func ReadDeployedBytecodeArtifact(byteCodeFileName string) (*DeployedBytecodeArtifact, error) {
+	byteCodeFile, err := extractByteCode(byteCodeFileName)
+	if err != nil {
+		return nil, err
+	}
+	byteCode, err := hex.DecodeString(strings.TrimPrefix(byteCodeFile.DeployedByteCode.Object, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to decode deployed bytecode")
+	}
+	artifact := &DeployedBytecodeArtifact{Bytecode: byteCode}
+	for _, references := range byteCodeFile.DeployedByteCode.ImmutableReferences {
+		artifact.ImmutableReferences = append(artifact.ImmutableReferences, references...)
+	}
+	sort.Slice(artifact.ImmutableReferences, func(i, j int) bool {
+		return artifact.ImmutableReferences[i].Start < artifact.ImmutableReferences[j].Start
+	})
+	return artifact, nil
+}
+
+// CompareDeployedBytecode checks that [deployed] was created from [artifact]. The bytes of
+// immutable variables are ignored, as is the CBOR encoded metadata appended by solc, which
+// depends on the source file paths and comments but not on the behaviour of the contract.
+// Returns ErrNoCode or ErrBytecodeMismatch wrapped with the location of the first difference.
+// Deployed code that doesn't end with CBOR metadata is a mismatch.
+func CompareDeployedBytecode(artifact *DeployedBytecodeArtifact, deployed []byte) error {
+	if len(deployed) == 0 {
+		return ErrNoCode
+	}
+	expected, expectedMetadata, err := splitMetadata(artifact.Bytecode)
+	if err != nil {
+		return errors.Wrap(err, "invalid artifact bytecode")
+	}
+	actual, actualMetadata, err := splitMetadata(deployed)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrBytecodeMismatch, err)
+	}
+	if len(expected) != len(actual) {
+		return fmt.Errorf(
+			"%w: code length %d, expected %d (metadata length %d, expected %d)",
+			ErrBytecodeMismatch,
+			len(actual),
+			len(expected),
+			len(actualMetadata),
+			len(expectedMetadata),
+		)
+	}
+
+	masked := make([]bool, len(expected))
+	for _, reference := range artifact.ImmutableReferences {
+		if reference.Start < 0 || reference.Length < 0 || reference.Start+reference.Length > len(expected) {
+			return fmt.Errorf("immutable reference %+v out of range", reference)
+		}
+		for i := reference.Start; i < reference.Start+reference.Length; i++ {
+			masked[i] = true
+		}
+	}
+	for i := range expected {
+		if !masked[i] && expected[i] != actual[i] {
+			return fmt.Errorf("%w: first difference at byte %d", ErrBytecodeMismatch, i)
+		}
+	}
+	return nil
+}
+
+// splitMetadata splits the CBOR metadata, and the two byte length that follows it,
+// from the end of [code]. Returns an error if the length doesn't fit in [code], or if
+// the metadata isn't a CBOR map.
+func splitMetadata(code []byte) ([]byte, []byte, error) {
+	if len(code) < 2 {
+		return nil, nil, errors.Errorf("code length %d is too short for metadata", len(code))
+	}
+	metadataLength := int(binary.BigEndian.Uint16(code[len(code)-2:]))
+	if metadataLength+2 > len(code) {
+		return nil, nil, errors.Errorf("metadata length %d exceeds code length %d", metadataLength, len(code))
+	}
+	metadata := code[len(code)-2-metadataLength:]
+	// The first byte of a CBOR map has major type 5 in its high three bits
+	if metadataLength == 0 || metadata[0]>>5 != cborMapMajorType {
+		return nil, nil, errors.New("metadata is not a CBOR map")
+	}
+	return code[:len(code)-2-metadataLength], metadata, nil
+}
+
+// CodeReader reads deployed contract code
+type CodeReader interface {
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+// BytecodeVerification is the result of verifying the code at an address on one chain
+type BytecodeVerification struct {
+	Chain   string         `json:"chain"`
+	Address common.Address `json:"address"`
+	Match   bool           `json:"match"`
+	// MetadataMatch reports whether the CBOR metadata is also identical to the artifact's,
+	// meaning the contract was compiled from identical sources.
+	MetadataMatch bool   `json:"metadataMatch"`
+	Error         string `json:"error,omitempty"`
+}
+
+// VerifyDeployedBytecode compares the code at [address] on each chain in [clients], keyed by
+// chain name, to [artifact], and returns the results in chain name order.
+func VerifyDeployedBytecode(
+	ctx context.Context,
+	artifact *DeployedBytecodeArtifact,
+	address common.Address,
+	clients map[string]CodeReader,
+) []BytecodeVerification {
+	chains := make([]string, 0, len(clients))
+	for chain := range clients {
+		chains = append(chains, chain)
+	}
+	sort.Strings(chains)
+
+	_, expectedMetadata, _ := splitMetadata(artifact.Bytecode)
+	results := make([]BytecodeVerification, 0, len(chains))
+	for _, chain := range chains {
+		result := BytecodeVerification{
+			Chain:   chain,
+			Address: address,
+		}
+		code, err := clients[chain].CodeAt(ctx, address, nil)
+		if err == nil {
+			err = CompareDeployedBytecode(artifact, code)
+		}
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			// The code was compared, so its metadata is valid
+			_, metadata, _ := splitMetadata(code)
+			result.Match = true
+			result.MetadataMatch = bytes.Equal(metadata, expectedMetadata)
+		}
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,143 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// withMetadata appends [metadata] and its length to [code], as solc does
+func withMetadata(code []byte, metadata []byte) []byte {
+	result := append(append([]byte{}, code...), metadata...)
+	return append(result, byte(len(metadata)>>8), byte(len(metadata)))
+}
+
+func TestCompareDeployedBytecode(t *testing.T) {
+	code := []byte{0x60, 0x80, 0x60, 0x40, 0x7f, 0, 0, 0, 0, 0x52}
+	metadata := []byte{0xa2, 0x64, 0x69, 0x70, 0x66, 0x73}
+	artifact := &DeployedBytecodeArtifact{
+		Bytecode:            withMetadata(code, metadata),
+		ImmutableReferences: []ByteRange{{Start: 5, Length: 4}},
+	}
+	withImmutable := append([]byte{}, code...)
+	copy(withImmutable[5:9], []byte{1, 2, 3, 4})
+	modified := append([]byte{}, code...)
+	modified[9] = 0x53
+
+	tests := []struct {
+		name        string
+		deployed    []byte
+		expectedErr error
+	}{
+		{
+			name:     "identical",
+			deployed: withMetadata(code, metadata),
+		},
+		{
+			name:     "immutable set",
+			deployed: withMetadata(withImmutable, metadata),
+		},
+		{
+			name:     "other metadata",
+			deployed: withMetadata(code, []byte{0xa2, 0x64, 0x69, 0x70, 0x66, 0x74, 0x00}),
+		},
+		{
+			name:        "modified code",
+			deployed:    withMetadata(modified, metadata),
+			expectedErr: ErrBytecodeMismatch,
+		},
+		{
+			name:        "other length",
+			deployed:    withMetadata(code[:9], metadata),
+			expectedErr: ErrBytecodeMismatch,
+		},
+		{
+			name:        "metadata length exceeds code",
+			deployed:    append(append([]byte{}, code...), 0xff, 0xff),
+			expectedErr: ErrBytecodeMismatch,
+		},
+		{
+			name:        "metadata not a CBOR map",
+			deployed:    withMetadata(code, []byte{0x60, 0x64, 0x69, 0x70, 0x66, 0x73}),
+			expectedErr: ErrBytecodeMismatch,
+		},
+		{
+			name:        "no metadata",
+			deployed:    append(append([]byte{}, code...), 0, 0),
+			expectedErr: ErrBytecodeMismatch,
+		},
+		{
+			name:        "no code",
+			deployed:    nil,
+			expectedErr: ErrNoCode,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CompareDeployedBytecode(artifact, test.deployed)
+			if test.expectedErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, test.expectedErr)
+			}
+		})
+	}
+}
+
+type mockCodeReader struct {
+	code []byte
+	err  error
+}
+
+func (m *mockCodeReader) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return m.code, m.err
+}
+
+func TestVerifyDeployedBytecode(t *testing.T) {
+	code := []byte{0x60, 0x80, 0x60, 0x40}
+	metadata := []byte{0xa2, 0x64}
+	artifact := &DeployedBytecodeArtifact{Bytecode: withMetadata(code, metadata)}
+	address := common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf")
+
+	results := VerifyDeployedBytecode(context.Background(), artifact, address, map[string]CodeReader{
+		"c": &mockCodeReader{err: errors.New("connection refused")},
+		"b": &mockCodeReader{code: withMetadata(code, []byte{0xa2, 0x65})},
+		"a": &mockCodeReader{code: withMetadata(code, metadata)},
+		"d": &mockCodeReader{},
+	})
+	require.Equal(t, []BytecodeVerification{
+		{Chain: "a", Address: address, Match: true, MetadataMatch: true},
+		{Chain: "b", Address: address, Match: true, MetadataMatch: false},
+		{Chain: "c", Address: address, Error: "connection refused"},
+		{Chain: "d", Address: address, Error: ErrNoCode.Error()},
+	}, results)
+}
+
+func TestReadDeployedBytecodeArtifact(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "Contract.json")
+	err := os.WriteFile(fileName, []byte(`{
+		"bytecode": {"object": "0x6080"},
+		"deployedBytecode": {
+			"object": "0x60806040",
+			"immutableReferences": {
+				"12": [{"start": 2, "length": 1}],
+				"7": [{"start": 0, "length": 1}, {"start": 3, "length": 1}]
+			}
+		}
+	}`), 0o600)
+	require.NoError(t, err)
+
+	artifact, err := ReadDeployedBytecodeArtifact(fileName)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x60, 0x80, 0x60, 0x40}, artifact.Bytecode)
+	require.Equal(t, []ByteRange{{0, 1}, {2, 1}, {3, 1}}, artifact.ImmutableReferences)
+}
@@ -0,0 +1,33 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"math"
+	"runtime"
+)
+
+// MaxUnpackAllocation bounds the memory that unpacking a small, adversarial input may allocate
+const MaxUnpackAllocation = 1 << 20
+
+// allocationRuns is the number of times a function is run to measure its allocations
+const allocationRuns = 3
+
+// AllocatedBytes returns the number of heap bytes allocated while running [f], which must allocate
+// the same amount each time it is run. The heap statistics are global, so [f] is run several times
+// on a single processor, and the smallest measurement is returned, since allocations by other
+// goroutines can only add to it.
+func AllocatedBytes(f func()) uint64 {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+
+	allocated := uint64(math.MaxUint64)
+	for i := 0; i < allocationRuns; i++ {
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		f()
+		runtime.ReadMemStats(&after)
+		allocated = min(allocated, after.TotalAlloc-before.TotalAlloc)
+	}
+	return allocated
+}
@@ -0,0 +1,295 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// DefaultGasLimitHeadroomPercent is the headroom added to the simulated gas usage of a receiver
+// if none is configured.
+const DefaultGasLimitHeadroomPercent uint64 = 20
+
+// ReceiverKind identifies the interface through which a cross-chain message is delivered to
+// its destination contract.
+type ReceiverKind int
+
+const (
+	// TeleporterReceiver receives messages from the TeleporterMessenger through
+	// ITeleporterReceiver.receiveTeleporterMessage, executed with TeleporterMessageInput.requiredGasLimit.
+	TeleporterReceiver ReceiverKind = iota
+	// ERC20SendAndCallReceiver receives tokens from an ERC20 token transferrer through
+	// IERC20SendAndCallReceiver.receiveTokens, executed with SendAndCallInput.recipientGasLimit.
+	ERC20SendAndCallReceiver
+	// NativeSendAndCallReceiver receives tokens from a native token transferrer through
+	// INativeSendAndCallReceiver.receiveTokens, executed with SendAndCallInput.recipientGasLimit.
+	NativeSendAndCallReceiver
+)
+
+func (k ReceiverKind) String() string {
+	switch k {
+	case TeleporterReceiver:
+		return "teleporter"
+	case ERC20SendAndCallReceiver:
+		return "erc20"
+	case NativeSendAndCallReceiver:
+		return "native"
+	default:
+		return fmt.Sprintf("ReceiverKind(%d)", int(k))
+	}
+}
+
+// ParseReceiverKind is the inverse of ReceiverKind.String
+func ParseReceiverKind(s string) (ReceiverKind, error) {
+	for _, kind := range []ReceiverKind{TeleporterReceiver, ERC20SendAndCallReceiver, NativeSendAndCallReceiver} {
+		if kind.String() == s {
+			return kind, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown receiver kind %q", s)
+}
+
+var (
+	receiveTeleporterMessageMethod abi.Method
+	erc20ReceiveTokensMethod       abi.Method
+	nativeReceiveTokensMethod      abi.Method
+)
+
+func init() {
+	newArguments := func(args ...abi.ArgumentMarshaling) abi.Arguments {
+		arguments := make(abi.Arguments, len(args))
+		for i, arg := range args {
+			t, err := abi.NewType(arg.Type, "", nil)
+			if err != nil {
+				panic(fmt.Sprintf("failed to create ABI type %s: %v", arg.Type, err))
+			}
+			arguments[i] = abi.Argument{Name: arg.Name, Type: t}
+		}
+		return arguments
+	}
+	// Keep in sync with ITeleporterReceiver.sol, IERC20SendAndCallReceiver.sol and
+	// INativeSendAndCallReceiver.sol. The contracts only declare these functions in
+	// interfaces, which have no generated bindings.
+	receiveTeleporterMessageMethod = abi.NewMethod(
+		"receiveTeleporterMessage", "receiveTeleporterMessage", abi.Function, "nonpayable", false, false,
+		newArguments(
+			abi.ArgumentMarshaling{Name: "sourceBlockchainID", Type: "bytes32"},
+			abi.ArgumentMarshaling{Name: "originSenderAddress", Type: "address"},
+			abi.ArgumentMarshaling{Name: "message", Type: "bytes"},
+		),
+		nil,
+	)
+	erc20ReceiveTokensMethod = abi.NewMethod(
+		"receiveTokens", "receiveTokens", abi.Function, "nonpayable", false, false,
+		newArguments(
+			abi.ArgumentMarshaling{Name: "sourceBlockchainID", Type: "bytes32"},
+			abi.ArgumentMarshaling{Name: "originTokenTransferrerAddress", Type: "address"},
+			abi.ArgumentMarshaling{Name: "originSenderAddress", Type: "address"},
+			abi.ArgumentMarshaling{Name: "token", Type: "address"},
+			abi.ArgumentMarshaling{Name: "amount", Type: "uint256"},
+			abi.ArgumentMarshaling{Name: "payload", Type: "bytes"},
+		),
+		nil,
+	)
+	nativeReceiveTokensMethod = abi.NewMethod(
+		"receiveTokens", "receiveTokens", abi.Function, "payable", false, true,
+		newArguments(
+			abi.ArgumentMarshaling{Name: "sourceBlockchainID", Type: "bytes32"},
+			abi.ArgumentMarshaling{Name: "originTokenTransferrerAddress", Type: "address"},
+			abi.ArgumentMarshaling{Name: "originSenderAddress", Type: "address"},
+			abi.ArgumentMarshaling{Name: "payload", Type: "bytes"},
+		),
+		nil,
+	)
+}
+
+// ReceiverCall describes the call made to a destination contract when a message is delivered
+type ReceiverCall struct {
+	Kind ReceiverKind
+	// Caller is the contract that calls the receiver on the destination chain: the
+	// TeleporterMessenger for TeleporterReceiver, and the token transferrer otherwise.
+	// ERC20 token transferrers approve the receiver to spend Amount before calling it, which the
+	// simulation does not replicate, so receivers that pull tokens should be simulated with a Caller
+	// that has already granted the allowance.
+	Caller   common.Address
+	Receiver common.Address
+
+	SourceBlockchainID  ids.ID
+	OriginSenderAddress common.Address
+	// Payload is the Teleporter message payload, or the recipient payload of a send and call.
+	Payload []byte
+
+	// Only used by send and call receivers
+	OriginTokenTransferrerAddress common.Address
+	// Only used by ERC20SendAndCallReceiver
+	Token common.Address
+	// The amount of tokens transferred. Sent as the call value for NativeSendAndCallReceiver,
+	// in which case Caller must hold it.
+	Amount *big.Int
+
+	// EnclosingGasLimit optionally sets the gas available to the frame that calls the receiver,
+	// such as the requiredGasLimit of the Teleporter message carrying a send and call, so that
+	// it can be checked against the 63/64 rule. Zero skips the check.
+	EnclosingGasLimit uint64
+}
+
+// RequiredGasLimitAdvice is the outcome of simulating a receiver call
+type RequiredGasLimitAdvice struct {
+	// SimulatedGas is the gas used by the receiver call, excluding the intrinsic transaction cost.
+	SimulatedGas uint64
+	// RecommendedGasLimit is SimulatedGas plus the configured headroom. It is the value to use
+	// as requiredGasLimit or recipientGasLimit.
+	RecommendedGasLimit uint64
+	// MinimumCallerGas is the gas that must remain in the caller when it calls the receiver for
+	// the receiver to be given RecommendedGasLimit.
+	//
+	// CallUtils only requires gasleft() >= gasAmount before making the call, but the EVM forwards
+	// at most 63/64 of the remaining gas, so with less than MinimumCallerGas left the receiver is
+	// executed with less than RecommendedGasLimit and may run out of gas.
+	MinimumCallerGas uint64
+	// Starved is set if ReceiverCall.EnclosingGasLimit is below MinimumCallerGas.
+	Starved bool
+}
+
+// RequiredGasLimitAdvisor recommends gas limits for cross-chain message receivers by simulating
+// the receiver call on the destination chain's current state via eth_estimateGas.
+type RequiredGasLimitAdvisor struct {
+	client          interfaces.GasEstimator
+	headroomPercent uint64
+}
+
+// NewRequiredGasLimitAdvisor returns an advisor that adds [headroomPercent] percent to the
+// simulated gas usage. DefaultGasLimitHeadroomPercent is used if [headroomPercent] is zero.
+func NewRequiredGasLimitAdvisor(client interfaces.GasEstimator, headroomPercent uint64) *RequiredGasLimitAdvisor {
+	if headroomPercent == 0 {
+		headroomPercent = DefaultGasLimitHeadroomPercent
+	}
+	return &RequiredGasLimitAdvisor{
+		client:          client,
+		headroomPercent: headroomPercent,
+	}
+}
+
+// AdviseRequiredGasLimit simulates [call] and recommends the gas limit to execute it with
+func (a *RequiredGasLimitAdvisor) AdviseRequiredGasLimit(
+	ctx context.Context,
+	call ReceiverCall,
+) (*RequiredGasLimitAdvice, error) {
+	callData, value, err := packReceiverCall(call)
+	if err != nil {
+		return nil, err
+	}
+	gas, err := a.client.EstimateGas(ctx, interfaces.CallMsg{
+		From:  call.Caller,
+		To:    &call.Receiver,
+		Value: value,
+		Data:  callData,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to simulate %s receiver call", call.Kind)
+	}
+	intrinsicGas := calldataIntrinsicGas(callData)
+	if gas < intrinsicGas {
+		return nil, fmt.Errorf("simulated gas %d is less than the intrinsic gas %d", gas, intrinsicGas)
+	}
+
+	advice := &RequiredGasLimitAdvice{
+		SimulatedGas: gas - intrinsicGas,
+	}
+	advice.RecommendedGasLimit = advice.SimulatedGas + advice.SimulatedGas*a.headroomPercent/100
+	advice.MinimumCallerGas = MinimumCallerGas(advice.RecommendedGasLimit, value != nil && value.Sign() > 0)
+	advice.Starved = call.EnclosingGasLimit != 0 && call.EnclosingGasLimit < advice.MinimumCallerGas
+	return advice, nil
+}
+
+// MinimumCallerGas returns the gas that must be left before CallUtils calls a contract with
+// [gasAmount] for the callee to receive all of it, accounting for the cost of the CALL
+// itself and the 63/64 rule.
+func MinimumCallerGas(gasAmount uint64, transfersValue bool) uint64 {
+	// CallUtils checks the code length of the target before calling it, so the target is warm.
+	callCost := params.WarmStorageReadCostEIP2929
+	if transfersValue {
+		callCost += params.CallValueTransferGas
+	}
+	// The callee is given available - available/64 of the gas available after paying for the call.
+	// Writing available as 64q + r, the callee is given 63q + r, so the smallest sufficient
+	// amount is found from the quotient and remainder of gasAmount by 63.
+	q, r := gasAmount/63, gasAmount%63
+	available := 64*q + r
+	if r == 0 && q > 0 {
+		available = 64*q - 1
+	}
+	return available + callCost
+}
+
+func packReceiverCall(call ReceiverCall) ([]byte, *big.Int, error) {
+	var (
+		method abi.Method
+		args   []interface{}
+		value  *big.Int
+	)
+	switch call.Kind {
+	case TeleporterReceiver:
+		method = receiveTeleporterMessageMethod
+		args = []interface{}{[32]byte(call.SourceBlockchainID), call.OriginSenderAddress, call.Payload}
+	case ERC20SendAndCallReceiver:
+		if call.Amount == nil {
+			return nil, nil, errors.New("amount is required for send and call receivers")
+		}
+		method = erc20ReceiveTokensMethod
+		args = []interface{}{
+			[32]byte(call.SourceBlockchainID),
+			call.OriginTokenTransferrerAddress,
+			call.OriginSenderAddress,
+			call.Token,
+			call.Amount,
+			call.Payload,
+		}
+	case NativeSendAndCallReceiver:
+		if call.Amount == nil {
+			return nil, nil, errors.New("amount is required for send and call receivers")
+		}
+		method = nativeReceiveTokensMethod
+		args = []interface{}{
+			[32]byte(call.SourceBlockchainID),
+			call.OriginTokenTransferrerAddress,
+			call.OriginSenderAddress,
+			call.Payload,
+		}
+		value = call.Amount
+	default:
+		return nil, nil, fmt.Errorf("unknown receiver kind %d", int(call.Kind))
+	}
+	if call.Payload == nil {
+		args[len(args)-1] = []byte{}
+	}
+
+	packedArgs, err := method.Inputs.Pack(args...)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to pack %s", method.Sig)
+	}
+	return append(append([]byte{}, method.ID...), packedArgs...), value, nil
+}
+
+// calldataIntrinsicGas is the intrinsic gas of a call transaction without an access list
+func calldataIntrinsicGas(data []byte) uint64 {
+	gas := params.TxGas
+	for _, b := range data {
+		if b == 0 {
+			gas += params.TxDataZeroGas
+		} else {
+			gas += params.TxDataNonZeroGasEIP2028
+		}
+	}
+	return gas
+}
@@ -0,0 +1,173 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	mockerc20sendandcallreceiver "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/mocks/MockERC20SendAndCallReceiver"
+	mocknativesendandcallreceiver "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/mocks/MockNativeSendAndCallReceiver"
+	testmessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/tests/TestMessenger"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// mockReceiverGasEstimator charges a fixed amount of gas for executing the receiver,
+// in addition to the intrinsic gas of the call.
+type mockReceiverGasEstimator struct {
+	executionGas uint64
+	calls        []interfaces.CallMsg
+}
+
+func (m *mockReceiverGasEstimator) EstimateGas(_ context.Context, call interfaces.CallMsg) (uint64, error) {
+	m.calls = append(m.calls, call)
+	return calldataIntrinsicGas(call.Data) + m.executionGas, nil
+}
+
+func TestAdviseRequiredGasLimit(t *testing.T) {
+	sourceBlockchainID := ids.ID{1, 2, 3}
+	caller := common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf")
+	receiver := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	originSender := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	originTokenTransferrer := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	token := common.HexToAddress("0x4444444444444444444444444444444444444444")
+	amount := big.NewInt(1000)
+	payload := []byte{1, 2, 3, 4}
+
+	mustPack := func(metaData *bind.MetaData, args ...interface{}) []byte {
+		parsed, err := metaData.GetAbi()
+		require.NoError(t, err)
+		for _, name := range []string{"receiveTeleporterMessage", "receiveTokens"} {
+			if _, ok := parsed.Methods[name]; ok {
+				data, err := parsed.Pack(name, args...)
+				require.NoError(t, err)
+				return data
+			}
+		}
+		require.FailNow(t, "receiver method not found")
+		return nil
+	}
+
+	tests := []struct {
+		name              string
+		call              ReceiverCall
+		expectedData      []byte
+		expectedValue     *big.Int
+		expectedCallerGas uint64
+		starved           bool
+	}{
+		{
+			name: "teleporter receiver",
+			call: ReceiverCall{
+				Kind:                TeleporterReceiver,
+				Caller:              caller,
+				Receiver:            receiver,
+				SourceBlockchainID:  sourceBlockchainID,
+				OriginSenderAddress: originSender,
+				Payload:             payload,
+			},
+			expectedData: mustPack(
+				testmessenger.TestMessengerMetaData,
+				[32]byte(sourceBlockchainID),
+				originSender,
+				payload,
+			),
+			expectedCallerGas: 121_904 + 100,
+		},
+		{
+			name: "erc20 send and call receiver",
+			call: ReceiverCall{
+				Kind:                          ERC20SendAndCallReceiver,
+				Caller:                        caller,
+				Receiver:                      receiver,
+				SourceBlockchainID:            sourceBlockchainID,
+				OriginSenderAddress:           originSender,
+				Payload:                       payload,
+				OriginTokenTransferrerAddress: originTokenTransferrer,
+				Token:                         token,
+				Amount:                        amount,
+				EnclosingGasLimit:             121_904 + 100,
+			},
+			expectedData: mustPack(
+				mockerc20sendandcallreceiver.MockERC20SendAndCallReceiverMetaData,
+				[32]byte(sourceBlockchainID),
+				originTokenTransferrer,
+				originSender,
+				token,
+				amount,
+				payload,
+			),
+			expectedCallerGas: 121_904 + 100,
+		},
+		{
+			name: "native send and call receiver",
+			call: ReceiverCall{
+				Kind:                          NativeSendAndCallReceiver,
+				Caller:                        caller,
+				Receiver:                      receiver,
+				SourceBlockchainID:            sourceBlockchainID,
+				OriginSenderAddress:           originSender,
+				OriginTokenTransferrerAddress: originTokenTransferrer,
+				Amount:                        amount,
+				// Enough for the recommended gas limit, but not for the 63/64 rule.
+				EnclosingGasLimit: 120_000,
+			},
+			expectedData: mustPack(
+				mocknativesendandcallreceiver.MockNativeSendAndCallReceiverMetaData,
+				[32]byte(sourceBlockchainID),
+				originTokenTransferrer,
+				originSender,
+				[]byte{},
+			),
+			expectedValue:     amount,
+			expectedCallerGas: 121_904 + 100 + 9000,
+			starved:           true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			estimator := &mockReceiverGasEstimator{executionGas: 100_000}
+			advisor := NewRequiredGasLimitAdvisor(estimator, 20)
+
+			advice, err := advisor.AdviseRequiredGasLimit(context.Background(), test.call)
+			require.NoError(t, err)
+			require.Equal(t, uint64(100_000), advice.SimulatedGas)
+			require.Equal(t, uint64(120_000), advice.RecommendedGasLimit)
+			require.Equal(t, test.expectedCallerGas, advice.MinimumCallerGas)
+			require.Equal(t, test.starved, advice.Starved)
+
+			require.Len(t, estimator.calls, 1)
+			require.Equal(t, test.call.Caller, estimator.calls[0].From)
+			require.Equal(t, test.call.Receiver, *estimator.calls[0].To)
+			require.Equal(t, test.expectedData, estimator.calls[0].Data)
+			require.Equal(t, test.expectedValue, estimator.calls[0].Value)
+		})
+	}
+}
+
+func TestAdviseRequiredGasLimitMissingAmount(t *testing.T) {
+	advisor := NewRequiredGasLimitAdvisor(&mockReceiverGasEstimator{}, 0)
+	_, err := advisor.AdviseRequiredGasLimit(context.Background(), ReceiverCall{Kind: ERC20SendAndCallReceiver})
+	require.ErrorContains(t, err, "amount is required")
+}
+
+func TestMinimumCallerGas(t *testing.T) {
+	for _, gasAmount := range []uint64{0, 1, 63, 64, 100_000, 630_000, 8_000_000} {
+		callerGas := MinimumCallerGas(gasAmount, false)
+		// The callee receives all but one 64th of the gas left after paying for the call.
+		available := callerGas - 100
+		require.GreaterOrEqual(t, available-available/64, gasAmount)
+		if gasAmount > 0 {
+			available--
+			require.Less(t, available-available/64, gasAmount)
+		}
+		require.Equal(t, callerGas+9000, MinimumCallerGas(gasAmount, true))
+	}
+}
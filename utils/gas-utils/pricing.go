@@ -0,0 +1,333 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultFeeHistoryBlocks uint64 = 20
+	// Minimum percentage by which both fee fields must be raised for the
+	// mempool to accept a replacement transaction with the same nonce.
+	defaultReplacementBumpPercent = 10
+	// JSON-RPC error code returned for methods the node does not serve
+	methodNotFoundErrorCode = -32601
+)
+
+var (
+	ErrFeeCapExceeded = errors.New("transaction fee exceeds the configured cap")
+	// ErrFeeConfigUnavailable is returned by FeeConfig on chains without a dynamic fee
+	// configuration, such as the C-Chain.
+	ErrFeeConfigUnavailable = errors.New("fee config is not available")
+)
+
+// PricingStrategy trades off transaction cost against inclusion latency
+type PricingStrategy int
+
+const (
+	// Conservative pays a low tip and tolerates little base fee growth before the transaction stalls.
+	Conservative PricingStrategy = iota
+	// Normal allows the base fee to grow by BaseFeeFactor, as ICM tooling has historically done.
+	Normal
+	// Fast pays a high tip and tolerates a large base fee spike.
+	Fast
+)
+
+func (s PricingStrategy) String() string {
+	switch s {
+	case Conservative:
+		return "conservative"
+	case Normal:
+		return "normal"
+	case Fast:
+		return "fast"
+	default:
+		return fmt.Sprintf("PricingStrategy(%d)", int(s))
+	}
+}
+
+// tipPercentile is the percentile of recent priority fees paid under the strategy
+func (s PricingStrategy) tipPercentile() float64 {
+	switch s {
+	case Conservative:
+		return 25
+	case Fast:
+		return 90
+	default:
+		return 50
+	}
+}
+
+// baseFeeMultiplierPercent is the headroom over the current base fee allowed by the fee cap
+func (s PricingStrategy) baseFeeMultiplierPercent() int64 {
+	switch s {
+	case Conservative:
+		return 125
+	case Fast:
+		return 300
+	default:
+		return BaseFeeFactor * 100
+	}
+}
+
+// FeeMarketClient is the subset of chain RPC methods used to price transactions
+type FeeMarketClient interface {
+	interfaces.FeeHistoryReader
+	EstimateBaseFee(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	// FeeConfig returns the chain's current dynamic fee configuration, or
+	// ErrFeeConfigUnavailable if the chain does not have one.
+	FeeConfig(ctx context.Context) (*commontype.FeeConfig, error)
+}
+
+type feeMarketClient struct {
+	ethclient.Client
+}
+
+// NewFeeMarketClient adapts an ethclient.Client to a FeeMarketClient, reading the
+// dynamic fee configuration with subnet-evm's eth_feeConfig method.
+func NewFeeMarketClient(client ethclient.Client) FeeMarketClient {
+	return &feeMarketClient{Client: client}
+}
+
+func (c *feeMarketClient) FeeConfig(ctx context.Context) (*commontype.FeeConfig, error) {
+	var result struct {
+		FeeConfig commontype.FeeConfig `json:"feeConfig"`
+	}
+	if err := c.Client.Client().CallContext(ctx, &result, "eth_feeConfig", nil); err != nil {
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundErrorCode {
+			return nil, fmt.Errorf("%w: %w", ErrFeeConfigUnavailable, err)
+		}
+		return nil, errors.Wrap(err, "failed to get fee config")
+	}
+	return &result.FeeConfig, nil
+}
+
+// TxPricerConfig configures a TxPricer. The zero value is valid and selects the Normal
+// strategy without a spending cap.
+type TxPricerConfig struct {
+	Strategy PricingStrategy
+	// MaxFeePerTx caps the maximum fee of a single transaction, gasLimit * gasFeeCap, in wei.
+	// No cap is applied if nil.
+	MaxFeePerTx *big.Int
+	// FeeHistoryBlocks is the number of recent blocks whose priority fees are sampled.
+	// Defaults to 20.
+	FeeHistoryBlocks uint64
+	// ReplacementBumpPercent is the minimum increase of both fee fields when re-pricing
+	// a transaction. Defaults to 10, the minimum accepted by the mempool.
+	ReplacementBumpPercent int64
+}
+
+// TxPrice holds the EIP-1559 fee fields of a dynamic fee transaction
+type TxPrice struct {
+	GasFeeCap *big.Int
+	GasTipCap *big.Int
+}
+
+// EffectiveGasPrice returns the price per unit of gas paid by a transaction with these fee
+// fields if it is included in a block with [baseFee]
+func (p *TxPrice) EffectiveGasPrice(baseFee *big.Int) *big.Int {
+	price := new(big.Int).Add(baseFee, p.GasTipCap)
+	if price.Cmp(p.GasFeeCap) > 0 {
+		price.Set(p.GasFeeCap)
+	}
+	return price
+}
+
+// TxPricer prices ICM transactions, such as Teleporter message deliveries and validator
+// manager calls, from the chain's dynamic fee configuration and recent fee history.
+type TxPricer struct {
+	client FeeMarketClient
+	config TxPricerConfig
+}
+
+func NewTxPricer(client FeeMarketClient, config TxPricerConfig) *TxPricer {
+	if config.FeeHistoryBlocks == 0 {
+		config.FeeHistoryBlocks = defaultFeeHistoryBlocks
+	}
+	if config.ReplacementBumpPercent == 0 {
+		config.ReplacementBumpPercent = defaultReplacementBumpPercent
+	}
+	return &TxPricer{
+		client: client,
+		config: config,
+	}
+}
+
+// Price returns fee fields for a new transaction with the given gas limit.
+// The fee cap leaves the strategy's headroom over the estimated base fee, which is never
+// assumed to be below the chain's minimum base fee, and the tip is taken from recent blocks.
+// On chains without a fee configuration, the fee cap instead leaves MaxPriorityFeePerGas over
+// the headroom and the tip is the node's suggestion.
+// Returns ErrFeeCapExceeded if the transaction cannot be priced above the current base
+// fee within MaxFeePerTx.
+func (p *TxPricer) Price(ctx context.Context, gasLimit uint64) (*TxPrice, error) {
+	baseFee, hasFeeConfig, err := p.baseFee(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gasFeeCap := new(big.Int).Mul(baseFee, big.NewInt(p.config.Strategy.baseFeeMultiplierPercent()))
+	gasFeeCap.Div(gasFeeCap, big.NewInt(100))
+
+	var gasTipCap *big.Int
+	if hasFeeConfig {
+		gasTipCap, err = p.gasTipCap(ctx)
+		if err != nil {
+			return nil, err
+		}
+		gasFeeCap.Add(gasFeeCap, gasTipCap)
+	} else {
+		gasTipCap, err = p.client.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to suggest gas tip cap")
+		}
+		gasFeeCap.Add(gasFeeCap, big.NewInt(MaxPriorityFeePerGas))
+	}
+	return p.applyCap(&TxPrice{GasFeeCap: gasFeeCap, GasTipCap: gasTipCap}, baseFee, gasLimit)
+}
+
+// Reprice returns fee fields for a transaction replacing [previous], which has not been
+// accepted. Both fields are raised by at least ReplacementBumpPercent so that the mempool
+// accepts the replacement, and to at least the current price under the strategy.
+// Returns ErrFeeCapExceeded if the bumped price exceeds MaxFeePerTx.
+func (p *TxPricer) Reprice(ctx context.Context, previous *TxPrice, gasLimit uint64) (*TxPrice, error) {
+	current, err := p.Price(ctx, gasLimit)
+	if err != nil && !errors.Is(err, ErrFeeCapExceeded) {
+		return nil, err
+	}
+	baseFee, _, err := p.baseFee(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	price := &TxPrice{
+		GasFeeCap: bumpPrice(previous.GasFeeCap, p.config.ReplacementBumpPercent),
+		GasTipCap: bumpPrice(previous.GasTipCap, p.config.ReplacementBumpPercent),
+	}
+	if current != nil {
+		price.GasFeeCap = bigMax(price.GasFeeCap, current.GasFeeCap)
+		price.GasTipCap = bigMax(price.GasTipCap, current.GasTipCap)
+	}
+	// The fee cap must be at least the tip for the transaction to be valid.
+	price.GasFeeCap = bigMax(price.GasFeeCap, price.GasTipCap)
+	if err := p.checkCap(price.GasFeeCap, gasLimit); err != nil {
+		return nil, err
+	}
+	if price.GasFeeCap.Cmp(baseFee) < 0 {
+		return nil, fmt.Errorf("replacement fee cap %s is below the current base fee %s", price.GasFeeCap, baseFee)
+	}
+	return price, nil
+}
+
+// baseFee returns the estimated base fee, raised to the chain's minimum base fee, and
+// whether the chain has a dynamic fee configuration.
+func (p *TxPricer) baseFee(ctx context.Context) (*big.Int, bool, error) {
+	baseFee, err := p.client.EstimateBaseFee(ctx)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to estimate base fee")
+	}
+	feeConfig, err := p.client.FeeConfig(ctx)
+	if errors.Is(err, ErrFeeConfigUnavailable) {
+		return baseFee, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if feeConfig.MinBaseFee != nil {
+		baseFee = bigMax(baseFee, feeConfig.MinBaseFee)
+	}
+	return baseFee, true, nil
+}
+
+// gasTipCap returns the median over recent blocks of the strategy's percentile of paid
+// priority fees. Falls back to the node's suggestion if no fees were paid recently.
+func (p *TxPricer) gasTipCap(ctx context.Context) (*big.Int, error) {
+	history, err := p.client.FeeHistory(
+		ctx,
+		p.config.FeeHistoryBlocks,
+		nil,
+		[]float64{p.config.Strategy.tipPercentile()},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get fee history")
+	}
+	var tips []*big.Int
+	for _, rewards := range history.Reward {
+		if len(rewards) > 0 && rewards[0] != nil && rewards[0].Sign() > 0 {
+			tips = append(tips, rewards[0])
+		}
+	}
+	if len(tips) == 0 {
+		tip, err := p.client.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to suggest gas tip cap")
+		}
+		return tip, nil
+	}
+	slices.SortFunc(tips, func(a, b *big.Int) int { return a.Cmp(b) })
+	return new(big.Int).Set(tips[len(tips)/2]), nil
+}
+
+// applyCap lowers the fee cap of [price] to fit MaxFeePerTx, as long as it stays above [baseFee]
+func (p *TxPricer) applyCap(price *TxPrice, baseFee *big.Int, gasLimit uint64) (*TxPrice, error) {
+	if p.config.MaxFeePerTx == nil || p.checkCap(price.GasFeeCap, gasLimit) == nil {
+		return price, nil
+	}
+	maxGasFeeCap := new(big.Int).Div(p.config.MaxFeePerTx, new(big.Int).SetUint64(gasLimit))
+	if maxGasFeeCap.Cmp(baseFee) < 0 {
+		return nil, fmt.Errorf(
+			"%w: base fee %s requires more than %s wei for %d gas",
+			ErrFeeCapExceeded,
+			baseFee,
+			p.config.MaxFeePerTx,
+			gasLimit,
+		)
+	}
+	price.GasFeeCap = maxGasFeeCap
+	if price.GasTipCap.Cmp(maxGasFeeCap) > 0 {
+		price.GasTipCap = new(big.Int).Set(maxGasFeeCap)
+	}
+	return price, nil
+}
+
+func (p *TxPricer) checkCap(gasFeeCap *big.Int, gasLimit uint64) error {
+	if p.config.MaxFeePerTx == nil {
+		return nil
+	}
+	maxFee := new(big.Int).Mul(gasFeeCap, new(big.Int).SetUint64(gasLimit))
+	if maxFee.Cmp(p.config.MaxFeePerTx) > 0 {
+		return fmt.Errorf("%w: %s wei exceeds %s wei", ErrFeeCapExceeded, maxFee, p.config.MaxFeePerTx)
+	}
+	return nil
+}
+
+// bumpPrice raises [price] by [percent], rounding up, and by at least 1 wei
+func bumpPrice(price *big.Int, percent int64) *big.Int {
+	bumped := new(big.Int).Mul(price, big.NewInt(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	bumped.Div(bumped, big.NewInt(100))
+	if bumped.Cmp(price) <= 0 {
+		bumped.Add(price, common.Big1)
+	}
+	return bumped
+}
+
+func bigMax(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return new(big.Int).Set(a)
+	}
+	return new(big.Int).Set(b)
+}
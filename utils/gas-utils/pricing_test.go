@@ -0,0 +1,206 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFeeMarketClient struct {
+	baseFee      *big.Int
+	minBaseFee   *big.Int
+	rewards      []int64
+	suggestedTip *big.Int
+	feeConfigErr error
+}
+
+func (m *mockFeeMarketClient) FeeHistory(
+	_ context.Context,
+	blockCount uint64,
+	_ *big.Int,
+	rewardPercentiles []float64,
+) (*interfaces.FeeHistory, error) {
+	history := &interfaces.FeeHistory{}
+	for _, reward := range m.rewards {
+		history.Reward = append(history.Reward, []*big.Int{big.NewInt(reward)})
+	}
+	return history, nil
+}
+
+func (m *mockFeeMarketClient) EstimateBaseFee(context.Context) (*big.Int, error) {
+	return new(big.Int).Set(m.baseFee), nil
+}
+
+func (m *mockFeeMarketClient) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return new(big.Int).Set(m.suggestedTip), nil
+}
+
+func (m *mockFeeMarketClient) FeeConfig(context.Context) (*commontype.FeeConfig, error) {
+	if m.feeConfigErr != nil {
+		return nil, m.feeConfigErr
+	}
+	return &commontype.FeeConfig{MinBaseFee: m.minBaseFee}, nil
+}
+
+func TestTxPricerPrice(t *testing.T) {
+	tests := []struct {
+		name              string
+		client            *mockFeeMarketClient
+		config            TxPricerConfig
+		gasLimit          uint64
+		expectedGasFeeCap int64
+		expectedGasTipCap int64
+		expectedErr       error
+	}{
+		{
+			name: "normal",
+			client: &mockFeeMarketClient{
+				baseFee: big.NewInt(100), minBaseFee: big.NewInt(1), rewards: []int64{3, 1, 2},
+			},
+			config:            TxPricerConfig{Strategy: Normal},
+			expectedGasFeeCap: 202,
+			expectedGasTipCap: 2,
+		},
+		{
+			name: "conservative",
+			client: &mockFeeMarketClient{
+				baseFee: big.NewInt(100), minBaseFee: big.NewInt(1), rewards: []int64{2},
+			},
+			config:            TxPricerConfig{Strategy: Conservative},
+			expectedGasFeeCap: 127,
+			expectedGasTipCap: 2,
+		},
+		{
+			name: "fast",
+			client: &mockFeeMarketClient{
+				baseFee: big.NewInt(100), minBaseFee: big.NewInt(1), rewards: []int64{2},
+			},
+			config:            TxPricerConfig{Strategy: Fast},
+			expectedGasFeeCap: 302,
+			expectedGasTipCap: 2,
+		},
+		{
+			name: "minimum base fee",
+			client: &mockFeeMarketClient{
+				baseFee: big.NewInt(10), minBaseFee: big.NewInt(100), rewards: []int64{2},
+			},
+			expectedGasFeeCap: 202,
+			expectedGasTipCap: 2,
+		},
+		{
+			name: "suggested tip without fee history",
+			client: &mockFeeMarketClient{
+				baseFee: big.NewInt(100), minBaseFee: big.NewInt(1), rewards: []int64{0, 0}, suggestedTip: big.NewInt(5),
+			},
+			expectedGasFeeCap: 205,
+			expectedGasTipCap: 5,
+		},
+		{
+			name: "without fee config",
+			client: &mockFeeMarketClient{
+				baseFee: big.NewInt(100), rewards: []int64{3}, suggestedTip: big.NewInt(5),
+				feeConfigErr: ErrFeeConfigUnavailable,
+			},
+			expectedGasFeeCap: 200 + MaxPriorityFeePerGas,
+			expectedGasTipCap: 5,
+		},
+		{
+			name: "capped",
+			client: &mockFeeMarketClient{
+				baseFee: big.NewInt(100), minBaseFee: big.NewInt(1), rewards: []int64{50},
+			},
+			config:            TxPricerConfig{MaxFeePerTx: big.NewInt(1200)},
+			gasLimit:          10,
+			expectedGasFeeCap: 120,
+			expectedGasTipCap: 50,
+		},
+		{
+			name: "cap below base fee",
+			client: &mockFeeMarketClient{
+				baseFee: big.NewInt(100), minBaseFee: big.NewInt(1), rewards: []int64{2},
+			},
+			config:      TxPricerConfig{MaxFeePerTx: big.NewInt(999)},
+			gasLimit:    10,
+			expectedErr: ErrFeeCapExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			price, err := NewTxPricer(test.client, test.config).Price(context.Background(), test.gasLimit)
+			if test.expectedErr != nil {
+				require.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, big.NewInt(test.expectedGasFeeCap), price.GasFeeCap)
+			require.Equal(t, big.NewInt(test.expectedGasTipCap), price.GasTipCap)
+		})
+	}
+}
+
+func TestTxPricerReprice(t *testing.T) {
+	client := &mockFeeMarketClient{baseFee: big.NewInt(100), minBaseFee: big.NewInt(1), rewards: []int64{2}}
+	pricer := NewTxPricer(client, TxPricerConfig{MaxFeePerTx: big.NewInt(10_000)})
+
+	// A stuck transaction priced above the current market only needs the minimum bump.
+	price, err := pricer.Reprice(
+		context.Background(),
+		&TxPrice{GasFeeCap: big.NewInt(300), GasTipCap: big.NewInt(10)},
+		10,
+	)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(330), price.GasFeeCap)
+	require.Equal(t, big.NewInt(11), price.GasTipCap)
+
+	// A stuck transaction priced below the current market is raised to the market price.
+	price, err = pricer.Reprice(
+		context.Background(),
+		&TxPrice{GasFeeCap: big.NewInt(50), GasTipCap: big.NewInt(0)},
+		10,
+	)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(202), price.GasFeeCap)
+	require.Equal(t, big.NewInt(2), price.GasTipCap)
+
+	// Replacements may not exceed the cap.
+	_, err = pricer.Reprice(
+		context.Background(),
+		&TxPrice{GasFeeCap: big.NewInt(1000), GasTipCap: big.NewInt(10)},
+		10,
+	)
+	require.ErrorIs(t, err, ErrFeeCapExceeded)
+}
+
+func TestEffectiveGasPrice(t *testing.T) {
+	price := &TxPrice{GasFeeCap: big.NewInt(200), GasTipCap: big.NewInt(2)}
+	require.Equal(t, big.NewInt(102), price.EffectiveGasPrice(big.NewInt(100)))
+	require.Equal(t, big.NewInt(200), price.EffectiveGasPrice(big.NewInt(199)))
+}
+
+// cChainService serves the fee market methods of a chain without eth_feeConfig
+type cChainService struct{}
+
+func (cChainService) BaseFee() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(100))
+}
+
+func TestFeeConfigUnavailable(t *testing.T) {
+	server := rpc.NewServer(0)
+	defer server.Stop()
+	require.NoError(t, server.RegisterName("eth", cChainService{}))
+	client := NewFeeMarketClient(ethclient.NewClient(rpc.DialInProc(server)))
+
+	_, err := client.FeeConfig(context.Background())
+	require.ErrorIs(t, err, ErrFeeConfigUnavailable)
+}
@@ -0,0 +1,260 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	predicateutils "github.com/ava-labs/subnet-evm/predicate"
+	subnetEvmUtils "github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// ReceiveGasEstimate is the gas required by a receiveCrossChainMessage transaction,
+// split by the part of the call that consumes it. The components sum to Total.
+type ReceiveGasEstimate struct {
+	// PredicateVerification is the gas charged by the Warp precompile, both for verifying the
+	// predicate included in the access list and for reading it in getVerifiedWarpMessage.
+	PredicateVerification uint64
+	// Decoding is the gas used by the rest of receiveCrossChainMessage for a message without
+	// receipts, payload or required gas limit: the intrinsic transaction cost, decoding the
+	// Teleporter message, and recording its delivery. Such a message can't be executed, so
+	// this includes storing it as a failed execution.
+	Decoding uint64
+	// ReceiptMarking is the gas used to process the receipts included in the message.
+	ReceiptMarking uint64
+	// Execution is the gas that must be made available to execute the message payload,
+	// including the required gas limit that receiveCrossChainMessage reserves for the call.
+	Execution uint64
+	Total     uint64
+
+	// Simulated is false if the transaction could not be simulated, in which case the estimate
+	// is derived from CalculateReceiveMessageGasLimit and SimulationErr holds the reason.
+	Simulated     bool
+	SimulationErr error
+}
+
+// ReceiveGasEstimator estimates the gas required to deliver Teleporter messages by simulating
+// receiveCrossChainMessage on the destination chain's current state via eth_estimateGas.
+//
+// The Warp message is passed to the simulation as a synthetic predicate with the given number
+// of signers and an empty aggregate signature. Predicates are not verified when estimating gas,
+// so the message does not need to be signed, but the precompile charges the same amount of gas
+// as for a signed message with the same number of signers.
+type ReceiveGasEstimator struct {
+	client            interfaces.GasEstimator
+	teleporterAddress common.Address
+	relayerAddress    common.Address
+}
+
+// NewReceiveGasEstimator returns an estimator that simulates delivery of messages to the
+// TeleporterMessenger at [teleporterAddress] by [relayerAddress].
+func NewReceiveGasEstimator(
+	client interfaces.GasEstimator,
+	teleporterAddress common.Address,
+	relayerAddress common.Address,
+) *ReceiveGasEstimator {
+	return &ReceiveGasEstimator{
+		client:            client,
+		teleporterAddress: teleporterAddress,
+		relayerAddress:    relayerAddress,
+	}
+}
+
+// EstimateReceiveMessageGas estimates the gas required to deliver [unsignedMessage] with an
+// aggregate signature from [numSigners] validators. The gas used by each part of the call is
+// isolated by also simulating the message without its payload and required gas limit, with
+// and without its receipts.
+// If simulation fails, the static CalculateReceiveMessageGasLimit formula is used instead.
+// An error is only returned if the message is not a valid Teleporter message.
+func (e *ReceiveGasEstimator) EstimateReceiveMessageGas(
+	ctx context.Context,
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	numSigners int,
+) (*ReceiveGasEstimate, error) {
+	parsed, err := teleportermessenger.ParseTeleporterWarpMessage(unsignedMessage, e.teleporterAddress)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse teleporter warp message")
+	}
+	message := parsed.Message
+
+	fullMessage, err := newSyntheticWarpMessage(unsignedMessage, numSigners)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate, err := e.simulate(ctx, unsignedMessage.NetworkID, unsignedMessage.SourceChainID, message, numSigners)
+	if err == nil {
+		return estimate, nil
+	}
+
+	// Fall back to the static formula.
+	gasLimit, staticErr := CalculateReceiveMessageGasLimit(
+		numSigners,
+		message.RequiredGasLimit,
+		len(fullMessage.Bytes()),
+		len(parsed.UnsignedMessage.Payload),
+		len(message.Receipts),
+	)
+	if staticErr != nil {
+		return nil, errors.Wrap(staticErr, "failed to calculate static receive gas limit")
+	}
+	predicateGas := uint64(len(fullMessage.Bytes()))*warp.GasCostPerWarpMessageBytes*2 +
+		uint64(numSigners)*warp.GasCostPerWarpSigner +
+		warp.GasCostPerSignatureVerification
+	receiptGas := uint64(len(message.Receipts)) * MarkMessageReceiptGasCost
+	executionGas := message.RequiredGasLimit.Uint64()
+	return &ReceiveGasEstimate{
+		PredicateVerification: predicateGas,
+		Decoding:              gasLimit - predicateGas - receiptGas - executionGas,
+		ReceiptMarking:        receiptGas,
+		Execution:             executionGas,
+		Total:                 gasLimit,
+		Simulated:             false,
+		SimulationErr:         err,
+	}, nil
+}
+
+func (e *ReceiveGasEstimator) simulate(
+	ctx context.Context,
+	networkID uint32,
+	sourceBlockchainID ids.ID,
+	message teleportermessenger.TeleporterMessage,
+	numSigners int,
+) (*ReceiveGasEstimate, error) {
+	// The same message without a payload or required gas limit, and then also without receipts,
+	// isolates the execution and receipt marking costs. Only stripping the payload would leave
+	// the required gas limit reserved for the call in every variant.
+	withoutExecution := message
+	withoutExecution.Message = nil
+	withoutExecution.RequiredGasLimit = common.Big0
+	withoutReceipts := withoutExecution
+	withoutReceipts.Receipts = nil
+
+	var gas, predicateGas [3]uint64
+	for i, variant := range []teleportermessenger.TeleporterMessage{message, withoutExecution, withoutReceipts} {
+		var err error
+		gas[i], predicateGas[i], err = e.simulateVariant(ctx, networkID, sourceBlockchainID, variant, numSigners)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Remove the precompile costs, which depend on the message size, before comparing variants.
+	contractGas := [3]uint64{}
+	for i := range gas {
+		if gas[i] < predicateGas[i] {
+			return nil, errors.New("simulated gas is less than the predicate gas")
+		}
+		contractGas[i] = gas[i] - predicateGas[i]
+	}
+	decodingGas := min(contractGas[2], contractGas[0])
+	receiptGas := min(saturatingSub(contractGas[1], contractGas[2]), contractGas[0]-decodingGas)
+	return &ReceiveGasEstimate{
+		PredicateVerification: predicateGas[0],
+		Decoding:              decodingGas,
+		ReceiptMarking:        receiptGas,
+		Execution:             contractGas[0] - decodingGas - receiptGas,
+		Total:                 gas[0],
+		Simulated:             true,
+	}, nil
+}
+
+// simulateVariant estimates the gas of delivering [message], and returns it together with the
+// part of it charged by the Warp precompile.
+func (e *ReceiveGasEstimator) simulateVariant(
+	ctx context.Context,
+	networkID uint32,
+	sourceBlockchainID ids.ID,
+	message teleportermessenger.TeleporterMessage,
+	numSigners int,
+) (uint64, uint64, error) {
+	warpMessage, err := teleportermessenger.NewTeleporterWarpMessage(
+		networkID,
+		sourceBlockchainID,
+		e.teleporterAddress,
+		message,
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+	signedMessage, err := newSyntheticWarpMessage(warpMessage.UnsignedMessage, numSigners)
+	if err != nil {
+		return 0, 0, err
+	}
+	predicateGas, err := calculateWarpPrecompileGas(signedMessage)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	callData, err := teleportermessenger.PackReceiveCrossChainMessage(0, e.relayerAddress)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to pack receiveCrossChainMessage")
+	}
+	gas, err := e.client.EstimateGas(ctx, interfaces.CallMsg{
+		From: e.relayerAddress,
+		To:   &e.teleporterAddress,
+		Data: callData,
+		AccessList: types.AccessList{
+			{
+				Address:     warp.ContractAddress,
+				StorageKeys: subnetEvmUtils.BytesToHashSlice(predicateutils.PackPredicate(signedMessage.Bytes())),
+			},
+		},
+	})
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to simulate receiveCrossChainMessage")
+	}
+	return gas, predicateGas, nil
+}
+
+// newSyntheticWarpMessage attaches a signature from [numSigners] validators to [unsignedMessage].
+// The aggregate signature itself is empty, since predicates are not verified during simulation.
+func newSyntheticWarpMessage(
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	numSigners int,
+) (*avalancheWarp.Message, error) {
+	if numSigners < 0 {
+		return nil, errors.New("negative number of signers")
+	}
+	signers := set.NewBits()
+	for i := 0; i < numSigners; i++ {
+		signers.Add(i)
+	}
+	signedMessage, err := avalancheWarp.NewMessage(unsignedMessage, &avalancheWarp.BitSetSignature{
+		Signers: signers.Bytes(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create synthetic warp message")
+	}
+	return signedMessage, nil
+}
+
+// calculateWarpPrecompileGas returns the gas charged by the Warp precompile for a transaction that
+// includes [signedMessage] as a predicate and reads it once with getVerifiedWarpMessage.
+func calculateWarpPrecompileGas(signedMessage *avalancheWarp.Message) (uint64, error) {
+	predicateBytes := predicateutils.PackPredicate(signedMessage.Bytes())
+	verificationGas, err := (&warp.Config{}).PredicateGas(predicateBytes)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to calculate predicate gas")
+	}
+	readGas := warp.GetVerifiedWarpMessageBaseCost +
+		uint64(len(predicateBytes))*warp.GasCostPerWarpMessageBytes
+	return verificationGas + readGas, nil
+}
+
+func saturatingSub(a, b uint64) uint64 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
@@ -0,0 +1,187 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	predicateutils "github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBaseGas         uint64 = 71_000
+	testReceiptGas      uint64 = 4_000
+	testPayloadByteGas  uint64 = 100
+	testExecutionMargin uint64 = 5_000
+)
+
+var (
+	testTeleporterAddress = common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf")
+	testRelayerAddress    = common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+)
+
+// mockGasEstimator charges a fixed amount per part of receiveCrossChainMessage, in addition to
+// the Warp precompile gas for the predicate in the access list. Like eth_estimateGas, it
+// includes the required gas limit whether or not the message has a payload, since
+// receiveCrossChainMessage reserves it for the call.
+type mockGasEstimator struct {
+	err error
+}
+
+func (m *mockGasEstimator) EstimateGas(_ context.Context, call interfaces.CallMsg) (uint64, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	var predicateBytes []byte
+	for _, key := range call.AccessList[0].StorageKeys {
+		predicateBytes = append(predicateBytes, key.Bytes()...)
+	}
+	unpacked, err := predicateutils.UnpackPredicate(predicateBytes)
+	if err != nil {
+		return 0, err
+	}
+	signedMessage, err := avalancheWarp.ParseMessage(unpacked)
+	if err != nil {
+		return 0, err
+	}
+	parsed, err := teleportermessenger.ParseTeleporterWarpMessage(&signedMessage.UnsignedMessage, *call.To)
+	if err != nil {
+		return 0, err
+	}
+	gas, err := calculateWarpPrecompileGas(signedMessage)
+	if err != nil {
+		return 0, err
+	}
+	gas += testBaseGas + uint64(len(parsed.Message.Receipts))*testReceiptGas
+	gas += uint64(len(parsed.Message.Message)) * testPayloadByteGas
+	if requiredGasLimit := parsed.Message.RequiredGasLimit.Uint64(); requiredGasLimit > 0 {
+		gas += requiredGasLimit + testExecutionMargin
+	}
+	return gas, nil
+}
+
+func createTestWarpMessage(t *testing.T) (*avalancheWarp.UnsignedMessage, teleportermessenger.TeleporterMessage) {
+	message := teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(1),
+		OriginSenderAddress:     common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		DestinationBlockchainID: ids.ID{1},
+		DestinationAddress:      common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		RequiredGasLimit:        big.NewInt(150_000),
+		AllowedRelayerAddresses: []common.Address{},
+		Receipts: []teleportermessenger.TeleporterMessageReceipt{
+			{ReceivedMessageNonce: big.NewInt(1), RelayerRewardAddress: testRelayerAddress},
+			{ReceivedMessageNonce: big.NewInt(2), RelayerRewardAddress: testRelayerAddress},
+		},
+		Message: []byte{1, 2, 3, 4},
+	}
+	warpMessage, err := teleportermessenger.NewTeleporterWarpMessage(1, ids.ID{2}, testTeleporterAddress, message)
+	require.NoError(t, err)
+	return warpMessage.UnsignedMessage, message
+}
+
+func TestEstimateReceiveMessageGasSimulated(t *testing.T) {
+	unsignedMessage, message := createTestWarpMessage(t)
+	numSigners := 5
+	estimator := NewReceiveGasEstimator(&mockGasEstimator{}, testTeleporterAddress, testRelayerAddress)
+
+	estimate, err := estimator.EstimateReceiveMessageGas(context.Background(), unsignedMessage, numSigners)
+	require.NoError(t, err)
+	require.True(t, estimate.Simulated)
+	require.NoError(t, estimate.SimulationErr)
+
+	signedMessage, err := newSyntheticWarpMessage(unsignedMessage, numSigners)
+	require.NoError(t, err)
+	numSignersInMessage, err := signedMessage.Signature.NumSigners()
+	require.NoError(t, err)
+	require.Equal(t, numSigners, numSignersInMessage)
+	predicateGas, err := calculateWarpPrecompileGas(signedMessage)
+	require.NoError(t, err)
+
+	require.Equal(t, predicateGas, estimate.PredicateVerification)
+	require.Equal(t, testBaseGas, estimate.Decoding)
+	require.Equal(t, 2*testReceiptGas, estimate.ReceiptMarking)
+	require.Equal(
+		t,
+		message.RequiredGasLimit.Uint64()+testExecutionMargin+uint64(len(message.Message))*testPayloadByteGas,
+		estimate.Execution,
+	)
+	require.Equal(
+		t,
+		estimate.Total,
+		estimate.PredicateVerification+estimate.Decoding+estimate.ReceiptMarking+estimate.Execution,
+	)
+}
+
+// TestEstimateReceiveMessageGasWithoutPayload checks that the required gas limit of a message
+// without a payload is attributed to execution rather than decoding
+func TestEstimateReceiveMessageGasWithoutPayload(t *testing.T) {
+	_, message := createTestWarpMessage(t)
+	message.Message = nil
+	message.Receipts = nil
+	warpMessage, err := teleportermessenger.NewTeleporterWarpMessage(1, ids.ID{2}, testTeleporterAddress, message)
+	require.NoError(t, err)
+	estimator := NewReceiveGasEstimator(&mockGasEstimator{}, testTeleporterAddress, testRelayerAddress)
+
+	estimate, err := estimator.EstimateReceiveMessageGas(context.Background(), warpMessage.UnsignedMessage, 5)
+	require.NoError(t, err)
+	require.True(t, estimate.Simulated)
+	require.Equal(t, testBaseGas, estimate.Decoding)
+	require.Zero(t, estimate.ReceiptMarking)
+	require.Equal(t, message.RequiredGasLimit.Uint64()+testExecutionMargin, estimate.Execution)
+	require.Equal(
+		t,
+		estimate.Total,
+		estimate.PredicateVerification+estimate.Decoding+estimate.ReceiptMarking+estimate.Execution,
+	)
+}
+
+func TestEstimateReceiveMessageGasFallback(t *testing.T) {
+	unsignedMessage, message := createTestWarpMessage(t)
+	numSigners := 5
+	simulationErr := errors.New("eth_estimateGas not supported")
+	estimator := NewReceiveGasEstimator(
+		&mockGasEstimator{err: simulationErr},
+		testTeleporterAddress,
+		testRelayerAddress,
+	)
+
+	estimate, err := estimator.EstimateReceiveMessageGas(context.Background(), unsignedMessage, numSigners)
+	require.NoError(t, err)
+	require.False(t, estimate.Simulated)
+	require.ErrorIs(t, estimate.SimulationErr, simulationErr)
+
+	signedMessage, err := newSyntheticWarpMessage(unsignedMessage, numSigners)
+	require.NoError(t, err)
+	expected, err := CalculateReceiveMessageGasLimit(
+		numSigners,
+		message.RequiredGasLimit,
+		len(signedMessage.Bytes()),
+		len(unsignedMessage.Payload),
+		len(message.Receipts),
+	)
+	require.NoError(t, err)
+	require.Equal(t, expected, estimate.Total)
+	require.Equal(
+		t,
+		estimate.Total,
+		estimate.PredicateVerification+estimate.Decoding+estimate.ReceiptMarking+estimate.Execution,
+	)
+}
+
+func TestEstimateReceiveMessageGasInvalidMessage(t *testing.T) {
+	unsignedMessage, _ := createTestWarpMessage(t)
+	// Messages must be sent by the TeleporterMessenger being simulated.
+	estimator := NewReceiveGasEstimator(&mockGasEstimator{}, testRelayerAddress, testRelayerAddress)
+	_, err := estimator.EstimateReceiveMessageGas(context.Background(), unsignedMessage, 1)
+	require.ErrorIs(t, err, teleportermessenger.ErrUnexpectedSourceAddress)
+}
@@ -0,0 +1,163 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+// MaxTokenDecimals is the maximum number of decimals TokenHome and TokenRemote accept
+const MaxTokenDecimals = 18
+
+var (
+	ErrTokenDecimalsTooHigh = fmt.Errorf("token decimals exceed %d", MaxTokenDecimals)
+	ErrInvalidAmount        = errors.New("amount must be a non-negative 256 bit integer")
+	ErrInvalidMultiplier    = errors.New("token multiplier must be a positive 256 bit integer")
+	// ErrAmountOverflow corresponds to the arithmetic overflow panic of the contracts
+	ErrAmountOverflow = errors.New("scaled amount overflows uint256")
+)
+
+// TokenScaling is the token scaling between a TokenHome instance and one of its TokenRemote
+// instances, as stored in the home's RemoteTokenTransferrerSettings. It replicates
+// TokenScalingUtils.sol, including the home or remote tokens that are lost to integer division
+// when scaling down.
+type TokenScaling struct {
+	TokenMultiplier  *big.Int
+	MultiplyOnRemote bool
+}
+
+// DeriveTokenScaling returns the token scaling of a remote token with [remoteTokenDecimals]
+// decimals for a home token with [homeTokenDecimals] decimals, as computed on registration.
+func DeriveTokenScaling(homeTokenDecimals uint8, remoteTokenDecimals uint8) (TokenScaling, error) {
+	if homeTokenDecimals > MaxTokenDecimals || remoteTokenDecimals > MaxTokenDecimals {
+		return TokenScaling{}, ErrTokenDecimalsTooHigh
+	}
+	multiplyOnRemote := remoteTokenDecimals > homeTokenDecimals
+	exponent := homeTokenDecimals - remoteTokenDecimals
+	if multiplyOnRemote {
+		exponent = remoteTokenDecimals - homeTokenDecimals
+	}
+	return TokenScaling{
+		TokenMultiplier:  new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil),
+		MultiplyOnRemote: multiplyOnRemote,
+	}, nil
+}
+
+// ApplyTokenScale returns the amount of remote tokens [homeTokenAmount] is scaled to when
+// sending from the home to the remote.
+func (s TokenScaling) ApplyTokenScale(homeTokenAmount *big.Int) (*big.Int, error) {
+	scaled, _, err := s.scaleTokens(homeTokenAmount, true)
+	return scaled, err
+}
+
+// RemoveTokenScale returns the amount of home tokens [remoteTokenAmount] is scaled to when
+// sending from the remote back to the home.
+func (s TokenScaling) RemoveTokenScale(remoteTokenAmount *big.Int) (*big.Int, error) {
+	scaled, _, err := s.scaleTokens(remoteTokenAmount, false)
+	return scaled, err
+}
+
+// ApplyTokenScaleWithDust is ApplyTokenScale, and also returns the amount of home tokens that
+// don't correspond to any remote tokens. The dust remains locked in the TokenHome instance.
+func (s TokenScaling) ApplyTokenScaleWithDust(homeTokenAmount *big.Int) (*big.Int, *big.Int, error) {
+	return s.scaleTokens(homeTokenAmount, true)
+}
+
+// RemoveTokenScaleWithDust is RemoveTokenScale, and also returns the amount of remote tokens
+// that don't correspond to any home tokens. The dust is burned on the remote.
+func (s TokenScaling) RemoveTokenScaleWithDust(remoteTokenAmount *big.Int) (*big.Int, *big.Int, error) {
+	return s.scaleTokens(remoteTokenAmount, false)
+}
+
+// CollateralNeeded returns the amount of home tokens needed to collateralize a remote that was
+// deployed with [initialReserveImbalance] remote tokens, rounded up as on registration.
+func (s TokenScaling) CollateralNeeded(initialReserveImbalance *big.Int) (*big.Int, error) {
+	collateralNeeded, dust, err := s.scaleTokens(initialReserveImbalance, false)
+	if err != nil {
+		return nil, err
+	}
+	if s.MultiplyOnRemote && dust.Sign() != 0 {
+		collateralNeeded.Add(collateralNeeded, big.NewInt(1))
+	}
+	return collateralNeeded, nil
+}
+
+// MinSendableFromHome returns the smallest amount of home tokens that can be sent to the remote,
+// since TokenHome rejects transfers scaled to zero remote tokens.
+func (s TokenScaling) MinSendableFromHome() (*big.Int, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	if s.MultiplyOnRemote {
+		return big.NewInt(1), nil
+	}
+	return new(big.Int).Set(s.TokenMultiplier), nil
+}
+
+// MinSendableFromRemote returns the smallest amount of remote tokens that can be sent back to the
+// home, or through the home to another remote while paying [secondaryFee] remote tokens for the
+// second hop. The amount must be worth more home tokens than the secondary fee, and at least one.
+func (s TokenScaling) MinSendableFromRemote(secondaryFee *big.Int) (*big.Int, error) {
+	if secondaryFee == nil {
+		secondaryFee = new(big.Int)
+	}
+	homeFee, err := s.RemoveTokenScale(secondaryFee)
+	if err != nil {
+		return nil, err
+	}
+	minHomeAmount := homeFee.Add(homeFee, big.NewInt(1))
+	if minHomeAmount.BitLen() > 256 {
+		return nil, ErrAmountOverflow
+	}
+	// Find the smallest remote amount that is scaled to at least minHomeAmount
+	var minAmount *big.Int
+	if s.MultiplyOnRemote {
+		minAmount = minHomeAmount.Mul(minHomeAmount, s.TokenMultiplier)
+	} else {
+		minAmount = ceilDiv(minHomeAmount, s.TokenMultiplier)
+	}
+	if minAmount.BitLen() > 256 {
+		return nil, ErrAmountOverflow
+	}
+	return minAmount, nil
+}
+
+// scaleTokens mirrors _scaleTokens, and returns the remainder of the division if one is performed
+func (s TokenScaling) scaleTokens(amount *big.Int, isSendToRemote bool) (*big.Int, *big.Int, error) {
+	if err := s.validate(); err != nil {
+		return nil, nil, err
+	}
+	if amount == nil || amount.Sign() < 0 || amount.BitLen() > 256 {
+		return nil, nil, ErrInvalidAmount
+	}
+	// Multiply when multiplyOnRemote and isSendToRemote are both true or both false.
+	if s.MultiplyOnRemote == isSendToRemote {
+		scaled := new(big.Int).Mul(amount, s.TokenMultiplier)
+		if scaled.Cmp(math.MaxBig256) > 0 {
+			return nil, nil, ErrAmountOverflow
+		}
+		return scaled, new(big.Int), nil
+	}
+	scaled, dust := new(big.Int).QuoRem(amount, s.TokenMultiplier, new(big.Int))
+	return scaled, dust, nil
+}
+
+func (s TokenScaling) validate() error {
+	if s.TokenMultiplier == nil || s.TokenMultiplier.Sign() <= 0 || s.TokenMultiplier.BitLen() > 256 {
+		return ErrInvalidMultiplier
+	}
+	return nil
+}
+
+func ceilDiv(x *big.Int, y *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(x, y, new(big.Int))
+	if remainder.Sign() != 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	return quotient
+}
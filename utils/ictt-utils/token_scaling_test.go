@@ -0,0 +1,332 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient/simulated"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func bigFromString(t testing.TB, s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 10)
+	require.True(t, ok, s)
+	return n
+}
+
+// tokenScalingCorpus holds outputs of TokenScalingUtils.sol. The same values are asserted in
+// contracts/utilities/tests/TokenScalingUtilsTests.t.sol. An empty result means the contract
+// reverts with an arithmetic overflow.
+var tokenScalingCorpus = []struct {
+	name             string
+	tokenMultiplier  string
+	multiplyOnRemote bool
+	amount           string
+	applied          string
+	removed          string
+}{
+	{
+		name:             "6 home decimals to 18 remote decimals",
+		tokenMultiplier:  "1000000000000",
+		multiplyOnRemote: true,
+		amount:           "1500000",
+		applied:          "1500000000000000000",
+		removed:          "0",
+	},
+	{
+		name:             "18 home decimals to 6 remote decimals",
+		tokenMultiplier:  "1000000000000",
+		multiplyOnRemote: false,
+		amount:           "1234567890123456789",
+		applied:          "1234567",
+		removed:          "1234567890123456789000000000000",
+	},
+	{
+		name:             "equal decimals",
+		tokenMultiplier:  "1",
+		multiplyOnRemote: false,
+		amount:           "42",
+		applied:          "42",
+		removed:          "42",
+	},
+	{
+		name:             "max amount",
+		tokenMultiplier:  "1000000000000000000",
+		multiplyOnRemote: true,
+		amount:           math.MaxBig256.String(),
+		applied:          "",
+		removed:          "115792089237316195423570985008687907853269984665640564039457",
+	},
+}
+
+func TestTokenScalingSolidityCorpus(t *testing.T) {
+	for _, test := range tokenScalingCorpus {
+		t.Run(test.name, func(t *testing.T) {
+			scaling := TokenScaling{
+				TokenMultiplier:  bigFromString(t, test.tokenMultiplier),
+				MultiplyOnRemote: test.multiplyOnRemote,
+			}
+			amount := bigFromString(t, test.amount)
+
+			applied, err := scaling.ApplyTokenScale(amount)
+			if test.applied == "" {
+				require.ErrorIs(t, err, ErrAmountOverflow)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, bigFromString(t, test.applied), applied)
+			}
+			removed, err := scaling.RemoveTokenScale(amount)
+			require.NoError(t, err)
+			require.Equal(t, bigFromString(t, test.removed), removed)
+		})
+	}
+}
+
+func TestDeriveTokenScaling(t *testing.T) {
+	tests := []struct {
+		homeDecimals     uint8
+		remoteDecimals   uint8
+		tokenMultiplier  int64
+		multiplyOnRemote bool
+		expectedErr      error
+	}{
+		{homeDecimals: 6, remoteDecimals: 18, tokenMultiplier: 1e12, multiplyOnRemote: true},
+		{homeDecimals: 18, remoteDecimals: 6, tokenMultiplier: 1e12, multiplyOnRemote: false},
+		{homeDecimals: 18, remoteDecimals: 18, tokenMultiplier: 1, multiplyOnRemote: false},
+		{homeDecimals: 0, remoteDecimals: 18, tokenMultiplier: 1e18, multiplyOnRemote: true},
+		{homeDecimals: 19, remoteDecimals: 18, expectedErr: ErrTokenDecimalsTooHigh},
+		{homeDecimals: 18, remoteDecimals: 19, expectedErr: ErrTokenDecimalsTooHigh},
+	}
+	for _, test := range tests {
+		scaling, err := DeriveTokenScaling(test.homeDecimals, test.remoteDecimals)
+		require.ErrorIs(t, err, test.expectedErr)
+		if test.expectedErr != nil {
+			continue
+		}
+		require.Equal(t, big.NewInt(test.tokenMultiplier), scaling.TokenMultiplier)
+		require.Equal(t, test.multiplyOnRemote, scaling.MultiplyOnRemote)
+	}
+}
+
+func TestTokenScalingDust(t *testing.T) {
+	scaling, err := DeriveTokenScaling(18, 6)
+	require.NoError(t, err)
+
+	remoteAmount, dust, err := scaling.ApplyTokenScaleWithDust(big.NewInt(2_000_000_000_123))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2), remoteAmount)
+	require.Equal(t, big.NewInt(123), dust)
+
+	homeAmount, dust, err := scaling.RemoveTokenScaleWithDust(big.NewInt(5))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(5_000_000_000_000), homeAmount)
+	require.Zero(t, dust.Sign())
+
+	_, err = scaling.ApplyTokenScale(big.NewInt(-1))
+	require.ErrorIs(t, err, ErrInvalidAmount)
+	_, err = TokenScaling{TokenMultiplier: big.NewInt(0)}.ApplyTokenScale(big.NewInt(1))
+	require.ErrorIs(t, err, ErrInvalidMultiplier)
+}
+
+func TestCollateralNeeded(t *testing.T) {
+	multiplyOnRemote, err := DeriveTokenScaling(6, 18)
+	require.NoError(t, err)
+	divideOnRemote, err := DeriveTokenScaling(18, 6)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                    string
+		scaling                 TokenScaling
+		initialReserveImbalance *big.Int
+		expected                *big.Int
+	}{
+		{
+			name:                    "divisible",
+			scaling:                 multiplyOnRemote,
+			initialReserveImbalance: big.NewInt(2e18),
+			expected:                big.NewInt(2_000_000),
+		},
+		{
+			name:                    "rounded up",
+			scaling:                 multiplyOnRemote,
+			initialReserveImbalance: big.NewInt(1e18 + 1),
+			expected:                big.NewInt(1_000_001),
+		},
+		{
+			name:                    "multiplied",
+			scaling:                 divideOnRemote,
+			initialReserveImbalance: big.NewInt(3),
+			expected:                big.NewInt(3e12),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collateralNeeded, err := test.scaling.CollateralNeeded(test.initialReserveImbalance)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, collateralNeeded)
+		})
+	}
+}
+
+func TestMinSendable(t *testing.T) {
+	for _, decimals := range [][2]uint8{{6, 18}, {18, 6}, {18, 18}} {
+		scaling, err := DeriveTokenScaling(decimals[0], decimals[1])
+		require.NoError(t, err)
+
+		// The minimum is scaled to a non-zero amount, and one less is not
+		minHome, err := scaling.MinSendableFromHome()
+		require.NoError(t, err)
+		scaled, err := scaling.ApplyTokenScale(minHome)
+		require.NoError(t, err)
+		require.Positive(t, scaled.Sign())
+		scaled, err = scaling.ApplyTokenScale(new(big.Int).Sub(minHome, big.NewInt(1)))
+		require.NoError(t, err)
+		require.Zero(t, scaled.Sign())
+
+		for _, secondaryFee := range []*big.Int{nil, big.NewInt(0), big.NewInt(1), big.NewInt(3e12 + 5)} {
+			minRemote, err := scaling.MinSendableFromRemote(secondaryFee)
+			require.NoError(t, err)
+			if secondaryFee == nil {
+				secondaryFee = big.NewInt(0)
+			}
+			homeFee, err := scaling.RemoveTokenScale(secondaryFee)
+			require.NoError(t, err)
+			homeAmount, err := scaling.RemoveTokenScale(minRemote)
+			require.NoError(t, err)
+			require.Equal(t, 1, homeAmount.Cmp(homeFee))
+			homeAmount, err = scaling.RemoveTokenScale(new(big.Int).Sub(minRemote, big.NewInt(1)))
+			require.NoError(t, err)
+			require.NotEqual(t, 1, homeAmount.Cmp(homeFee))
+		}
+	}
+}
+
+// tokenScalingHarnessCode is the runtime code of a harness evaluating TokenScalingUtils._scaleTokens
+// with Solidity 0.8's checked arithmetic. It takes the ABI encoded arguments (uint256 tokenMultiplier,
+// bool multiplyOnRemote, uint256 amount, bool isSendToRemote) without a selector, returns the scaled
+// amount, and reverts with Panic(0x11) on overflow. Assembled from:
+//
+//	    PUSH1 0x20 CALLDATALOAD ISZERO ISZERO PUSH1 0x60 CALLDATALOAD ISZERO ISZERO
+//	    EQ PUSH2 mul JUMPI
+//	    PUSH1 0x00 CALLDATALOAD PUSH1 0x40 CALLDATALOAD DIV PUSH2 ret JUMP
+//	mul:
+//	    JUMPDEST PUSH1 0x00 CALLDATALOAD PUSH1 0x40 CALLDATALOAD DUP2 DUP2 MUL
+//	    DUP2 ISZERO PUSH2 ok JUMPI
+//	    DUP2 DUP2 DIV DUP4 EQ PUSH2 ok JUMPI
+//	    PUSH4 0x4e487b71 PUSH1 0xe0 SHL PUSH1 0x00 MSTORE PUSH1 0x11 PUSH1 0x04 MSTORE
+//	    PUSH1 0x24 PUSH1 0x00 REVERT
+//	ok:
+//	    JUMPDEST SWAP2 POP POP
+//	ret:
+//	    JUMPDEST PUSH1 0x00 MSTORE PUSH1 0x20 PUSH1 0x00 RETURN
+var tokenScalingHarnessCode = common.FromHex(
+	"0x602035151560603515151461001a576000356040350461004c565b6000356040358181028115610048578181048314" +
+		"61004857634e487b7160e01b600052601160045260246000fd5b9150505b60005260206000f3",
+)
+
+// arithmeticOverflowPanic is the revert data of Solidity's Panic(0x11)
+const arithmeticOverflowPanic = "0x4e487b710000000000000000000000000000000000000000000000000000000000000011"
+
+var tokenScalingHarnessAddress = common.HexToAddress("0x0100000000000000000000000000000000000011")
+
+// evmScaleTokens evaluates _scaleTokens with the harness on [client], returning nil if it reverts
+// with an arithmetic overflow
+func evmScaleTokens(
+	t *testing.T,
+	client simulated.Client,
+	tokenMultiplier *uint256.Int,
+	multiplyOnRemote bool,
+	amount *uint256.Int,
+	isSendToRemote bool,
+) *big.Int {
+	boolWord := func(b bool) []byte {
+		if b {
+			return common.LeftPadBytes([]byte{1}, 32)
+		}
+		return make([]byte, 32)
+	}
+	tokenMultiplierWord := tokenMultiplier.Bytes32()
+	amountWord := amount.Bytes32()
+	var input []byte
+	input = append(input, tokenMultiplierWord[:]...)
+	input = append(input, boolWord(multiplyOnRemote)...)
+	input = append(input, amountWord[:]...)
+	input = append(input, boolWord(isSendToRemote)...)
+
+	result, err := client.CallContract(
+		context.Background(),
+		interfaces.CallMsg{To: &tokenScalingHarnessAddress, Data: input},
+		nil,
+	)
+	if err != nil {
+		var dataErr rpc.DataError
+		require.ErrorAs(t, err, &dataErr)
+		require.Equal(t, arithmeticOverflowPanic, dataErr.ErrorData())
+		return nil
+	}
+	return new(big.Int).SetBytes(result)
+}
+
+// FuzzTokenScaling compares TokenScaling against the EVM execution of _scaleTokens
+func FuzzTokenScaling(f *testing.F) {
+	for _, test := range tokenScalingCorpus {
+		f.Add(
+			bigFromString(f, test.tokenMultiplier).Bytes(),
+			test.multiplyOnRemote,
+			bigFromString(f, test.amount).Bytes(),
+		)
+	}
+	for _, multiplier := range []*big.Int{big.NewInt(1), big.NewInt(10), math.BigPow(10, 18), math.MaxBig256} {
+		for _, amount := range []*big.Int{big.NewInt(0), big.NewInt(1), math.MaxBig256} {
+			f.Add(multiplier.Bytes(), true, amount.Bytes())
+			f.Add(multiplier.Bytes(), false, amount.Bytes())
+		}
+	}
+
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		tokenScalingHarnessAddress: {Code: tokenScalingHarnessCode, Balance: common.Big0},
+	})
+	f.Cleanup(func() { require.NoError(f, backend.Close()) })
+	client := backend.Client()
+
+	f.Fuzz(func(t *testing.T, multiplierBytes []byte, multiplyOnRemote bool, amountBytes []byte) {
+		if len(multiplierBytes) > 32 || len(amountBytes) > 32 {
+			return
+		}
+		multiplier := new(uint256.Int).SetBytes(multiplierBytes)
+		if multiplier.IsZero() {
+			return
+		}
+		amount := new(uint256.Int).SetBytes(amountBytes)
+		scaling := TokenScaling{TokenMultiplier: multiplier.ToBig(), MultiplyOnRemote: multiplyOnRemote}
+
+		for _, isSendToRemote := range []bool{true, false} {
+			expected := evmScaleTokens(t, client, multiplier, multiplyOnRemote, amount, isSendToRemote)
+			scaled, dust, err := scaling.scaleTokens(amount.ToBig(), isSendToRemote)
+			if expected == nil {
+				require.ErrorIs(t, err, ErrAmountOverflow)
+				continue
+			}
+			require.NoError(t, err)
+			require.Equal(t, expected.String(), scaled.String())
+
+			// The dust is exactly the amount that isn't accounted for by the scaled amount
+			if multiplyOnRemote != isSendToRemote {
+				reconstructed := new(big.Int).Mul(scaled, scaling.TokenMultiplier)
+				require.Equal(t, amount.ToBig(), reconstructed.Add(reconstructed, dust))
+			} else {
+				require.Zero(t, dust.Sign())
+			}
+		}
+	})
+}
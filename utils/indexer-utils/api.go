@@ -0,0 +1,175 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var errInvalidParameter = errors.New("invalid parameter")
+
+// MessageResponse is the response of the message endpoint
+type MessageResponse struct {
+	Message *Message
+	Events  []*Event
+}
+
+type errorResponse struct {
+	Error string
+}
+
+// NewHandler returns the HTTP query API of [store]:
+//
+//	GET /messages/{messageID}: a message and its events, by hex or CB58 encoded ID
+//	GET /messages: messages, filtered by the sender, sourceBlockchainID, destinationBlockchainID,
+//	    destinationAddress and status query parameters, and paginated by limit and offset
+//	GET /redemptions/{redeemer}: the reward redemptions of a relayer
+func NewHandler(store *Store) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /messages/{messageID}", func(w http.ResponseWriter, r *http.Request) {
+		messageID, err := ParseMessageID(r.PathValue("messageID"))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		message, err := store.Message(r.Context(), messageID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		events, err := store.MessageEvents(r.Context(), messageID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, MessageResponse{Message: message, Events: events})
+	})
+	mux.HandleFunc("GET /messages", func(w http.ResponseWriter, r *http.Request) {
+		filter, err := parseMessageFilter(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		messages, err := store.Messages(r.Context(), filter)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if messages == nil {
+			messages = []*Message{}
+		}
+		writeJSON(w, http.StatusOK, messages)
+	})
+	mux.HandleFunc("GET /redemptions/{redeemer}", func(w http.ResponseWriter, r *http.Request) {
+		redeemer, err := parseAddress(r.PathValue("redeemer"))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		events, err := store.Redemptions(r.Context(), redeemer)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if events == nil {
+			events = []*Event{}
+		}
+		writeJSON(w, http.StatusOK, events)
+	})
+	return mux
+}
+
+func parseMessageFilter(r *http.Request) (MessageFilter, error) {
+	var (
+		filter MessageFilter
+		err    error
+	)
+	query := r.URL.Query()
+	if value := query.Get("sender"); value != "" {
+		if filter.Sender, err = parseAddress(value); err != nil {
+			return filter, err
+		}
+	}
+	if value := query.Get("destinationAddress"); value != "" {
+		if filter.DestinationAddress, err = parseAddress(value); err != nil {
+			return filter, err
+		}
+	}
+	if value := query.Get("sourceBlockchainID"); value != "" {
+		if filter.SourceBlockchainID, err = ids.FromString(value); err != nil {
+			return filter, fmt.Errorf("%w: sourceBlockchainID: %w", errInvalidParameter, err)
+		}
+	}
+	if value := query.Get("destinationBlockchainID"); value != "" {
+		if filter.DestinationBlockchainID, err = ids.FromString(value); err != nil {
+			return filter, fmt.Errorf("%w: destinationBlockchainID: %w", errInvalidParameter, err)
+		}
+	}
+	if value := query.Get("status"); value != "" {
+		if filter.Status, err = ToMessageStatus(value); err != nil {
+			return filter, fmt.Errorf("%w: %w", errInvalidParameter, err)
+		}
+	}
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			return filter, fmt.Errorf("%w: limit %s", errInvalidParameter, value)
+		}
+	}
+	if value := query.Get("offset"); value != "" {
+		if filter.Offset, err = strconv.Atoi(value); err != nil || filter.Offset < 0 {
+			return filter, fmt.Errorf("%w: offset %s", errInvalidParameter, value)
+		}
+	}
+	return filter, nil
+}
+
+// ParseMessageID parses a hex encoded message ID, as in Teleporter events, or a CB58 encoded
+// one, as logged by the relayer
+func ParseMessageID(value string) (common.Hash, error) {
+	if decoded, err := hexutil.Decode(value); err == nil && len(decoded) == common.HashLength {
+		return common.BytesToHash(decoded), nil
+	}
+	id, err := ids.FromString(value)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("%w: message ID %s", errInvalidParameter, value)
+	}
+	return common.Hash(id), nil
+}
+
+func parseAddress(value string) (common.Address, error) {
+	if !common.IsHexAddress(value) {
+		return common.Address{}, fmt.Errorf("%w: address %s", errInvalidParameter, value)
+	}
+	return common.HexToAddress(value), nil
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, errInvalidParameter):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrMessageNotFound):
+		status = http.StatusNotFound
+	default:
+		log.Warn("Failed to query the index", "err", err)
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Warn("Failed to write response", "err", err)
+	}
+}
@@ -0,0 +1,73 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	store := newTestStore(t)
+	messageID := common.HexToHash("0x1d")
+	message := teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(1),
+		OriginSenderAddress:     testSender,
+		DestinationBlockchainID: testDestinationID,
+		DestinationAddress:      testDestination,
+		RequiredGasLimit:        big.NewInt(1),
+		AllowedRelayerAddresses: []common.Address{},
+		Receipts:                []teleportermessenger.TeleporterMessageReceipt{},
+		Message:                 []byte{},
+	}
+	feeInfo := teleportermessenger.TeleporterFeeInfo{Amount: big.NewInt(0)}
+	require.NoError(t, store.IndexBlocks(context.Background(), testSourceID, []types.Log{
+		newTestLog(t, 1, 0, "SendCrossChainMessage", messageID, testDestinationID, message, feeInfo),
+	}, newTestCursor(1)))
+	server := httptest.NewServer(NewHandler(store))
+	t.Cleanup(server.Close)
+
+	get := func(path string, expectedStatus int, response any) {
+		resp, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, expectedStatus, resp.StatusCode)
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(response))
+	}
+
+	for _, id := range []string{messageID.Hex(), ids.ID(messageID).String()} {
+		var response MessageResponse
+		get("/messages/"+id, http.StatusOK, &response)
+		require.Equal(t, messageID, response.Message.MessageID)
+		require.Equal(t, MessageSent, response.Message.Status)
+		require.Len(t, response.Events, 1)
+	}
+
+	var messages []*Message
+	get("/messages?sender="+testSender.Hex()+"&status=sent", http.StatusOK, &messages)
+	require.Len(t, messages, 1)
+	get("/messages?destinationBlockchainID="+testSourceID.String(), http.StatusOK, &messages)
+	require.Empty(t, messages)
+
+	var errResponse errorResponse
+	get("/messages/"+common.HexToHash("0x2").Hex(), http.StatusNotFound, &errResponse)
+	require.Contains(t, errResponse.Error, ErrMessageNotFound.Error())
+	get("/messages?status=lost", http.StatusBadRequest, &errResponse)
+	get("/messages?sender=0x1234", http.StatusBadRequest, &errResponse)
+	get("/redemptions/not-an-address", http.StatusBadRequest, &errResponse)
+
+	var redemptions []*Event
+	get("/redemptions/"+testRelayer.Hex(), http.StatusOK, &redemptions)
+	require.Empty(t, redemptions)
+}
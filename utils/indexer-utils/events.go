@@ -0,0 +1,86 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrNotIndexedEvent = errors.New("not an indexed Teleporter event")
+
+	teleporterABI *abi.ABI
+	// indexedEventIDs are the topics of the Teleporter events that are indexed
+	indexedEventIDs []common.Hash
+)
+
+func init() {
+	var err error
+	teleporterABI, err = teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse TeleporterMessenger ABI: %v", err))
+	}
+	for name, event := range teleporterABI.Events {
+		if _, err := teleportermessenger.ToEvent(name); err == nil {
+			indexedEventIDs = append(indexedEventIDs, event.ID)
+		}
+	}
+}
+
+// decodeLog returns the name and the fields of the Teleporter event with [topics] and [data]
+func decodeLog(topics []common.Hash, data []byte) (string, fmt.Stringer, error) {
+	if len(topics) == 0 {
+		return "", nil, ErrNotIndexedEvent
+	}
+	event, err := teleporterABI.EventByID(topics[0])
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %w", ErrNotIndexedEvent, err)
+	}
+	if _, err := teleportermessenger.ToEvent(event.Name); err != nil {
+		return "", nil, fmt.Errorf("%w: %s", ErrNotIndexedEvent, event.Name)
+	}
+	decoded, err := teleportermessenger.FilterTeleporterEvents(topics, data, event.Name)
+	if err != nil {
+		return "", nil, err
+	}
+	return event.Name, decoded, nil
+}
+
+// eventData returns the human readable JSON encoding of [event], a Teleporter event returned by
+// decodeLog. Such events are decoded from the topics and data of their log alone, so their Raw
+// log is empty and is left out.
+func eventData(event fmt.Stringer) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(event.String()), &fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "Raw")
+	return json.MarshalIndent(fields, "", "  ")
+}
+
+// eventMessageID returns the ID of the message [event] refers to, if any
+func eventMessageID(event fmt.Stringer) (common.Hash, bool) {
+	switch e := event.(type) {
+	case *teleportermessenger.TeleporterMessengerSendCrossChainMessage:
+		return e.MessageID, true
+	case *teleportermessenger.TeleporterMessengerReceiveCrossChainMessage:
+		return e.MessageID, true
+	case *teleportermessenger.TeleporterMessengerAddFeeAmount:
+		return e.MessageID, true
+	case *teleportermessenger.TeleporterMessengerMessageExecutionFailed:
+		return e.MessageID, true
+	case *teleportermessenger.TeleporterMessengerMessageExecuted:
+		return e.MessageID, true
+	case *teleportermessenger.TeleporterMessengerReceiptReceived:
+		return e.MessageID, true
+	default:
+		return common.Hash{}, false
+	}
+}
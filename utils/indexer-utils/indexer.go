@@ -0,0 +1,245 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	defaultPollInterval  = time.Second
+	defaultMaxBlockRange = 2048
+	defaultMaxReorgDepth = 256
+)
+
+var (
+	ErrInvalidConfig = errors.New("invalid indexer config")
+	ErrReorged       = errors.New("blocks were reorged while being indexed")
+)
+
+// Chain is a chain whose TeleporterMessenger events are indexed
+type Chain struct {
+	BlockchainID      ids.ID
+	Client            ethclient.Client
+	TeleporterAddress common.Address
+	// StartBlock is the first block indexed if the database has no cursor for the chain
+	StartBlock uint64
+}
+
+// Config configures an Indexer
+type Config struct {
+	Chains []Chain
+	// ConfirmationDepth is the number of blocks that must be built on top of a block before it
+	// is indexed. Reorgs of indexed blocks are rolled back regardless.
+	ConfirmationDepth uint64
+	// PollInterval is the interval at which Run checks for new blocks. Defaults to 1 second.
+	PollInterval time.Duration
+	// MaxBlockRange is the maximum number of blocks whose logs are requested at once. Defaults
+	// to 2048.
+	MaxBlockRange uint64
+	// MaxReorgDepth is the number of indexed blocks with events that are checked for a common
+	// ancestor when a reorg is detected. If none of them is canonical, the chain is indexed again
+	// from its start block. Defaults to 256.
+	MaxReorgDepth int
+}
+
+// Indexer stores the lifecycle of Teleporter messages across several chains in a Store
+type Indexer struct {
+	config Config
+	store  *Store
+}
+
+// NewIndexer returns an indexer of the chains of [config] into [store]
+func NewIndexer(config Config, store *Store) (*Indexer, error) {
+	if len(config.Chains) == 0 {
+		return nil, fmt.Errorf("%w: no chains", ErrInvalidConfig)
+	}
+	if config.PollInterval == 0 {
+		config.PollInterval = defaultPollInterval
+	}
+	if config.MaxBlockRange == 0 {
+		config.MaxBlockRange = defaultMaxBlockRange
+	}
+	if config.MaxReorgDepth == 0 {
+		config.MaxReorgDepth = defaultMaxReorgDepth
+	}
+	blockchainIDs := make(map[ids.ID]struct{}, len(config.Chains))
+	for _, chain := range config.Chains {
+		if _, ok := blockchainIDs[chain.BlockchainID]; ok {
+			return nil, fmt.Errorf("%w: duplicate chain %s", ErrInvalidConfig, chain.BlockchainID)
+		}
+		if chain.Client == nil {
+			return nil, fmt.Errorf("%w: no client for %s", ErrInvalidConfig, chain.BlockchainID)
+		}
+		blockchainIDs[chain.BlockchainID] = struct{}{}
+	}
+	return &Indexer{
+		config: config,
+		store:  store,
+	}, nil
+}
+
+// Run indexes new blocks of every chain until [ctx] is done
+func (i *Indexer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(i.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		for _, chain := range i.config.Chains {
+			if err := i.sync(ctx, chain); err != nil {
+				log.Warn("Failed to index blocks", "blockchainID", chain.BlockchainID, "err", err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync indexes the confirmed blocks of every chain that weren't indexed yet
+func (i *Indexer) Sync(ctx context.Context) error {
+	for _, chain := range i.config.Chains {
+		if err := i.sync(ctx, chain); err != nil {
+			return fmt.Errorf("failed to index %s: %w", chain.BlockchainID, err)
+		}
+	}
+	return nil
+}
+
+// sync rolls back the indexed blocks of [c] that were reorged, and indexes its confirmed blocks
+// that weren't indexed yet
+func (i *Indexer) sync(ctx context.Context, c Chain) error {
+	head, err := c.Client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the height of %s: %w", c.BlockchainID, err)
+	}
+	if head < i.config.ConfirmationDepth {
+		return nil
+	}
+	confirmed := head - i.config.ConfirmationDepth
+
+	cursor, err := i.store.Cursor(ctx, c.BlockchainID)
+	if err != nil {
+		return err
+	}
+	if cursor != nil {
+		cursor, err = i.rollbackReorg(ctx, c, cursor)
+		if err != nil {
+			return err
+		}
+	}
+	next := c.StartBlock
+	if cursor != nil {
+		next = cursor.BlockNumber + 1
+	}
+
+	for next <= confirmed {
+		to := min(confirmed, next+i.config.MaxBlockRange-1)
+		if err := i.indexBlocks(ctx, c, next, to); err != nil {
+			return err
+		}
+		next = to + 1
+	}
+	return nil
+}
+
+// indexBlocks indexes blocks [from] to [to] of [c]
+func (i *Indexer) indexBlocks(ctx context.Context, c Chain, from uint64, to uint64) error {
+	toBlock := new(big.Int).SetUint64(to)
+	header, err := c.Client.HeaderByNumber(ctx, toBlock)
+	if err != nil {
+		return fmt.Errorf("failed to get block %d of %s: %w", to, c.BlockchainID, err)
+	}
+	logs, err := c.Client.FilterLogs(ctx, interfaces.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   toBlock,
+		Addresses: []common.Address{c.TeleporterAddress},
+		Topics:    [][]common.Hash{indexedEventIDs},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get logs of %s: %w", c.BlockchainID, err)
+	}
+	// The hash of the last block commits to every block of the range, so the logs are only
+	// stored if none of them was reorged while they were requested
+	latestHeader, err := c.Client.HeaderByNumber(ctx, toBlock)
+	if err != nil {
+		return fmt.Errorf("failed to get block %d of %s: %w", to, c.BlockchainID, err)
+	}
+	if latestHeader.Hash() != header.Hash() {
+		return fmt.Errorf("%w: block %d of %s", ErrReorged, to, c.BlockchainID)
+	}
+
+	cursor := Cursor{BlockNumber: to, BlockHash: header.Hash()}
+	if err := i.store.IndexBlocks(ctx, c.BlockchainID, logs, cursor); err != nil {
+		return fmt.Errorf("failed to index blocks %d to %d of %s: %w", from, to, c.BlockchainID, err)
+	}
+	log.Debug("Indexed blocks", "blockchainID", c.BlockchainID, "from", from, "to", to, "logs", len(logs))
+	return nil
+}
+
+// rollbackReorg checks that [cursor] is still canonical, and if it isn't, rolls the store back
+// to the latest indexed block of [c] that is. Returns the new cursor, or nil if no indexed block
+// is canonical.
+func (i *Indexer) rollbackReorg(ctx context.Context, c Chain, cursor *Cursor) (*Cursor, error) {
+	canonical, err := isCanonical(ctx, c, cursor)
+	if err != nil || canonical {
+		return cursor, err
+	}
+
+	blocks, err := i.store.BlockHashes(ctx, c.BlockchainID, cursor.BlockNumber, i.config.MaxReorgDepth)
+	if err != nil {
+		return nil, err
+	}
+	var ancestor *Cursor
+	for _, block := range blocks {
+		canonical, err := isCanonical(ctx, c, &block)
+		if err != nil {
+			return nil, err
+		}
+		if canonical {
+			ancestor = &block
+			break
+		}
+	}
+	if err := i.store.Rollback(ctx, c.BlockchainID, ancestor); err != nil {
+		return nil, fmt.Errorf("failed to roll back %s: %w", c.BlockchainID, err)
+	}
+	if ancestor == nil {
+		log.Warn("Reorg detected, indexing again from the start block",
+			"blockchainID", c.BlockchainID,
+			"cursor", cursor.BlockNumber,
+			"startBlock", c.StartBlock,
+		)
+	} else {
+		log.Warn("Reorg detected, rolled back",
+			"blockchainID", c.BlockchainID,
+			"cursor", cursor.BlockNumber,
+			"ancestor", ancestor.BlockNumber,
+		)
+	}
+	return ancestor, nil
+}
+
+// isCanonical returns whether [block] is in the canonical chain of [c]
+func isCanonical(ctx context.Context, c Chain, block *Cursor) (bool, error) {
+	header, err := c.Client.HeaderByNumber(ctx, new(big.Int).SetUint64(block.BlockNumber))
+	if errors.Is(err, interfaces.NotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get block %d of %s: %w", block.BlockNumber, c.BlockchainID, err)
+	}
+	return header.Hash() == block.BlockHash, nil
+}
@@ -0,0 +1,743 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+
+	// Registers the pure Go "sqlite" driver, which doesn't require cgo
+	_ "modernc.org/sqlite"
+)
+
+// defaultQueryLimit is the number of messages returned by Messages if the filter has no limit
+const defaultQueryLimit = 100
+
+var ErrMessageNotFound = errors.New("message not found")
+
+const schema = `
+CREATE TABLE IF NOT EXISTS events (
+	blockchain_id TEXT NOT NULL,
+	block_number INTEGER NOT NULL,
+	block_hash TEXT NOT NULL,
+	log_index INTEGER NOT NULL,
+	tx_hash TEXT NOT NULL,
+	event TEXT NOT NULL,
+	message_id TEXT,
+	redeemer TEXT,
+	topics BLOB NOT NULL,
+	data BLOB NOT NULL,
+	PRIMARY KEY (blockchain_id, block_number, log_index)
+);
+CREATE INDEX IF NOT EXISTS events_message_id ON events (message_id);
+CREATE INDEX IF NOT EXISTS events_redeemer ON events (redeemer);
+
+CREATE TABLE IF NOT EXISTS messages (
+	message_id TEXT PRIMARY KEY,
+	source_blockchain_id TEXT NOT NULL,
+	destination_blockchain_id TEXT NOT NULL,
+	nonce TEXT NOT NULL,
+	sender TEXT NOT NULL,
+	destination_address TEXT NOT NULL,
+	required_gas_limit TEXT NOT NULL,
+	fee_token_address TEXT NOT NULL,
+	fee_amount TEXT NOT NULL,
+	status TEXT NOT NULL,
+	receipt_received INTEGER NOT NULL,
+	deliverer TEXT NOT NULL,
+	reward_redeemer TEXT NOT NULL,
+	send_tx_hash TEXT NOT NULL,
+	receive_tx_hash TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS messages_sender ON messages (sender);
+CREATE INDEX IF NOT EXISTS messages_destination ON messages (destination_blockchain_id, destination_address);
+CREATE INDEX IF NOT EXISTS messages_status ON messages (status);
+
+CREATE TABLE IF NOT EXISTS cursors (
+	blockchain_id TEXT PRIMARY KEY,
+	block_number INTEGER NOT NULL,
+	block_hash TEXT NOT NULL
+);
+`
+
+// MessageStatus is the furthest point of its lifecycle a message is known to have reached
+type MessageStatus uint8
+
+const (
+	MessageUnknown MessageStatus = iota
+	// MessageSent messages were sent, and aren't known to have been delivered
+	MessageSent
+	// MessageDelivered messages were received by their destination chain
+	MessageDelivered
+	// MessageExecutionFailed messages were received, but their execution failed and hasn't been
+	// retried successfully
+	MessageExecutionFailed
+	// MessageExecuted messages were received and executed
+	MessageExecuted
+
+	messageSentStr            = "sent"
+	messageDeliveredStr       = "delivered"
+	messageExecutionFailedStr = "execution_failed"
+	messageExecutedStr        = "executed"
+	messageUnknownStr         = "unknown"
+)
+
+func (s MessageStatus) String() string {
+	switch s {
+	case MessageSent:
+		return messageSentStr
+	case MessageDelivered:
+		return messageDeliveredStr
+	case MessageExecutionFailed:
+		return messageExecutionFailedStr
+	case MessageExecuted:
+		return messageExecutedStr
+	default:
+		return messageUnknownStr
+	}
+}
+
+// ToMessageStatus converts a string to a MessageStatus
+func ToMessageStatus(s string) (MessageStatus, error) {
+	switch strings.ToLower(s) {
+	case messageSentStr:
+		return MessageSent, nil
+	case messageDeliveredStr:
+		return MessageDelivered, nil
+	case messageExecutionFailedStr:
+		return MessageExecutionFailed, nil
+	case messageExecutedStr:
+		return MessageExecuted, nil
+	default:
+		return MessageUnknown, fmt.Errorf("unknown message status %s", s)
+	}
+}
+
+func (s MessageStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *MessageStatus) UnmarshalText(text []byte) error {
+	status, err := ToMessageStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*s = status
+	return nil
+}
+
+// Message is the state of a Teleporter message, derived from the events of every indexed chain
+// that refer to it. Fields that are only known from the events of chains that aren't indexed
+// have their zero value.
+type Message struct {
+	MessageID               common.Hash
+	SourceBlockchainID      ids.ID
+	DestinationBlockchainID ids.ID
+	Nonce                   *big.Int
+	Sender                  common.Address
+	DestinationAddress      common.Address
+	RequiredGasLimit        *big.Int
+	// FeeInfo is the fee paid by the message, including any amount added after it was sent
+	FeeInfo         teleportermessenger.TeleporterFeeInfo
+	Status          MessageStatus
+	ReceiptReceived bool
+	Deliverer       common.Address
+	RewardRedeemer  common.Address
+	SendTxHash      common.Hash
+	ReceiveTxHash   common.Hash
+}
+
+// Event is an indexed Teleporter log
+type Event struct {
+	BlockchainID ids.ID
+	BlockNumber  uint64
+	BlockHash    common.Hash
+	LogIndex     uint
+	TxHash       common.Hash
+	Name         string
+	// Data is the human readable JSON encoding of the event
+	Data json.RawMessage
+}
+
+// Cursor is the last block of a chain that was indexed
+type Cursor struct {
+	BlockNumber uint64
+	BlockHash   common.Hash
+}
+
+// MessageFilter selects messages. Zero fields match any message.
+type MessageFilter struct {
+	Sender                  common.Address
+	SourceBlockchainID      ids.ID
+	DestinationBlockchainID ids.ID
+	DestinationAddress      common.Address
+	Status                  MessageStatus
+	// Limit is the maximum number of messages returned. Defaults to 100.
+	Limit  int
+	Offset int
+}
+
+// Store is a SQLite database of the Teleporter events of several chains. The events are the
+// source of truth, from which the state of each message is derived whenever they change.
+type Store struct {
+	db *sql.DB
+}
+
+// OpenStore opens the database at [path], creating it if it doesn't exist
+func OpenStore(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database %s: %w", path, err)
+	}
+	// SQLite only supports a single writer, and in memory databases are per connection
+	db.SetMaxOpenConns(1)
+	// Other processes can read the database while it is written, and wait for concurrent writes
+	if _, err := db.Exec("PRAGMA journal_mode = WAL; PRAGMA busy_timeout = 5000;"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to configure database %s: %w", path, err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Cursor returns the last indexed block of [blockchainID], or nil if none was indexed
+func (s *Store) Cursor(ctx context.Context, blockchainID ids.ID) (*Cursor, error) {
+	var (
+		cursor    Cursor
+		blockHash string
+	)
+	err := s.db.QueryRowContext(
+		ctx,
+		"SELECT block_number, block_hash FROM cursors WHERE blockchain_id = ?",
+		blockchainID.String(),
+	).Scan(&cursor.BlockNumber, &blockHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cursor.BlockHash = common.HexToHash(blockHash)
+	return &cursor, nil
+}
+
+// IndexBlocks stores the Teleporter [logs] of [blockchainID] up to [cursor], and updates the
+// messages they refer to. Logs that are already stored are replaced.
+func (s *Store) IndexBlocks(ctx context.Context, blockchainID ids.ID, logs []types.Log, cursor Cursor) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	messageIDs := make(map[common.Hash]struct{})
+	for _, log := range logs {
+		name, event, err := decodeLog(log.Topics, log.Data)
+		if err != nil {
+			return fmt.Errorf("failed to decode log %d of block %d: %w", log.Index, log.BlockNumber, err)
+		}
+		var messageID, redeemer sql.NullString
+		if id, ok := eventMessageID(event); ok {
+			messageID = sql.NullString{String: id.Hex(), Valid: true}
+			messageIDs[id] = struct{}{}
+		}
+		if redeemed, ok := event.(*teleportermessenger.TeleporterMessengerRelayerRewardsRedeemed); ok {
+			redeemer = sql.NullString{String: redeemed.Redeemer.Hex(), Valid: true}
+		}
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT OR REPLACE INTO events (
+				blockchain_id, block_number, block_hash, log_index, tx_hash, event, message_id, redeemer, topics, data
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			blockchainID.String(),
+			log.BlockNumber,
+			log.BlockHash.Hex(),
+			log.Index,
+			log.TxHash.Hex(),
+			name,
+			messageID,
+			redeemer,
+			encodeTopics(log.Topics),
+			log.Data,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store event: %w", err)
+		}
+	}
+	if err := setCursor(ctx, tx, blockchainID, &cursor); err != nil {
+		return err
+	}
+	for messageID := range messageIDs {
+		if err := refreshMessage(ctx, tx, messageID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// BlockHashes returns the blocks of [blockchainID] below [height] that have indexed events,
+// from the highest, up to [limit] blocks
+func (s *Store) BlockHashes(ctx context.Context, blockchainID ids.ID, height uint64, limit int) ([]Cursor, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT DISTINCT block_number, block_hash FROM events
+		WHERE blockchain_id = ? AND block_number < ?
+		ORDER BY block_number DESC LIMIT ?`,
+		blockchainID.String(),
+		height,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blocks []Cursor
+	for rows.Next() {
+		var (
+			block     Cursor
+			blockHash string
+		)
+		if err := rows.Scan(&block.BlockNumber, &blockHash); err != nil {
+			return nil, err
+		}
+		block.BlockHash = common.HexToHash(blockHash)
+		blocks = append(blocks, block)
+	}
+	return blocks, rows.Err()
+}
+
+// Rollback removes the events of [blockchainID] above [ancestor], the last block that wasn't
+// reorged, and updates the messages they refer to. A nil [ancestor] removes every event of
+// the chain.
+func (s *Store) Rollback(ctx context.Context, blockchainID ids.ID, ancestor *Cursor) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var height int64 = -1
+	if ancestor != nil {
+		height = int64(ancestor.BlockNumber)
+	}
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT DISTINCT message_id FROM events
+		WHERE blockchain_id = ? AND block_number > ? AND message_id IS NOT NULL`,
+		blockchainID.String(),
+		height,
+	)
+	if err != nil {
+		return err
+	}
+	var messageIDs []common.Hash
+	for rows.Next() {
+		var messageID string
+		if err := rows.Scan(&messageID); err != nil {
+			rows.Close()
+			return err
+		}
+		messageIDs = append(messageIDs, common.HexToHash(messageID))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"DELETE FROM events WHERE blockchain_id = ? AND block_number > ?",
+		blockchainID.String(),
+		height,
+	)
+	if err != nil {
+		return err
+	}
+	if err := setCursor(ctx, tx, blockchainID, ancestor); err != nil {
+		return err
+	}
+	for _, messageID := range messageIDs {
+		if err := refreshMessage(ctx, tx, messageID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// setCursor sets the cursor of [blockchainID], or removes it if [cursor] is nil
+func setCursor(ctx context.Context, tx *sql.Tx, blockchainID ids.ID, cursor *Cursor) error {
+	var err error
+	if cursor == nil {
+		_, err = tx.ExecContext(ctx, "DELETE FROM cursors WHERE blockchain_id = ?", blockchainID.String())
+	} else {
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT OR REPLACE INTO cursors (blockchain_id, block_number, block_hash) VALUES (?, ?, ?)",
+			blockchainID.String(),
+			cursor.BlockNumber,
+			cursor.BlockHash.Hex(),
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update cursor of %s: %w", blockchainID, err)
+	}
+	return nil
+}
+
+// refreshMessage derives the state of [messageID] from its events, and removes it if it has none.
+// Block numbers are only comparable within a chain, so events are applied in the order they were
+// emitted by each chain.
+func refreshMessage(ctx context.Context, tx *sql.Tx, messageID common.Hash) error {
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT blockchain_id, tx_hash, event, topics, data FROM events
+		WHERE message_id = ? ORDER BY blockchain_id, block_number, log_index`,
+		messageID.Hex(),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	message := &Message{
+		MessageID:        messageID,
+		Nonce:            new(big.Int),
+		RequiredGasLimit: new(big.Int),
+		FeeInfo:          teleportermessenger.TeleporterFeeInfo{Amount: new(big.Int)},
+	}
+	found := false
+	for rows.Next() {
+		var (
+			blockchainIDStr, txHash, name string
+			topics, data                  []byte
+		)
+		if err := rows.Scan(&blockchainIDStr, &txHash, &name, &topics, &data); err != nil {
+			return err
+		}
+		blockchainID, err := ids.FromString(blockchainIDStr)
+		if err != nil {
+			return err
+		}
+		_, event, err := decodeLog(decodeTopics(topics), data)
+		if err != nil {
+			return err
+		}
+		message.apply(blockchainID, common.HexToHash(txHash), event)
+		found = true
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if !found {
+		_, err := tx.ExecContext(ctx, "DELETE FROM messages WHERE message_id = ?", messageID.Hex())
+		return err
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT OR REPLACE INTO messages (
+			message_id, source_blockchain_id, destination_blockchain_id, nonce, sender, destination_address,
+			required_gas_limit, fee_token_address, fee_amount, status, receipt_received, deliverer,
+			reward_redeemer, send_tx_hash, receive_tx_hash
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		message.MessageID.Hex(),
+		message.SourceBlockchainID.String(),
+		message.DestinationBlockchainID.String(),
+		message.Nonce.String(),
+		message.Sender.Hex(),
+		message.DestinationAddress.Hex(),
+		message.RequiredGasLimit.String(),
+		message.FeeInfo.FeeTokenAddress.Hex(),
+		message.FeeInfo.Amount.String(),
+		message.Status.String(),
+		message.ReceiptReceived,
+		message.Deliverer.Hex(),
+		message.RewardRedeemer.Hex(),
+		message.SendTxHash.Hex(),
+		message.ReceiveTxHash.Hex(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store message %s: %w", messageID, err)
+	}
+	return nil
+}
+
+// apply updates [m] with [event], emitted by [blockchainID] in transaction [txHash]. Events must
+// be applied in the order they were emitted by each chain.
+func (m *Message) apply(blockchainID ids.ID, txHash common.Hash, event fmt.Stringer) {
+	switch e := event.(type) {
+	case *teleportermessenger.TeleporterMessengerSendCrossChainMessage:
+		m.SourceBlockchainID = blockchainID
+		m.DestinationBlockchainID = ids.ID(e.DestinationBlockchainID)
+		m.setTeleporterMessage(e.Message)
+		m.SendTxHash = txHash
+		if !m.ReceiptReceived {
+			m.setFeeInfo(e.FeeInfo)
+		}
+		m.raiseStatus(MessageSent)
+	case *teleportermessenger.TeleporterMessengerAddFeeAmount:
+		if !m.ReceiptReceived {
+			m.setFeeInfo(e.UpdatedFeeInfo)
+		}
+		m.raiseStatus(MessageSent)
+	case *teleportermessenger.TeleporterMessengerReceiveCrossChainMessage:
+		m.SourceBlockchainID = ids.ID(e.SourceBlockchainID)
+		m.DestinationBlockchainID = blockchainID
+		m.setTeleporterMessage(e.Message)
+		m.Deliverer = e.Deliverer
+		m.RewardRedeemer = e.RewardRedeemer
+		m.ReceiveTxHash = txHash
+		m.raiseStatus(MessageDelivered)
+	case *teleportermessenger.TeleporterMessengerMessageExecutionFailed:
+		m.raiseStatus(MessageExecutionFailed)
+	case *teleportermessenger.TeleporterMessengerMessageExecuted:
+		m.raiseStatus(MessageExecuted)
+	case *teleportermessenger.TeleporterMessengerReceiptReceived:
+		// The fee info of the receipt is final
+		m.SourceBlockchainID = blockchainID
+		m.DestinationBlockchainID = ids.ID(e.DestinationBlockchainID)
+		m.RewardRedeemer = e.RelayerRewardAddress
+		m.setFeeInfo(e.FeeInfo)
+		m.ReceiptReceived = true
+		m.raiseStatus(MessageDelivered)
+	}
+}
+
+func (m *Message) setTeleporterMessage(message teleportermessenger.TeleporterMessage) {
+	if message.MessageNonce != nil {
+		m.Nonce = message.MessageNonce
+	}
+	if message.RequiredGasLimit != nil {
+		m.RequiredGasLimit = message.RequiredGasLimit
+	}
+	m.Sender = message.OriginSenderAddress
+	m.DestinationAddress = message.DestinationAddress
+}
+
+func (m *Message) setFeeInfo(feeInfo teleportermessenger.TeleporterFeeInfo) {
+	m.FeeInfo.FeeTokenAddress = feeInfo.FeeTokenAddress
+	if feeInfo.Amount != nil {
+		m.FeeInfo.Amount = feeInfo.Amount
+	}
+}
+
+// raiseStatus sets the status of [m] to [status] if it is further along the lifecycle
+func (m *Message) raiseStatus(status MessageStatus) {
+	if status > m.Status {
+		m.Status = status
+	}
+}
+
+// Message returns the message [messageID]
+func (s *Store) Message(ctx context.Context, messageID common.Hash) (*Message, error) {
+	messages, err := s.queryMessages(ctx, "WHERE message_id = ?", []any{messageID.Hex()})
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMessageNotFound, messageID)
+	}
+	return messages[0], nil
+}
+
+// Messages returns the messages matching [filter], ordered by source chain and nonce
+func (s *Store) Messages(ctx context.Context, filter MessageFilter) ([]*Message, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	if filter.Sender != (common.Address{}) {
+		conditions = append(conditions, "sender = ?")
+		args = append(args, filter.Sender.Hex())
+	}
+	if filter.SourceBlockchainID != ids.Empty {
+		conditions = append(conditions, "source_blockchain_id = ?")
+		args = append(args, filter.SourceBlockchainID.String())
+	}
+	if filter.DestinationBlockchainID != ids.Empty {
+		conditions = append(conditions, "destination_blockchain_id = ?")
+		args = append(args, filter.DestinationBlockchainID.String())
+	}
+	if filter.DestinationAddress != (common.Address{}) {
+		conditions = append(conditions, "destination_address = ?")
+		args = append(args, filter.DestinationAddress.Hex())
+	}
+	if filter.Status != MessageUnknown {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status.String())
+	}
+	var clauses string
+	if len(conditions) != 0 {
+		clauses = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultQueryLimit
+	}
+	// Nonces are stored as decimal strings, which sort numerically by length first
+	clauses += " ORDER BY source_blockchain_id, length(nonce), nonce LIMIT ? OFFSET ?"
+	args = append(args, limit, filter.Offset)
+	return s.queryMessages(ctx, clauses, args)
+}
+
+func (s *Store) queryMessages(ctx context.Context, clauses string, args []any) ([]*Message, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT
+			message_id, source_blockchain_id, destination_blockchain_id, nonce, sender, destination_address,
+			required_gas_limit, fee_token_address, fee_amount, status, receipt_received, deliverer,
+			reward_redeemer, send_tx_hash, receive_tx_hash
+		FROM messages `+clauses,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*Message
+	for rows.Next() {
+		var (
+			messageID, sourceID, destinationID, nonce, sender, destinationAddress string
+			requiredGasLimit, feeToken, feeAmount, status                         string
+			deliverer, rewardRedeemer, sendTxHash, receiveTxHash                  string
+			message                                                               Message
+		)
+		err := rows.Scan(
+			&messageID,
+			&sourceID,
+			&destinationID,
+			&nonce,
+			&sender,
+			&destinationAddress,
+			&requiredGasLimit,
+			&feeToken,
+			&feeAmount,
+			&status,
+			&message.ReceiptReceived,
+			&deliverer,
+			&rewardRedeemer,
+			&sendTxHash,
+			&receiveTxHash,
+		)
+		if err != nil {
+			return nil, err
+		}
+		message.MessageID = common.HexToHash(messageID)
+		if message.SourceBlockchainID, err = ids.FromString(sourceID); err != nil {
+			return nil, err
+		}
+		if message.DestinationBlockchainID, err = ids.FromString(destinationID); err != nil {
+			return nil, err
+		}
+		if message.Status, err = ToMessageStatus(status); err != nil {
+			return nil, err
+		}
+		message.Nonce, _ = new(big.Int).SetString(nonce, 10)
+		message.RequiredGasLimit, _ = new(big.Int).SetString(requiredGasLimit, 10)
+		message.FeeInfo.Amount, _ = new(big.Int).SetString(feeAmount, 10)
+		message.FeeInfo.FeeTokenAddress = common.HexToAddress(feeToken)
+		message.Sender = common.HexToAddress(sender)
+		message.DestinationAddress = common.HexToAddress(destinationAddress)
+		message.Deliverer = common.HexToAddress(deliverer)
+		message.RewardRedeemer = common.HexToAddress(rewardRedeemer)
+		message.SendTxHash = common.HexToHash(sendTxHash)
+		message.ReceiveTxHash = common.HexToHash(receiveTxHash)
+		messages = append(messages, &message)
+	}
+	return messages, rows.Err()
+}
+
+// MessageEvents returns the events of every indexed chain that refer to [messageID]
+func (s *Store) MessageEvents(ctx context.Context, messageID common.Hash) ([]*Event, error) {
+	return s.queryEvents(ctx, "WHERE message_id = ?", messageID.Hex())
+}
+
+// Redemptions returns the RelayerRewardsRedeemed events of [redeemer] on every indexed chain
+func (s *Store) Redemptions(ctx context.Context, redeemer common.Address) ([]*Event, error) {
+	return s.queryEvents(ctx, "WHERE redeemer = ?", redeemer.Hex())
+}
+
+func (s *Store) queryEvents(ctx context.Context, clauses string, args ...any) ([]*Event, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT blockchain_id, block_number, block_hash, log_index, tx_hash, event, topics, data
+		FROM events `+clauses+` ORDER BY blockchain_id, block_number, log_index`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*Event
+	for rows.Next() {
+		var (
+			event                           Event
+			blockchainID, blockHash, txHash string
+			topics, data                    []byte
+		)
+		err := rows.Scan(
+			&blockchainID,
+			&event.BlockNumber,
+			&blockHash,
+			&event.LogIndex,
+			&txHash,
+			&event.Name,
+			&topics,
+			&data,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if event.BlockchainID, err = ids.FromString(blockchainID); err != nil {
+			return nil, err
+		}
+		event.BlockHash = common.HexToHash(blockHash)
+		event.TxHash = common.HexToHash(txHash)
+		_, decoded, err := decodeLog(decodeTopics(topics), data)
+		if err != nil {
+			return nil, err
+		}
+		if event.Data, err = eventData(decoded); err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+	return events, rows.Err()
+}
+
+func encodeTopics(topics []common.Hash) []byte {
+	encoded := make([]byte, 0, len(topics)*common.HashLength)
+	for _, topic := range topics {
+		encoded = append(encoded, topic[:]...)
+	}
+	return encoded
+}
+
+func decodeTopics(encoded []byte) []common.Hash {
+	topics := make([]common.Hash, 0, len(encoded)/common.HashLength)
+	for i := 0; i+common.HashLength <= len(encoded); i += common.HashLength {
+		topics = append(topics, common.BytesToHash(encoded[i:i+common.HashLength]))
+	}
+	return topics
+}
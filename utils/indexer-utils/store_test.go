@@ -0,0 +1,209 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testSourceID      = ids.GenerateTestID()
+	testDestinationID = ids.GenerateTestID()
+	testSender        = common.HexToAddress("0x5e4d")
+	testDestination   = common.HexToAddress("0xde57")
+	testRelayer       = common.HexToAddress("0x7e1a")
+	testFeeToken      = common.HexToAddress("0xfee")
+)
+
+func newTestStore(t *testing.T) *Store {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "index.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+	})
+	return store
+}
+
+// newTestLog returns the log of the Teleporter event [name] with [args], in declaration order
+func newTestLog(t *testing.T, blockNumber uint64, index uint, name string, args ...any) types.Log {
+	event, ok := teleporterABI.Events[name]
+	require.True(t, ok)
+	require.Len(t, args, len(event.Inputs))
+
+	var indexed [][]any
+	var nonIndexed []any
+	for i, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, []any{args[i]})
+		} else {
+			nonIndexed = append(nonIndexed, args[i])
+		}
+	}
+	topics, err := abi.MakeTopics(indexed...)
+	require.NoError(t, err)
+	data, err := event.Inputs.NonIndexed().Pack(nonIndexed...)
+	require.NoError(t, err)
+
+	log := types.Log{
+		Topics:      []common.Hash{event.ID},
+		Data:        data,
+		BlockNumber: blockNumber,
+		BlockHash:   common.BigToHash(new(big.Int).SetUint64(blockNumber)),
+		TxHash:      common.BigToHash(new(big.Int).SetUint64(blockNumber)),
+		Index:       index,
+	}
+	for _, topic := range topics {
+		log.Topics = append(log.Topics, topic[0])
+	}
+	return log
+}
+
+func newTestCursor(blockNumber uint64) Cursor {
+	return Cursor{BlockNumber: blockNumber, BlockHash: common.BigToHash(new(big.Int).SetUint64(blockNumber))}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	messageID := common.HexToHash("0x1d")
+	message := teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(7),
+		OriginSenderAddress:     testSender,
+		DestinationBlockchainID: testDestinationID,
+		DestinationAddress:      testDestination,
+		RequiredGasLimit:        big.NewInt(100_000),
+		AllowedRelayerAddresses: []common.Address{},
+		Receipts:                []teleportermessenger.TeleporterMessageReceipt{},
+		Message:                 []byte{1, 2, 3},
+	}
+	feeInfo := func(amount int64) teleportermessenger.TeleporterFeeInfo {
+		return teleportermessenger.TeleporterFeeInfo{FeeTokenAddress: testFeeToken, Amount: big.NewInt(amount)}
+	}
+	requireStatus := func(status MessageStatus) *Message {
+		indexed, err := store.Message(ctx, messageID)
+		require.NoError(t, err)
+		require.Equal(t, status, indexed.Status)
+		return indexed
+	}
+
+	// The message is sent, and its fee is increased
+	require.NoError(t, store.IndexBlocks(ctx, testSourceID, []types.Log{
+		newTestLog(t, 10, 0, "SendCrossChainMessage", messageID, testDestinationID, message, feeInfo(100)),
+		newTestLog(t, 11, 0, "AddFeeAmount", messageID, feeInfo(150)),
+	}, newTestCursor(12)))
+	indexed := requireStatus(MessageSent)
+	require.Equal(t, testSourceID, indexed.SourceBlockchainID)
+	require.Equal(t, testDestinationID, indexed.DestinationBlockchainID)
+	require.Equal(t, big.NewInt(7), indexed.Nonce)
+	require.Equal(t, testSender, indexed.Sender)
+	require.Equal(t, testDestination, indexed.DestinationAddress)
+	require.Equal(t, big.NewInt(100_000), indexed.RequiredGasLimit)
+	require.Equal(t, feeInfo(150), indexed.FeeInfo)
+	require.False(t, indexed.ReceiptReceived)
+	cursor, err := store.Cursor(ctx, testSourceID)
+	require.NoError(t, err)
+	require.Equal(t, newTestCursor(12), *cursor)
+
+	// Its execution fails when it is received, and is retried successfully
+	require.NoError(t, store.IndexBlocks(ctx, testDestinationID, []types.Log{
+		newTestLog(t, 5, 0, "ReceiveCrossChainMessage", messageID, testSourceID, testRelayer, testRelayer, message),
+		newTestLog(t, 5, 1, "MessageExecutionFailed", messageID, testSourceID, message),
+	}, newTestCursor(5)))
+	indexed = requireStatus(MessageExecutionFailed)
+	require.Equal(t, testRelayer, indexed.Deliverer)
+	require.NoError(t, store.IndexBlocks(ctx, testDestinationID, []types.Log{
+		newTestLog(t, 7, 0, "MessageExecuted", messageID, testSourceID),
+	}, newTestCursor(8)))
+	requireStatus(MessageExecuted)
+
+	// Its receipt is returned, and the relayer redeems its reward
+	require.NoError(t, store.IndexBlocks(ctx, testSourceID, []types.Log{
+		newTestLog(t, 20, 0, "ReceiptReceived", messageID, testDestinationID, testRelayer, feeInfo(150)),
+		newTestLog(t, 21, 0, "RelayerRewardsRedeemed", testRelayer, testFeeToken, big.NewInt(150)),
+	}, newTestCursor(21)))
+	indexed = requireStatus(MessageExecuted)
+	require.True(t, indexed.ReceiptReceived)
+	require.Equal(t, testRelayer, indexed.RewardRedeemer)
+	redemptions, err := store.Redemptions(ctx, testRelayer)
+	require.NoError(t, err)
+	require.Len(t, redemptions, 1)
+	require.Equal(t, "RelayerRewardsRedeemed", redemptions[0].Name)
+	require.Equal(t, testSourceID, redemptions[0].BlockchainID)
+
+	events, err := store.MessageEvents(ctx, messageID)
+	require.NoError(t, err)
+	require.Len(t, events, 6)
+	for _, event := range events {
+		if event.Name == "SendCrossChainMessage" {
+			require.Equal(t, testSourceID, event.BlockchainID)
+			require.Contains(t, strings.ToLower(string(event.Data)), strings.ToLower(testSender.Hex()))
+			require.NotContains(t, string(event.Data), `"Raw"`)
+			require.True(t, json.Valid(event.Data))
+		}
+	}
+
+	for _, filter := range []MessageFilter{
+		{},
+		{Sender: testSender},
+		{SourceBlockchainID: testSourceID, DestinationBlockchainID: testDestinationID},
+		{DestinationAddress: testDestination, Status: MessageExecuted},
+	} {
+		messages, err := store.Messages(ctx, filter)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		require.Equal(t, messageID, messages[0].MessageID)
+	}
+	for _, filter := range []MessageFilter{
+		{Sender: testDestination},
+		{Status: MessageSent},
+		{Offset: 1},
+	} {
+		messages, err := store.Messages(ctx, filter)
+		require.NoError(t, err)
+		require.Empty(t, messages)
+	}
+
+	// The successful retry is reorged out
+	blocks, err := store.BlockHashes(ctx, testDestinationID, 8, 10)
+	require.NoError(t, err)
+	require.Equal(t, []Cursor{newTestCursor(7), newTestCursor(5)}, blocks)
+	ancestor := newTestCursor(5)
+	require.NoError(t, store.Rollback(ctx, testDestinationID, &ancestor))
+	requireStatus(MessageExecutionFailed)
+	cursor, err = store.Cursor(ctx, testDestinationID)
+	require.NoError(t, err)
+	require.Equal(t, ancestor, *cursor)
+
+	// Messages without events are removed
+	require.NoError(t, store.Rollback(ctx, testSourceID, nil))
+	requireStatus(MessageExecutionFailed)
+	require.NoError(t, store.Rollback(ctx, testDestinationID, nil))
+	_, err = store.Message(ctx, messageID)
+	require.ErrorIs(t, err, ErrMessageNotFound)
+	cursor, err = store.Cursor(ctx, testDestinationID)
+	require.NoError(t, err)
+	require.Nil(t, cursor)
+}
+
+func TestMessageStatus(t *testing.T) {
+	for _, status := range []MessageStatus{MessageSent, MessageDelivered, MessageExecutionFailed, MessageExecuted} {
+		parsed, err := ToMessageStatus(status.String())
+		require.NoError(t, err)
+		require.Equal(t, status, parsed)
+	}
+	_, err := ToMessageStatus(MessageUnknown.String())
+	require.Error(t, err)
+}
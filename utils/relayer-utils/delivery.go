@@ -0,0 +1,219 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	gasUtils "github.com/ava-labs/icm-contracts/utils/gas-utils"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	predicateutils "github.com/ava-labs/subnet-evm/predicate"
+	subnetEvmUtils "github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const receiptPollInterval = 200 * time.Millisecond
+
+// ReceiveMessageGasLimit returns the gas limit of a receiveCrossChainMessage transaction
+// delivering [message] in [signedMessage]
+func ReceiveMessageGasLimit(
+	signedMessage *avalancheWarp.Message,
+	message teleportermessenger.TeleporterMessage,
+) (uint64, error) {
+	numSigners, err := signedMessage.Signature.NumSigners()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count signers: %w", err)
+	}
+	return gasUtils.CalculateReceiveMessageGasLimit(
+		numSigners,
+		message.RequiredGasLimit,
+		len(signedMessage.Bytes()),
+		len(signedMessage.Payload),
+		len(message.Receipts),
+	)
+}
+
+// deliver sends a receiveCrossChainMessage transaction delivering [signedMessage] to
+// [destination], and waits for it to be accepted
+func (r *Relayer) deliver(
+	ctx context.Context,
+	destination *relayerChain,
+	signedMessage *avalancheWarp.Message,
+	gasLimit uint64,
+	price *gasUtils.TxPrice,
+) (*types.Receipt, error) {
+	callData, err := teleportermessenger.PackReceiveCrossChainMessage(0, r.config.RewardAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := sendTransaction(ctx, destination, r.config.Key, func(nonce uint64) *types.Transaction {
+		return predicateutils.NewPredicateTx(
+			destination.EVMChainID,
+			nonce,
+			&destination.TeleporterAddress,
+			gasLimit,
+			price.GasFeeCap,
+			price.GasTipCap,
+			big.NewInt(0),
+			callData,
+			types.AccessList{},
+			warp.ContractAddress,
+			signedMessage.Bytes(),
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := waitForReceipt(ctx, destination.Client, tx.Hash(), r.config.ReceiptTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("%w: transaction %s", ErrDeliveryReverted, tx.Hash())
+	}
+	return receipt, nil
+}
+
+// estimateDeliveryGas returns the gas expected to be used by a receiveCrossChainMessage
+// transaction delivering [signedMessage] to [destination], or 0 if it can't be simulated
+func (r *Relayer) estimateDeliveryGas(
+	ctx context.Context,
+	destination *relayerChain,
+	signedMessage *avalancheWarp.Message,
+) uint64 {
+	callData, err := teleportermessenger.PackReceiveCrossChainMessage(0, r.config.RewardAddress)
+	if err != nil {
+		return 0
+	}
+	gas, err := destination.Client.EstimateGas(ctx, interfaces.CallMsg{
+		From: r.address,
+		To:   &destination.TeleporterAddress,
+		Data: callData,
+		AccessList: types.AccessList{
+			{
+				Address:     warp.ContractAddress,
+				StorageKeys: subnetEvmUtils.BytesToHashSlice(predicateutils.PackPredicate(signedMessage.Bytes())),
+			},
+		},
+	})
+	if err != nil {
+		log.Debug("Failed to estimate delivery gas", "blockchainID", destination.BlockchainID, "err", err)
+		return 0
+	}
+	return gas
+}
+
+// sendTransaction signs the transaction built by [newTx] with [key] and its next nonce on [c],
+// and sends it
+func sendTransaction(
+	ctx context.Context,
+	c *relayerChain,
+	key *ecdsa.PrivateKey,
+	newTx func(nonce uint64) *types.Transaction,
+) (*types.Transaction, error) {
+	c.nonces.lock.Lock()
+	defer c.nonces.lock.Unlock()
+
+	nonce, err := c.nonces.next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := types.SignTx(newTx(nonce), types.LatestSignerForChainID(c.EVMChainID), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	if err := c.Client.SendTransaction(ctx, tx); err != nil {
+		// The nonce may not have been used, or may have been used by another sender of the key
+		c.nonces.reset()
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+	c.nonces.commit()
+	return tx, nil
+}
+
+// waitForReceipt waits up to [timeout] for the transaction [txHash] to be accepted
+func waitForReceipt(
+	ctx context.Context,
+	client ethclient.Client,
+	txHash common.Hash,
+	timeout time.Duration,
+) (*types.Receipt, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+	for {
+		receipt, err := client.TransactionReceipt(ctx, txHash)
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, interfaces.NotFound) {
+			return nil, fmt.Errorf("failed to get receipt of %s: %w", txHash, err)
+		}
+		log.Debug("Transaction not yet accepted", "txHash", txHash)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("transaction %s not accepted: %w", txHash, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// nonceManager tracks the nonce of an address on a chain, so that transactions can be sent
+// without waiting for the previous ones to be accepted. Senders must hold its lock from next until
+// commit or reset.
+type nonceManager struct {
+	lock    sync.Mutex
+	client  ethclient.Client
+	address common.Address
+	nonce   uint64
+	synced  bool
+}
+
+func newNonceManager(client ethclient.Client, address common.Address) *nonceManager {
+	return &nonceManager{
+		client:  client,
+		address: address,
+	}
+}
+
+// next returns the nonce of the next transaction, reading it from the chain's pending state if
+// it isn't tracked
+func (m *nonceManager) next(ctx context.Context) (uint64, error) {
+	if !m.synced {
+		nonce, err := m.client.PendingNonceAt(ctx, m.address)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get nonce of %s: %w", m.address, err)
+		}
+		m.nonce = nonce
+		m.synced = true
+	}
+	return m.nonce, nil
+}
+
+// commit records that the nonce returned by next was used
+func (m *nonceManager) commit() {
+	m.nonce++
+}
+
+// reset makes the next call to next read the nonce from the chain
+func (m *nonceManager) reset() {
+	m.synced = false
+}
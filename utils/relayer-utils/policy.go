@@ -0,0 +1,307 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// nativeTokenDecimals is the number of decimals of the native token of EVM chains
+const nativeTokenDecimals = 18
+
+var (
+	ErrDeniedDestination     = errors.New("destination contract is denied")
+	ErrDestinationNotAllowed = errors.New("destination contract is not allowed")
+	ErrFeeBelowMinimum       = errors.New("fee is below the minimum")
+	ErrNoFee                 = errors.New("message pays no fee")
+	ErrUnprofitable          = errors.New("expected profit is below the minimum")
+	ErrUnknownPrice          = errors.New("unknown token price")
+)
+
+// ChainAddress is an address on a specific chain, such as a fee token on the source chain of a
+// message or a contract on its destination chain
+type ChainAddress struct {
+	BlockchainID ids.ID
+	Address      common.Address
+}
+
+// PriceOracle values tokens in a common quote currency, so that the fees paid by messages can be
+// compared to the gas spent delivering them
+type PriceOracle interface {
+	// FeeTokenValue returns the value of [amount] of the ERC20 [token] of [blockchainID]
+	FeeTokenValue(ctx context.Context, blockchainID ids.ID, token common.Address, amount *big.Int) (*big.Float, error)
+	// NativeTokenValue returns the value of [amount] wei of the native token of [blockchainID]
+	NativeTokenValue(ctx context.Context, blockchainID ids.ID, amount *big.Int) (*big.Float, error)
+}
+
+// PolicyConfig configures a Policy. The zero value relays every message in the order it was sent.
+type PolicyConfig struct {
+	// AllowedDestinations, if not empty, are the only destination contracts messages are relayed to
+	AllowedDestinations []ChainAddress
+	// DeniedDestinations are destination contracts messages are never relayed to
+	DeniedDestinations []ChainAddress
+	// RequireFee skips messages that pay no fee, whatever their fee token
+	RequireFee bool
+	// MinFees are the minimum fee amounts of messages paying fees in each token, on the source
+	// chain of the message. Fee tokens that aren't listed have no minimum.
+	MinFees map[ChainAddress]*big.Int
+	// MinProfit, if set, is the minimum value of the fee of a message less the cost of the gas
+	// used to deliver it, in the quote currency of the oracle. Requires an oracle.
+	MinProfit *big.Float
+}
+
+// Candidate is a message that a relayer could deliver
+type Candidate struct {
+	MessageID               ids.ID
+	SourceBlockchainID      ids.ID
+	DestinationBlockchainID ids.ID
+	Message                 teleportermessenger.TeleporterMessage
+	FeeInfo                 teleportermessenger.TeleporterFeeInfo
+	// GasLimit is the gas limit of the delivery transaction, eg from ReceiveMessageGasLimit
+	GasLimit uint64
+	// GasUsed is the gas expected to be used by the delivery transaction, eg from eth_estimateGas.
+	// GasLimit is used if zero.
+	GasUsed uint64
+	// GasPrice is the price per unit of gas expected to be paid by the delivery transaction, the
+	// base fee plus the tip within the fee cap, in wei
+	GasPrice *big.Int
+}
+
+// Evaluation is the outcome of a Policy for a Candidate
+type Evaluation struct {
+	*Candidate
+	// FeeValue, GasCostValue and Profit are nil if the policy has no oracle, or the message could
+	// not be valued
+	FeeValue     *big.Float
+	GasCostValue *big.Float
+	Profit       *big.Float
+	// Skip is the reason the message should not be relayed, or nil if it should
+	Skip error
+}
+
+// Policy decides which messages are worth relaying, and in which order
+type Policy struct {
+	config              PolicyConfig
+	oracle              PriceOracle
+	allowedDestinations map[ChainAddress]struct{}
+	deniedDestinations  map[ChainAddress]struct{}
+}
+
+// NewPolicy returns a policy applying [config]. [oracle] may be nil if config.MinProfit is not set,
+// in which case messages are not valued.
+func NewPolicy(config PolicyConfig, oracle PriceOracle) (*Policy, error) {
+	if config.MinProfit != nil && oracle == nil {
+		return nil, fmt.Errorf("%w: a minimum profit requires a price oracle", ErrInvalidConfig)
+	}
+	p := &Policy{
+		config:              config,
+		oracle:              oracle,
+		allowedDestinations: make(map[ChainAddress]struct{}, len(config.AllowedDestinations)),
+		deniedDestinations:  make(map[ChainAddress]struct{}, len(config.DeniedDestinations)),
+	}
+	for _, destination := range config.AllowedDestinations {
+		p.allowedDestinations[destination] = struct{}{}
+	}
+	for _, destination := range config.DeniedDestinations {
+		p.deniedDestinations[destination] = struct{}{}
+	}
+	return p, nil
+}
+
+// Evaluate decides whether [candidate] should be relayed. Messages that the oracle fails to
+// value, eg because their fee token has no price, are skipped with the oracle's error as the
+// reason. An error is only returned if [ctx] is done.
+func (p *Policy) Evaluate(ctx context.Context, candidate *Candidate) (*Evaluation, error) {
+	evaluation := &Evaluation{Candidate: candidate}
+	evaluation.Skip = p.CheckDestination(ChainAddress{
+		BlockchainID: candidate.DestinationBlockchainID,
+		Address:      candidate.Message.DestinationAddress,
+	})
+	if evaluation.Skip != nil {
+		return evaluation, nil
+	}
+
+	feeToken := ChainAddress{
+		BlockchainID: candidate.SourceBlockchainID,
+		Address:      candidate.FeeInfo.FeeTokenAddress,
+	}
+	feeAmount := candidate.FeeInfo.Amount
+	if feeAmount == nil {
+		feeAmount = new(big.Int)
+	}
+	if p.config.RequireFee && feeAmount.Sign() == 0 {
+		evaluation.Skip = ErrNoFee
+		return evaluation, nil
+	}
+	if minFee, ok := p.config.MinFees[feeToken]; ok && feeAmount.Cmp(minFee) < 0 {
+		evaluation.Skip = fmt.Errorf("%w: %s < %s", ErrFeeBelowMinimum, feeAmount, minFee)
+		return evaluation, nil
+	}
+
+	if p.oracle == nil {
+		return evaluation, nil
+	}
+	evaluation.FeeValue = new(big.Float)
+	if feeAmount.Sign() != 0 {
+		feeValue, err := p.oracle.FeeTokenValue(ctx, feeToken.BlockchainID, feeToken.Address, feeAmount)
+		if err != nil {
+			return skipUnvalued(ctx, evaluation, fmt.Errorf("failed to value fee: %w", err))
+		}
+		evaluation.FeeValue = feeValue
+	}
+	gasUsed := candidate.GasUsed
+	if gasUsed == 0 {
+		gasUsed = candidate.GasLimit
+	}
+	gasCost := new(big.Int).SetUint64(gasUsed)
+	if candidate.GasPrice != nil {
+		gasCost.Mul(gasCost, candidate.GasPrice)
+	}
+	gasCostValue, err := p.oracle.NativeTokenValue(ctx, candidate.DestinationBlockchainID, gasCost)
+	if err != nil {
+		return skipUnvalued(ctx, evaluation, fmt.Errorf("failed to value gas cost: %w", err))
+	}
+	evaluation.GasCostValue = gasCostValue
+	evaluation.Profit = new(big.Float).Sub(evaluation.FeeValue, gasCostValue)
+	if p.config.MinProfit != nil && evaluation.Profit.Cmp(p.config.MinProfit) < 0 {
+		evaluation.Skip = fmt.Errorf("%w: %s < %s", ErrUnprofitable, evaluation.Profit, p.config.MinProfit)
+	}
+	return evaluation, nil
+}
+
+// CheckDestination returns the reason messages to the [destination] contract should not be
+// relayed, or nil if they may be. Unlike Evaluate, it doesn't require the message to be signed
+// and priced, so that relayers can skip messages before doing so.
+func (p *Policy) CheckDestination(destination ChainAddress) error {
+	if _, ok := p.deniedDestinations[destination]; ok {
+		return fmt.Errorf("%w: %s", ErrDeniedDestination, destination.Address)
+	}
+	if _, ok := p.allowedDestinations[destination]; !ok && len(p.allowedDestinations) != 0 {
+		return fmt.Errorf("%w: %s", ErrDestinationNotAllowed, destination.Address)
+	}
+	return nil
+}
+
+// skipUnvalued skips the message of [evaluation] because valuing it failed with [err], unless
+// the failure is due to [ctx] being done
+func skipUnvalued(ctx context.Context, evaluation *Evaluation, err error) (*Evaluation, error) {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	evaluation.FeeValue = nil
+	evaluation.Skip = err
+	return evaluation, nil
+}
+
+// Prioritize evaluates [candidates], and returns the evaluations of the messages that should be
+// relayed ordered by decreasing expected profit, followed by those of the skipped messages.
+// Messages with the same profit, or that aren't valued, keep the order of [candidates]. An error
+// is only returned if [ctx] is done.
+func (p *Policy) Prioritize(ctx context.Context, candidates []*Candidate) ([]*Evaluation, error) {
+	evaluations := make([]*Evaluation, 0, len(candidates))
+	for _, candidate := range candidates {
+		evaluation, err := p.Evaluate(ctx, candidate)
+		if err != nil {
+			return nil, err
+		}
+		evaluations = append(evaluations, evaluation)
+	}
+	slices.SortStableFunc(evaluations, func(a, b *Evaluation) int {
+		if (a.Skip == nil) != (b.Skip == nil) {
+			if a.Skip == nil {
+				return -1
+			}
+			return 1
+		}
+		if a.Skip != nil || a.Profit == nil || b.Profit == nil {
+			return 0
+		}
+		return b.Profit.Cmp(a.Profit)
+	})
+	return evaluations, nil
+}
+
+// TokenPrice is the price of a whole token with [Decimals] decimals, in the quote currency
+type TokenPrice struct {
+	Price    *big.Float
+	Decimals uint8
+}
+
+// value returns the value of [amount] of the token's smallest unit
+func (t TokenPrice) value(amount *big.Int) *big.Float {
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(t.Decimals)), nil))
+	value := new(big.Float).SetInt(amount)
+	value.Mul(value, t.Price)
+	return value.Quo(value, scale)
+}
+
+var _ PriceOracle = (*StaticPriceOracle)(nil)
+
+// StaticPriceOracle is a PriceOracle with fixed prices. It is safe for concurrent use, so that
+// prices can be updated from an external feed while a relayer is running.
+type StaticPriceOracle struct {
+	lock         sync.RWMutex
+	feeTokens    map[ChainAddress]TokenPrice
+	nativeTokens map[ids.ID]TokenPrice
+}
+
+func NewStaticPriceOracle() *StaticPriceOracle {
+	return &StaticPriceOracle{
+		feeTokens:    make(map[ChainAddress]TokenPrice),
+		nativeTokens: make(map[ids.ID]TokenPrice),
+	}
+}
+
+// SetFeeTokenPrice sets the price of the ERC20 [token] of [blockchainID]
+func (o *StaticPriceOracle) SetFeeTokenPrice(blockchainID ids.ID, token common.Address, price TokenPrice) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.feeTokens[ChainAddress{BlockchainID: blockchainID, Address: token}] = price
+}
+
+// SetNativeTokenPrice sets the price of a whole native token of [blockchainID], which has 18
+// decimals
+func (o *StaticPriceOracle) SetNativeTokenPrice(blockchainID ids.ID, price *big.Float) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.nativeTokens[blockchainID] = TokenPrice{Price: price, Decimals: nativeTokenDecimals}
+}
+
+func (o *StaticPriceOracle) FeeTokenValue(
+	_ context.Context,
+	blockchainID ids.ID,
+	token common.Address,
+	amount *big.Int,
+) (*big.Float, error) {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+	price, ok := o.feeTokens[ChainAddress{BlockchainID: blockchainID, Address: token}]
+	if !ok {
+		return nil, fmt.Errorf("%w: fee token %s of %s", ErrUnknownPrice, token, blockchainID)
+	}
+	return price.value(amount), nil
+}
+
+func (o *StaticPriceOracle) NativeTokenValue(
+	_ context.Context,
+	blockchainID ids.ID,
+	amount *big.Int,
+) (*big.Float, error) {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+	price, ok := o.nativeTokens[blockchainID]
+	if !ok {
+		return nil, fmt.Errorf("%w: native token of %s", ErrUnknownPrice, blockchainID)
+	}
+	return price.value(amount), nil
+}
@@ -0,0 +1,224 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testSourceID      = ids.GenerateTestID()
+	testDestinationID = ids.GenerateTestID()
+	testFeeToken      = common.HexToAddress("0xfee")
+)
+
+// newTestCandidate returns a message paying [fee] whole fee tokens, with a delivery using
+// [gasLimit] gas at 1 gwei
+func newTestCandidate(fee int64, gasLimit uint64) *Candidate {
+	return &Candidate{
+		MessageID:               ids.GenerateTestID(),
+		SourceBlockchainID:      testSourceID,
+		DestinationBlockchainID: testDestinationID,
+		Message: teleportermessenger.TeleporterMessage{
+			DestinationAddress: common.HexToAddress("0x1"),
+		},
+		FeeInfo: teleportermessenger.TeleporterFeeInfo{
+			FeeTokenAddress: testFeeToken,
+			Amount:          new(big.Int).Mul(big.NewInt(fee), big.NewInt(1e6)),
+		},
+		GasLimit: gasLimit,
+		GasPrice: big.NewInt(1e9),
+	}
+}
+
+func withGasUsed(candidate *Candidate, gasUsed uint64) *Candidate {
+	candidate.GasUsed = gasUsed
+	return candidate
+}
+
+func withoutFeeToken(candidate *Candidate) *Candidate {
+	candidate.FeeInfo.FeeTokenAddress = common.Address{}
+	return candidate
+}
+
+func newTestOracle() *StaticPriceOracle {
+	oracle := NewStaticPriceOracle()
+	// A 6 decimal stablecoin, and a native token worth 20
+	oracle.SetFeeTokenPrice(testSourceID, testFeeToken, TokenPrice{Price: big.NewFloat(1), Decimals: 6})
+	oracle.SetNativeTokenPrice(testDestinationID, big.NewFloat(20))
+	return oracle
+}
+
+func TestStaticPriceOracle(t *testing.T) {
+	ctx := context.Background()
+	oracle := newTestOracle()
+
+	value, err := oracle.FeeTokenValue(ctx, testSourceID, testFeeToken, big.NewInt(2_500_000))
+	require.NoError(t, err)
+	require.Zero(t, value.Cmp(big.NewFloat(2.5)))
+	value, err = oracle.NativeTokenValue(ctx, testDestinationID, big.NewInt(5e17))
+	require.NoError(t, err)
+	require.Zero(t, value.Cmp(big.NewFloat(10)))
+
+	_, err = oracle.FeeTokenValue(ctx, testDestinationID, testFeeToken, big.NewInt(1))
+	require.ErrorIs(t, err, ErrUnknownPrice)
+	_, err = oracle.NativeTokenValue(ctx, testSourceID, big.NewInt(1))
+	require.ErrorIs(t, err, ErrUnknownPrice)
+}
+
+func TestPolicyEvaluate(t *testing.T) {
+	ctx := context.Background()
+	destination := ChainAddress{BlockchainID: testDestinationID, Address: common.HexToAddress("0x1")}
+	tests := []struct {
+		name      string
+		config    PolicyConfig
+		candidate *Candidate
+		skip      error
+	}{
+		{
+			name:      "no restrictions",
+			candidate: newTestCandidate(0, 1_000_000),
+		},
+		{
+			name:      "denied destination",
+			config:    PolicyConfig{DeniedDestinations: []ChainAddress{destination}},
+			candidate: newTestCandidate(1, 1),
+			skip:      ErrDeniedDestination,
+		},
+		{
+			name: "destination not allowed",
+			config: PolicyConfig{AllowedDestinations: []ChainAddress{
+				{BlockchainID: testDestinationID, Address: common.HexToAddress("0x2")},
+			}},
+			candidate: newTestCandidate(1, 1),
+			skip:      ErrDestinationNotAllowed,
+		},
+		{
+			name:      "allowed destination",
+			config:    PolicyConfig{AllowedDestinations: []ChainAddress{destination}},
+			candidate: newTestCandidate(1, 1),
+		},
+		{
+			name: "fee below minimum",
+			config: PolicyConfig{MinFees: map[ChainAddress]*big.Int{
+				{BlockchainID: testSourceID, Address: testFeeToken}: big.NewInt(2e6),
+			}},
+			candidate: newTestCandidate(1, 1),
+			skip:      ErrFeeBelowMinimum,
+		},
+		{
+			// 100,000 gas at 1 gwei costs 0.0001 native tokens, worth 0.002
+			name:      "profitable",
+			config:    PolicyConfig{MinProfit: big.NewFloat(0)},
+			candidate: newTestCandidate(1, 100_000),
+		},
+		{
+			// 100,000,000 gas at 1 gwei costs 0.1 native tokens, worth 2
+			name:      "unprofitable",
+			config:    PolicyConfig{MinProfit: big.NewFloat(0)},
+			candidate: newTestCandidate(1, 100_000_000),
+			skip:      ErrUnprofitable,
+		},
+		{
+			// Only the gas expected to be used is paid for, not the whole gas limit
+			name:      "profitable gas used",
+			config:    PolicyConfig{MinProfit: big.NewFloat(0)},
+			candidate: withGasUsed(newTestCandidate(1, 100_000_000), 100_000),
+		},
+		{
+			name:      "no fee",
+			config:    PolicyConfig{RequireFee: true},
+			candidate: withoutFeeToken(newTestCandidate(0, 1)),
+			skip:      ErrNoFee,
+		},
+		{
+			// A message without a fee token is not subject to the minimum fee of any token
+			name: "no fee with minimum fees",
+			config: PolicyConfig{RequireFee: true, MinFees: map[ChainAddress]*big.Int{
+				{BlockchainID: testSourceID, Address: testFeeToken}: big.NewInt(1),
+			}},
+			candidate: withoutFeeToken(newTestCandidate(0, 1)),
+			skip:      ErrNoFee,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy, err := NewPolicy(test.config, newTestOracle())
+			require.NoError(t, err)
+			evaluation, err := policy.Evaluate(ctx, test.candidate)
+			require.NoError(t, err)
+			require.ErrorIs(t, evaluation.Skip, test.skip)
+		})
+	}
+
+	_, err := NewPolicy(PolicyConfig{MinProfit: big.NewFloat(0)}, nil)
+	require.ErrorIs(t, err, ErrInvalidConfig)
+
+	// Destinations are checked on their own before messages are signed and priced
+	policy, err := NewPolicy(PolicyConfig{DeniedDestinations: []ChainAddress{destination}}, nil)
+	require.NoError(t, err)
+	require.ErrorIs(t, policy.CheckDestination(destination), ErrDeniedDestination)
+	require.NoError(t, policy.CheckDestination(ChainAddress{BlockchainID: testSourceID, Address: destination.Address}))
+
+	// Fees in tokens without a price can't be valued, so the message is skipped
+	policy, err = NewPolicy(PolicyConfig{}, NewStaticPriceOracle())
+	require.NoError(t, err)
+	evaluation, err := policy.Evaluate(ctx, newTestCandidate(1, 1))
+	require.NoError(t, err)
+	require.ErrorIs(t, evaluation.Skip, ErrUnknownPrice)
+	require.Nil(t, evaluation.Profit)
+
+	// Unless the context is done
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = policy.Evaluate(cancelledCtx, newTestCandidate(1, 1))
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPolicyPrioritize(t *testing.T) {
+	ctx := context.Background()
+	low := newTestCandidate(1, 100_000)
+	unprofitable := newTestCandidate(1, 100_000_000)
+	high := newTestCandidate(5, 100_000)
+	alsoLow := newTestCandidate(1, 100_000)
+	candidates := []*Candidate{low, unprofitable, high, alsoLow}
+
+	policy, err := NewPolicy(PolicyConfig{MinProfit: big.NewFloat(0)}, newTestOracle())
+	require.NoError(t, err)
+	evaluations, err := policy.Prioritize(ctx, candidates)
+	require.NoError(t, err)
+	var order []*Candidate
+	for _, evaluation := range evaluations {
+		order = append(order, evaluation.Candidate)
+	}
+	require.Equal(t, []*Candidate{high, low, alsoLow, unprofitable}, order)
+	require.ErrorIs(t, evaluations[3].Skip, ErrUnprofitable)
+
+	// A message paying fees in a token without a price doesn't prevent the others from being relayed
+	unknownToken := newTestCandidate(5, 100_000)
+	unknownToken.FeeInfo.FeeTokenAddress = common.HexToAddress("0xbad")
+	evaluations, err = policy.Prioritize(ctx, []*Candidate{unknownToken, low})
+	require.NoError(t, err)
+	require.Equal(t, low, evaluations[0].Candidate)
+	require.NoError(t, evaluations[0].Skip)
+	require.Equal(t, unknownToken, evaluations[1].Candidate)
+	require.ErrorIs(t, evaluations[1].Skip, ErrUnknownPrice)
+
+	// Without an oracle, messages keep their order
+	policy, err = NewPolicy(PolicyConfig{}, nil)
+	require.NoError(t, err)
+	evaluations, err = policy.Prioritize(ctx, candidates)
+	require.NoError(t, err)
+	for i, evaluation := range evaluations {
+		require.Equal(t, candidates[i], evaluation.Candidate)
+		require.Nil(t, evaluation.Profit)
+	}
+}
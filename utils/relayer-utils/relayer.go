@@ -0,0 +1,747 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	gasUtils "github.com/ava-labs/icm-contracts/utils/gas-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	defaultPollInterval   = time.Second
+	defaultReceiptTimeout = 30 * time.Second
+	defaultMaxRetries     = 5
+	defaultMaxBlockRange  = 2048
+	defaultMaxSkipped     = 1024
+)
+
+var (
+	ErrInvalidConfig       = errors.New("invalid relayer config")
+	ErrUnknownChain        = errors.New("unknown chain")
+	ErrWarpMessageNotFound = errors.New("warp message not found")
+	ErrDeliveryReverted    = errors.New("message delivery reverted")
+)
+
+// sendCrossChainMessageTopic and addFeeAmountTopic are the topics of SendCrossChainMessage and
+// AddFeeAmount logs
+var sendCrossChainMessageTopic, addFeeAmountTopic common.Hash
+
+func init() {
+	teleporterABI, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	sendCrossChainMessageTopic = teleporterABI.Events["SendCrossChainMessage"].ID
+	addFeeAmountTopic = teleporterABI.Events["AddFeeAmount"].ID
+}
+
+// Chain is a chain that the relayer reads Teleporter messages from and delivers them to
+type Chain struct {
+	SubnetID                     ids.ID
+	BlockchainID                 ids.ID
+	EVMChainID                   *big.Int
+	Client                       ethclient.Client
+	TeleporterAddress            common.Address
+	RequirePrimaryNetworkSigners bool
+	// StartBlock is the first block scanned for messages by Run. If zero, Run starts after the
+	// latest confirmed block.
+	StartBlock uint64
+}
+
+// Signer returns Warp messages signed by a quorum of the validators of [inputSigningSubnet].
+// It is implemented by the signature aggregator clients in tests/utils.
+type Signer interface {
+	CreateSignedMessage(
+		unsignedMessage *avalancheWarp.UnsignedMessage,
+		justification []byte,
+		inputSigningSubnet ids.ID,
+		quorumPercentage uint64,
+	) (*avalancheWarp.Message, error)
+}
+
+// Config configures a Relayer
+type Config struct {
+	Chains []Chain
+	// Key signs and pays for the delivery transactions on every chain
+	Key *ecdsa.PrivateKey
+	// RewardAddress is credited with the fees of the delivered messages. Defaults to the address
+	// of Key.
+	RewardAddress common.Address
+	// ConfirmationDepth is the number of blocks that must be built on top of the block that sent a
+	// message before it is relayed, so that messages sent in blocks that are reorged out are not.
+	ConfirmationDepth uint64
+	// PollInterval is the interval at which Run checks for new blocks. Defaults to 1 second.
+	PollInterval time.Duration
+	// ReceiptTimeout bounds the time waited for a delivery transaction to be accepted. Defaults
+	// to 30 seconds.
+	ReceiptTimeout time.Duration
+	// MaxRetries is the number of polls at which Run retries a message that failed to be signed,
+	// priced or delivered, before dropping it. Defaults to 5. Negative values disable retries.
+	MaxRetries int
+	// QuorumPercentage is the percentage of stake required to sign messages. Defaults to the
+	// Warp precompile's default quorum.
+	QuorumPercentage uint64
+	// Pricing configures the fees of the delivery transactions
+	Pricing gasUtils.TxPricerConfig
+	// MaxBlockRange is the maximum number of blocks whose logs are requested at once. Defaults
+	// to 2048.
+	MaxBlockRange uint64
+	// Policy, if set, decides which messages are relayed, and orders the messages found in each
+	// scan. Its destination filters are applied before messages are signed and priced. Messages
+	// it skips for other reasons are kept, and evaluated again with their current fee once it is
+	// increased with addFeeAmount.
+	Policy *Policy
+	// MaxSkippedMessages is the number of messages skipped by the policy that are kept per source
+	// chain. Messages skipped once the limit is reached are dropped. Defaults to 1024.
+	MaxSkippedMessages int
+	// OnDelivery, if set, is called after each message is delivered
+	OnDelivery func(*Delivery)
+}
+
+// Delivery is a Teleporter message delivered by the relayer
+type Delivery struct {
+	MessageID               ids.ID
+	SourceBlockchainID      ids.ID
+	DestinationBlockchainID ids.ID
+	Message                 teleportermessenger.TeleporterMessage
+	FeeInfo                 teleportermessenger.TeleporterFeeInfo
+	Receipt                 *types.Receipt
+}
+
+// Relayer delivers Teleporter messages sent between a set of chains. Messages are read from the
+// SendCrossChainMessage logs of each chain's TeleporterMessenger once they are ConfirmationDepth
+// blocks deep, signed by [Signer], and delivered with receiveCrossChainMessage. Messages that
+// don't allow the relayer's address to deliver them, and messages that have already been
+// received, are skipped, so that several relayers can serve the same chains.
+type Relayer struct {
+	config  Config
+	signer  Signer
+	address common.Address
+	chains  map[ids.ID]*relayerChain
+	// order is the order in which Run scans the chains
+	order []ids.ID
+}
+
+type relayerChain struct {
+	Chain
+	messenger *teleportermessenger.TeleporterMessenger
+	pricer    *gasUtils.TxPricer
+	// nonces are the nonces of the key on the chain, which may be shared with an Accountant
+	nonces *nonceManager
+	// nextBlock is the next block scanned by Run
+	nextBlock uint64
+	// retries are the messages sent from the chain that failed, and that Run relays again at its
+	// next poll. skipped are the messages skipped by the policy, which are relayed again when
+	// their fee is increased. Both are guarded by retriesLock.
+	retriesLock sync.Mutex
+	retries     []*sentMessage
+	skipped     map[ids.ID]*sentMessage
+}
+
+// NewRelayer returns a relayer for the chains of [config]
+func NewRelayer(config Config, signer Signer) (*Relayer, error) {
+	if config.Key == nil {
+		return nil, fmt.Errorf("%w: no key", ErrInvalidConfig)
+	}
+	if len(config.Chains) == 0 {
+		return nil, fmt.Errorf("%w: no chains", ErrInvalidConfig)
+	}
+	address := crypto.PubkeyToAddress(config.Key.PublicKey)
+	if config.RewardAddress == (common.Address{}) {
+		config.RewardAddress = address
+	}
+	if config.PollInterval == 0 {
+		config.PollInterval = defaultPollInterval
+	}
+	if config.ReceiptTimeout == 0 {
+		config.ReceiptTimeout = defaultReceiptTimeout
+	}
+	if config.MaxRetries == 0 {
+		config.MaxRetries = defaultMaxRetries
+	}
+	if config.QuorumPercentage == 0 {
+		config.QuorumPercentage = warp.WarpDefaultQuorumNumerator
+	}
+	if config.MaxBlockRange == 0 {
+		config.MaxBlockRange = defaultMaxBlockRange
+	}
+	if config.MaxSkippedMessages == 0 {
+		config.MaxSkippedMessages = defaultMaxSkipped
+	}
+
+	r := &Relayer{
+		config:  config,
+		signer:  signer,
+		address: address,
+		chains:  make(map[ids.ID]*relayerChain, len(config.Chains)),
+	}
+	for _, chain := range config.Chains {
+		if _, ok := r.chains[chain.BlockchainID]; ok {
+			return nil, fmt.Errorf("%w: duplicate chain %s", ErrInvalidConfig, chain.BlockchainID)
+		}
+		c, err := newRelayerChain(chain, address, config.Pricing)
+		if err != nil {
+			return nil, err
+		}
+		r.chains[chain.BlockchainID] = c
+		r.order = append(r.order, chain.BlockchainID)
+	}
+	return r, nil
+}
+
+func newRelayerChain(chain Chain, address common.Address, pricing gasUtils.TxPricerConfig) (*relayerChain, error) {
+	if chain.Client == nil || chain.EVMChainID == nil {
+		return nil, fmt.Errorf("%w: chain %s has no client or EVM chain ID", ErrInvalidConfig, chain.BlockchainID)
+	}
+	messenger, err := teleportermessenger.NewTeleporterMessenger(chain.TeleporterAddress, chain.Client)
+	if err != nil {
+		return nil, err
+	}
+	return &relayerChain{
+		Chain:     chain,
+		messenger: messenger,
+		pricer:    gasUtils.NewTxPricer(gasUtils.NewFeeMarketClient(chain.Client), pricing),
+		nonces:    newNonceManager(chain.Client, address),
+		nextBlock: chain.StartBlock,
+		skipped:   make(map[ids.ID]*sentMessage),
+	}, nil
+}
+
+// Address returns the address that delivers messages
+func (r *Relayer) Address() common.Address {
+	return r.address
+}
+
+// Run relays messages until [ctx] is done. Messages that fail to be signed, priced or delivered
+// are retried at the next MaxRetries polls, unless their delivery transaction reverted. Failed
+// messages don't hold back the messages sent in later blocks.
+func (r *Relayer) Run(ctx context.Context) error {
+	for _, blockchainID := range r.order {
+		c := r.chains[blockchainID]
+		if c.nextBlock != 0 {
+			continue
+		}
+		confirmed, err := r.confirmedHeight(ctx, c)
+		if err != nil {
+			return err
+		}
+		c.nextBlock = confirmed + 1
+	}
+
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		for _, blockchainID := range r.order {
+			if err := r.poll(ctx, r.chains[blockchainID]); err != nil {
+				log.Warn("Failed to relay messages", "blockchainID", blockchainID, "err", err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relayer) poll(ctx context.Context, c *relayerChain) error {
+	confirmed, err := r.confirmedHeight(ctx, c)
+	if err != nil {
+		return err
+	}
+	var sent []*sentMessage
+	if c.nextBlock <= confirmed {
+		sent, err = r.readMessages(ctx, c, c.nextBlock, confirmed)
+		if err != nil {
+			return err
+		}
+		c.nextBlock = confirmed + 1
+	}
+	_, err = r.relayMessages(ctx, c, append(c.takeRetries(), sent...))
+	return err
+}
+
+// confirmedHeight returns the height of the latest block of [c] that is ConfirmationDepth deep
+func (r *Relayer) confirmedHeight(ctx context.Context, c *relayerChain) (uint64, error) {
+	return confirmedHeight(ctx, c, r.config.ConfirmationDepth)
+}
+
+// confirmedHeight returns the height of the latest block of [c] that is [depth] blocks deep
+func confirmedHeight(ctx context.Context, c *relayerChain, depth uint64) (uint64, error) {
+	head, err := c.Client.BlockNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the height of %s: %w", c.BlockchainID, err)
+	}
+	if head < depth {
+		return 0, nil
+	}
+	return head - depth, nil
+}
+
+// ProcessBlocks relays the messages sent from [sourceBlockchainID] in blocks [from] to [to],
+// regardless of their confirmation depth, and returns the messages it delivered. Messages that
+// fail are retried by Run, and their errors are returned together.
+func (r *Relayer) ProcessBlocks(
+	ctx context.Context,
+	sourceBlockchainID ids.ID,
+	from uint64,
+	to uint64,
+) ([]*Delivery, error) {
+	c, ok := r.chains[sourceBlockchainID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownChain, sourceBlockchainID)
+	}
+	sent, err := r.readMessages(ctx, c, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return r.relayMessages(ctx, c, sent)
+}
+
+// sentMessage is a message sent in a scanned block
+type sentMessage struct {
+	event    *teleportermessenger.TeleporterMessengerSendCrossChainMessage
+	warpLogs []types.Log
+	// signedMessage is kept while the message is skipped by the policy, so that it isn't signed
+	// again each time it is evaluated
+	signedMessage *avalancheWarp.Message
+	// attempts is the number of times relaying the message failed
+	attempts int
+}
+
+// pendingMessage is a sent message, signed and ready to be delivered
+type pendingMessage struct {
+	*sentMessage
+	candidate   *Candidate
+	source      *relayerChain
+	destination *relayerChain
+	price       *gasUtils.TxPrice
+}
+
+// readMessages returns the messages sent in blocks [from] to [to] of [c], followed by the skipped
+// messages whose fee was increased in those blocks. Logs are requested MaxBlockRange blocks at a
+// time.
+func (r *Relayer) readMessages(
+	ctx context.Context,
+	c *relayerChain,
+	from uint64,
+	to uint64,
+) ([]*sentMessage, error) {
+	var sent, feeIncreased []*sentMessage
+	for next := from; next <= to; {
+		end := min(to, next+r.config.MaxBlockRange-1)
+		rangeSent, rangeFeeIncreased, err := r.readMessageRange(ctx, c, next, end)
+		if err != nil {
+			return nil, err
+		}
+		sent = append(sent, rangeSent...)
+		feeIncreased = append(feeIncreased, rangeFeeIncreased...)
+		if end == to {
+			break
+		}
+		next = end + 1
+	}
+	return append(sent, feeIncreased...), nil
+}
+
+// readMessageRange returns the messages sent in blocks [from] to [to] of [c], and the skipped
+// messages whose fee was increased in those blocks
+func (r *Relayer) readMessageRange(
+	ctx context.Context,
+	c *relayerChain,
+	from uint64,
+	to uint64,
+) ([]*sentMessage, []*sentMessage, error) {
+	query := interfaces.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+	}
+	query.Addresses = []common.Address{c.TeleporterAddress}
+	query.Topics = [][]common.Hash{{sendCrossChainMessageTopic, addFeeAmountTopic}}
+	teleporterLogs, err := c.Client.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get Teleporter logs of %s: %w", c.BlockchainID, err)
+	}
+	var sendLogs []types.Log
+	var feeIncreased []*sentMessage
+	for _, teleporterLog := range teleporterLogs {
+		if teleporterLog.Removed || len(teleporterLog.Topics) == 0 {
+			continue
+		}
+		if teleporterLog.Topics[0] == sendCrossChainMessageTopic {
+			sendLogs = append(sendLogs, teleporterLog)
+			continue
+		}
+		event, err := c.messenger.ParseAddFeeAmount(teleporterLog)
+		if err != nil {
+			log.Error("Failed to parse AddFeeAmount log", "txHash", teleporterLog.TxHash, "err", err)
+			continue
+		}
+		if message := c.takeSkipped(ids.ID(event.MessageID)); message != nil {
+			feeIncreased = append(feeIncreased, message)
+		}
+	}
+	if len(sendLogs) == 0 {
+		return nil, feeIncreased, nil
+	}
+
+	query.Addresses = []common.Address{warp.ContractAddress}
+	query.Topics = nil
+	warpLogs, err := c.Client.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get Warp logs of %s: %w", c.BlockchainID, err)
+	}
+	warpLogsByTx := make(map[common.Hash][]types.Log)
+	for _, warpLog := range warpLogs {
+		warpLogsByTx[warpLog.TxHash] = append(warpLogsByTx[warpLog.TxHash], warpLog)
+	}
+
+	var sent []*sentMessage
+	for _, sendLog := range sendLogs {
+		event, err := c.messenger.ParseSendCrossChainMessage(sendLog)
+		if err != nil {
+			// The log can't be parsed on a later attempt either
+			log.Error("Failed to parse SendCrossChainMessage log", "txHash", sendLog.TxHash, "err", err)
+			continue
+		}
+		// A skipped message read again is evaluated again, and not kept twice
+		c.takeSkipped(ids.ID(event.MessageID))
+		sent = append(sent, &sentMessage{event: event, warpLogs: warpLogsByTx[sendLog.TxHash]})
+	}
+	feeIncreased = slices.DeleteFunc(feeIncreased, func(skipped *sentMessage) bool {
+		return slices.ContainsFunc(sent, func(message *sentMessage) bool {
+			return message.event.MessageID == skipped.event.MessageID
+		})
+	})
+	return sent, feeIncreased, nil
+}
+
+// relayMessages relays the messages of [sent], which were sent from [c], in the order decided by
+// the relayer's policy. Messages that fail are recorded to be retried, and don't prevent the
+// other messages from being relayed. The errors of the failed messages are returned together.
+func (r *Relayer) relayMessages(
+	ctx context.Context,
+	c *relayerChain,
+	sent []*sentMessage,
+) ([]*Delivery, error) {
+	var (
+		pending []*pendingMessage
+		errs    []error
+	)
+	for _, message := range sent {
+		prepared, err := r.prepare(ctx, c, message)
+		if err != nil {
+			errs = append(errs, r.recordFailure(c, message, err))
+			continue
+		}
+		if prepared != nil {
+			pending = append(pending, prepared)
+		}
+	}
+	prioritized, skipped, err := r.prioritize(ctx, pending)
+	if err != nil {
+		for _, message := range pending {
+			errs = append(errs, r.recordFailure(c, message.sentMessage, err))
+		}
+		return nil, errors.Join(errs...)
+	}
+
+	// The fee of skipped messages can be increased
+	for _, message := range skipped {
+		c.addSkipped(message.sentMessage, r.config.MaxSkippedMessages)
+	}
+
+	var deliveries []*Delivery
+	for _, message := range prioritized {
+		delivery, err := r.relay(ctx, message)
+		if errors.Is(err, ErrDeliveryReverted) {
+			log.Error("Failed to deliver message", "messageID", message.candidate.MessageID, "err", err)
+			continue
+		}
+		if err != nil {
+			errs = append(errs, r.recordFailure(c, message.sentMessage, err))
+			continue
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, errors.Join(errs...)
+}
+
+// recordFailure records that relaying [message] from [c] failed with [err], and schedules it to
+// be retried unless it already failed MaxRetries times. It returns [err].
+func (r *Relayer) recordFailure(c *relayerChain, message *sentMessage, err error) error {
+	messageID := ids.ID(message.event.MessageID)
+	message.attempts++
+	// The signing validators may have changed by the next attempt
+	message.signedMessage = nil
+	if message.attempts > r.config.MaxRetries {
+		log.Error("Dropping message", "messageID", messageID, "attempts", message.attempts, "err", err)
+		return err
+	}
+	log.Warn("Failed to relay message", "messageID", messageID, "attempts", message.attempts, "err", err)
+	c.addRetry(message)
+	return err
+}
+
+// addRetry schedules [message] to be relayed again at the next poll of Run
+func (c *relayerChain) addRetry(message *sentMessage) {
+	c.retriesLock.Lock()
+	defer c.retriesLock.Unlock()
+	c.retries = append(c.retries, message)
+}
+
+// takeRetries returns the messages of [c] to retry, and clears them
+func (c *relayerChain) takeRetries() []*sentMessage {
+	c.retriesLock.Lock()
+	defer c.retriesLock.Unlock()
+	retries := c.retries
+	c.retries = nil
+	return retries
+}
+
+// addSkipped keeps [message], which was skipped by the policy, until its fee is increased, unless
+// [maxSkipped] messages are already kept
+func (c *relayerChain) addSkipped(message *sentMessage, maxSkipped int) {
+	c.retriesLock.Lock()
+	defer c.retriesLock.Unlock()
+	messageID := ids.ID(message.event.MessageID)
+	if _, ok := c.skipped[messageID]; !ok && len(c.skipped) >= maxSkipped {
+		log.Warn("Dropping skipped message", "messageID", messageID, "maxSkipped", maxSkipped)
+		return
+	}
+	c.skipped[messageID] = message
+}
+
+// takeSkipped returns the skipped message [messageID] of [c], if it is kept, and stops keeping it
+func (c *relayerChain) takeSkipped(messageID ids.ID) *sentMessage {
+	c.retriesLock.Lock()
+	defer c.retriesLock.Unlock()
+	message, ok := c.skipped[messageID]
+	if !ok {
+		return nil
+	}
+	delete(c.skipped, messageID)
+	return message
+}
+
+// prepare signs [message] and prices its delivery, unless it should be skipped, in which case it
+// returns nil
+func (r *Relayer) prepare(
+	ctx context.Context,
+	source *relayerChain,
+	message *sentMessage,
+) (*pendingMessage, error) {
+	event := message.event
+	messageID := ids.ID(event.MessageID)
+	destination, ok := r.chains[ids.ID(event.DestinationBlockchainID)]
+	if !ok {
+		log.Debug("Skipping message to unknown chain", "messageID", messageID)
+		return nil, nil
+	}
+	if !isAllowedRelayer(event.Message.AllowedRelayerAddresses, r.address) {
+		log.Debug("Skipping message that doesn't allow the relayer to deliver it", "messageID", messageID)
+		return nil, nil
+	}
+	// Apply the policy's destination filters before spending signature and fee queries on the message
+	if r.config.Policy != nil {
+		err := r.config.Policy.CheckDestination(ChainAddress{
+			BlockchainID: destination.BlockchainID,
+			Address:      event.Message.DestinationAddress,
+		})
+		if err != nil {
+			log.Info("Skipping message", "messageID", messageID, "reason", err)
+			return nil, nil
+		}
+	}
+	received, err := destination.messenger.MessageReceived(&bind.CallOpts{Context: ctx}, event.MessageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if message %s was received: %w", messageID, err)
+	}
+	if received {
+		log.Debug("Skipping message that was already received", "messageID", messageID)
+		return nil, nil
+	}
+
+	// The fee may have been increased with addFeeAmount since the message was sent
+	feeToken, feeAmount, err := source.messenger.GetFeeInfo(&bind.CallOpts{Context: ctx}, event.MessageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee of message %s: %w", messageID, err)
+	}
+
+	if message.signedMessage == nil {
+		unsignedMessage, err := findWarpMessage(message.warpLogs, source.TeleporterAddress, messageID)
+		if err != nil {
+			return nil, err
+		}
+		message.signedMessage, err = r.signer.CreateSignedMessage(
+			unsignedMessage,
+			nil,
+			signingSubnetID(source.Chain, destination.Chain),
+			r.config.QuorumPercentage,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign message %s: %w", messageID, err)
+		}
+	}
+	gasLimit, err := ReceiveMessageGasLimit(message.signedMessage, event.Message)
+	if err != nil {
+		return nil, err
+	}
+	price, err := destination.pricer.Price(ctx, gasLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to price delivery of message %s: %w", messageID, err)
+	}
+	// The policy values the gas at the price expected to be paid, rather than the fee cap
+	gasPrice := price.GasFeeCap
+	var gasUsed uint64
+	if r.config.Policy != nil {
+		baseFee, err := destination.Client.EstimateBaseFee(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate base fee of %s: %w", destination.BlockchainID, err)
+		}
+		gasPrice = price.EffectiveGasPrice(baseFee)
+		gasUsed = r.estimateDeliveryGas(ctx, destination, message.signedMessage)
+	}
+
+	return &pendingMessage{
+		sentMessage: message,
+		candidate: &Candidate{
+			MessageID:               messageID,
+			SourceBlockchainID:      source.BlockchainID,
+			DestinationBlockchainID: destination.BlockchainID,
+			Message:                 event.Message,
+			FeeInfo: teleportermessenger.TeleporterFeeInfo{
+				FeeTokenAddress: feeToken,
+				Amount:          feeAmount,
+			},
+			GasLimit: gasLimit,
+			GasUsed:  gasUsed,
+			GasPrice: gasPrice,
+		},
+		source:      source,
+		destination: destination,
+		price:       price,
+	}, nil
+}
+
+// prioritize returns the messages of [pending] that the relayer's policy accepts, in the order
+// they should be delivered, and the messages it skips
+func (r *Relayer) prioritize(
+	ctx context.Context,
+	pending []*pendingMessage,
+) ([]*pendingMessage, []*pendingMessage, error) {
+	if r.config.Policy == nil || len(pending) == 0 {
+		return pending, nil, nil
+	}
+	candidates := make([]*Candidate, 0, len(pending))
+	byCandidate := make(map[*Candidate]*pendingMessage, len(pending))
+	for _, message := range pending {
+		candidates = append(candidates, message.candidate)
+		byCandidate[message.candidate] = message
+	}
+	evaluations, err := r.config.Policy.Prioritize(ctx, candidates)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prioritized := make([]*pendingMessage, 0, len(pending))
+	var skipped []*pendingMessage
+	for _, evaluation := range evaluations {
+		if evaluation.Skip != nil {
+			log.Info("Skipping message", "messageID", evaluation.MessageID, "reason", evaluation.Skip)
+			skipped = append(skipped, byCandidate[evaluation.Candidate])
+			continue
+		}
+		prioritized = append(prioritized, byCandidate[evaluation.Candidate])
+	}
+	return prioritized, skipped, nil
+}
+
+// relay delivers [message]
+func (r *Relayer) relay(ctx context.Context, message *pendingMessage) (*Delivery, error) {
+	candidate := message.candidate
+	receipt, err := r.deliver(ctx, message.destination, message.signedMessage, candidate.GasLimit, message.price)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deliver message %s: %w", candidate.MessageID, err)
+	}
+
+	delivery := &Delivery{
+		MessageID:               candidate.MessageID,
+		SourceBlockchainID:      candidate.SourceBlockchainID,
+		DestinationBlockchainID: candidate.DestinationBlockchainID,
+		Message:                 candidate.Message,
+		FeeInfo:                 candidate.FeeInfo,
+		Receipt:                 receipt,
+	}
+	log.Info(
+		"Delivered message",
+		"messageID", candidate.MessageID,
+		"source", candidate.SourceBlockchainID,
+		"destination", candidate.DestinationBlockchainID,
+		"txHash", receipt.TxHash,
+	)
+	if r.config.OnDelivery != nil {
+		r.config.OnDelivery(delivery)
+	}
+	return delivery, nil
+}
+
+// isAllowedRelayer returns whether [relayer] may deliver a message with [allowedRelayers].
+// An empty list allows any relayer.
+func isAllowedRelayer(allowedRelayers []common.Address, relayer common.Address) bool {
+	return len(allowedRelayers) == 0 || slices.Contains(allowedRelayers, relayer)
+}
+
+// signingSubnetID returns the subnet whose validators sign messages sent from [source] to
+// [destination]. Messages from the primary network are verified against the validators of the
+// destination L1, unless it requires primary network signers.
+func signingSubnetID(source Chain, destination Chain) ids.ID {
+	if source.SubnetID == constants.PrimaryNetworkID && !destination.RequirePrimaryNetworkSigners {
+		return destination.SubnetID
+	}
+	return source.SubnetID
+}
+
+// findWarpMessage returns the Warp message of [warpLogs] that carries the Teleporter message
+// [messageID] sent by [teleporterAddress]
+func findWarpMessage(
+	warpLogs []types.Log,
+	teleporterAddress common.Address,
+	messageID ids.ID,
+) (*avalancheWarp.UnsignedMessage, error) {
+	for _, warpLog := range warpLogs {
+		unsignedMessage, err := warp.UnpackSendWarpEventDataToMessage(warpLog.Data)
+		if err != nil {
+			continue
+		}
+		message, err := teleportermessenger.ParseTeleporterWarpMessage(unsignedMessage, teleporterAddress)
+		if err != nil {
+			continue
+		}
+		if message.MessageID == messageID {
+			return unsignedMessage, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrWarpMessageNotFound, messageID)
+}
@@ -0,0 +1,168 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRelayer(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chain := Chain{
+		BlockchainID: ids.GenerateTestID(),
+		EVMChainID:   big.NewInt(1),
+		Client:       ethclient.NewClient(nil),
+	}
+
+	_, err = NewRelayer(Config{Chains: []Chain{chain}}, nil)
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	_, err = NewRelayer(Config{Key: key}, nil)
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	_, err = NewRelayer(Config{Key: key, Chains: []Chain{chain, chain}}, nil)
+	require.ErrorIs(t, err, ErrInvalidConfig)
+
+	r, err := NewRelayer(Config{Key: key, Chains: []Chain{chain}}, nil)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), r.Address())
+	require.Equal(t, r.Address(), r.config.RewardAddress)
+	require.Equal(t, warp.WarpDefaultQuorumNumerator, r.config.QuorumPercentage)
+
+	_, err = r.ProcessBlocks(context.Background(), ids.GenerateTestID(), 0, 0)
+	require.ErrorIs(t, err, ErrUnknownChain)
+}
+
+func TestRecordFailure(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chain := Chain{
+		BlockchainID: ids.GenerateTestID(),
+		EVMChainID:   big.NewInt(1),
+		Client:       ethclient.NewClient(nil),
+	}
+	r, err := NewRelayer(Config{Key: key, Chains: []Chain{chain}, MaxRetries: 2}, nil)
+	require.NoError(t, err)
+	c := r.chains[chain.BlockchainID]
+
+	failed := &sentMessage{event: &teleportermessenger.TeleporterMessengerSendCrossChainMessage{}}
+	other := &sentMessage{event: &teleportermessenger.TeleporterMessengerSendCrossChainMessage{}}
+	errFailed := errors.New("failed")
+	require.ErrorIs(t, r.recordFailure(c, failed, errFailed), errFailed)
+	require.ErrorIs(t, r.recordFailure(c, other, errFailed), errFailed)
+	require.Equal(t, []*sentMessage{failed, other}, c.takeRetries())
+	require.Empty(t, c.takeRetries())
+
+	// The message is dropped once it failed more than MaxRetries times
+	require.ErrorIs(t, r.recordFailure(c, failed, errFailed), errFailed)
+	require.Equal(t, []*sentMessage{failed}, c.takeRetries())
+	require.ErrorIs(t, r.recordFailure(c, failed, errFailed), errFailed)
+	require.Empty(t, c.takeRetries())
+	require.Equal(t, 3, failed.attempts)
+}
+
+func TestSkippedMessages(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chain := Chain{
+		BlockchainID: ids.GenerateTestID(),
+		EVMChainID:   big.NewInt(1),
+		Client:       ethclient.NewClient(nil),
+	}
+	r, err := NewRelayer(Config{Key: key, Chains: []Chain{chain}}, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(defaultMaxBlockRange), r.config.MaxBlockRange)
+	c := r.chains[chain.BlockchainID]
+
+	newSkipped := func() *sentMessage {
+		return &sentMessage{event: &teleportermessenger.TeleporterMessengerSendCrossChainMessage{
+			MessageID: ids.GenerateTestID(),
+		}}
+	}
+	first := newSkipped()
+	second := newSkipped()
+	c.addSkipped(first, 1)
+	// Skipped messages are dropped once the limit is reached, unless they are already kept
+	c.addSkipped(second, 1)
+	c.addSkipped(first, 1)
+	require.Nil(t, c.takeSkipped(ids.ID(second.event.MessageID)))
+	require.Equal(t, first, c.takeSkipped(ids.ID(first.event.MessageID)))
+	require.Nil(t, c.takeSkipped(ids.ID(first.event.MessageID)))
+	// Skipped messages are not retried at each poll
+	require.Empty(t, c.takeRetries())
+}
+
+func TestIsAllowedRelayer(t *testing.T) {
+	relayer := common.HexToAddress("0x1")
+	require.True(t, isAllowedRelayer(nil, relayer))
+	require.True(t, isAllowedRelayer([]common.Address{common.HexToAddress("0x2"), relayer}, relayer))
+	require.False(t, isAllowedRelayer([]common.Address{common.HexToAddress("0x2")}, relayer))
+}
+
+func TestSigningSubnetID(t *testing.T) {
+	primaryNetwork := Chain{SubnetID: constants.PrimaryNetworkID}
+	l1 := Chain{SubnetID: ids.GenerateTestID()}
+	require.Equal(t, l1.SubnetID, signingSubnetID(l1, primaryNetwork))
+	require.Equal(t, l1.SubnetID, signingSubnetID(primaryNetwork, l1))
+
+	l1.RequirePrimaryNetworkSigners = true
+	require.Equal(t, constants.PrimaryNetworkID, signingSubnetID(primaryNetwork, l1))
+}
+
+func TestFindWarpMessage(t *testing.T) {
+	teleporterAddress := common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf")
+	newLog := func(message teleportermessenger.TeleporterMessage) types.Log {
+		warpMessage, err := teleportermessenger.NewTeleporterWarpMessage(
+			constants.UnitTestID,
+			ids.GenerateTestID(),
+			teleporterAddress,
+			message,
+		)
+		require.NoError(t, err)
+		unsignedMessage := warpMessage.UnsignedMessage
+		_, data, err := warp.PackSendWarpMessageEvent(
+			teleporterAddress,
+			common.Hash(unsignedMessage.ID()),
+			unsignedMessage.Bytes(),
+		)
+		require.NoError(t, err)
+		return types.Log{Data: data}
+	}
+	message := teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(1),
+		DestinationBlockchainID: ids.GenerateTestID(),
+		RequiredGasLimit:        big.NewInt(1),
+	}
+	first := newLog(message)
+	message.MessageNonce = big.NewInt(2)
+	second := newLog(message)
+	logs := []types.Log{{Data: []byte{1}}, first, second}
+
+	// The message ID depends on the source blockchain ID of the Warp message
+	unsignedMessage, err := warp.UnpackSendWarpEventDataToMessage(second.Data)
+	require.NoError(t, err)
+	parsed, err := teleportermessenger.ParseTeleporterWarpMessage(unsignedMessage, teleporterAddress)
+	require.NoError(t, err)
+
+	found, err := findWarpMessage(logs, teleporterAddress, parsed.MessageID)
+	require.NoError(t, err)
+	require.Equal(t, unsignedMessage.Bytes(), found.Bytes())
+
+	_, err = findWarpMessage(logs, common.HexToAddress("0x2"), parsed.MessageID)
+	require.ErrorIs(t, err, ErrWarpMessageNotFound)
+	_, err = findWarpMessage(logs, teleporterAddress, ids.GenerateTestID())
+	require.ErrorIs(t, err, ErrWarpMessageNotFound)
+}
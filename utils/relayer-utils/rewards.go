@@ -0,0 +1,563 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	gasUtils "github.com/ava-labs/icm-contracts/utils/gas-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const defaultRedemptionInterval = time.Minute
+
+var ErrRedemptionReverted = errors.New("reward redemption reverted")
+
+// RewardStatus is the state of the reward for delivering a message
+type RewardStatus int
+
+const (
+	// RewardPending rewards are for messages whose receipt hasn't been returned to their source
+	// chain yet
+	RewardPending RewardStatus = iota
+	// RewardRedeemable rewards have been credited to the reward address by the source chain's
+	// TeleporterMessenger
+	RewardRedeemable
+	// RewardRedeemed rewards have been transferred to the reward address
+	RewardRedeemed
+)
+
+func (s RewardStatus) String() string {
+	switch s {
+	case RewardPending:
+		return "pending"
+	case RewardRedeemable:
+		return "redeemable"
+	case RewardRedeemed:
+		return "redeemed"
+	default:
+		return "unknown"
+	}
+}
+
+// LedgerEntry is the reward for delivering a message. The reward is paid on the message's source
+// chain, in its fee token.
+type LedgerEntry struct {
+	MessageID               ids.ID
+	SourceBlockchainID      ids.ID
+	DestinationBlockchainID ids.ID
+	FeeTokenAddress         common.Address
+	Amount                  *big.Int
+	Status                  RewardStatus
+	// DeliveryTxHash is zero for messages whose receipt was found without their delivery being
+	// recorded, such as messages delivered before the accountant was started
+	DeliveryTxHash   common.Hash
+	ReceiptTxHash    common.Hash
+	RedemptionTxHash common.Hash
+
+	// receiptPosition is the position of the ReceiptReceived log of the message
+	receiptPosition logPosition
+}
+
+// logPosition orders the logs of a chain
+type logPosition struct {
+	blockNumber uint64
+	index       uint
+}
+
+func (p logPosition) before(other logPosition) bool {
+	return p.blockNumber < other.blockNumber || (p.blockNumber == other.blockNumber && p.index < other.index)
+}
+
+// RewardBalance is the sum of the rewards in a fee token
+type RewardBalance struct {
+	Pending    *big.Int
+	Redeemable *big.Int
+}
+
+// Redemption is a redeemRelayerRewards transaction sent by the accountant
+type Redemption struct {
+	BlockchainID    ids.ID
+	FeeTokenAddress common.Address
+	Amount          *big.Int
+	Receipt         *types.Receipt
+}
+
+// AccountantConfig configures an Accountant
+type AccountantConfig struct {
+	Chains []Chain
+	// Key is the key of the reward address of the relayer, which redeems its rewards
+	Key *ecdsa.PrivateKey
+	// RedemptionThresholds are the minimum reward balances, per fee token of each chain, that are
+	// redeemed. Other fee tokens are redeemed whenever they have a balance.
+	RedemptionThresholds map[ChainAddress]*big.Int
+	// ConfirmationDepth is the number of blocks that must be built on top of a block before its
+	// receipts are accounted for by Run
+	ConfirmationDepth uint64
+	// PollInterval is the interval at which Run checks for receipts. Defaults to 1 second.
+	PollInterval time.Duration
+	// RedemptionInterval is the interval at which Run redeems rewards. Defaults to 1 minute.
+	RedemptionInterval time.Duration
+	// ReceiptTimeout bounds the time waited for a redemption transaction to be accepted. Defaults
+	// to 30 seconds.
+	ReceiptTimeout time.Duration
+	// Pricing configures the fees of the redemption transactions
+	Pricing gasUtils.TxPricerConfig
+	// Relayer is the relayer whose rewards are accounted for. It must be set if Key is also the
+	// relayer's key, so that the accountant sends its transactions with the relayer's nonces.
+	Relayer *Relayer
+}
+
+// Accountant keeps a ledger of the rewards earned by a relayer. Deliveries are recorded as
+// pending rewards with RecordDelivery, which can be set as the relayer's OnDelivery hook. Rewards
+// become redeemable when the ReceiptReceived log of the message is found on its source chain,
+// and are redeemed in batches, one redeemRelayerRewards transaction per fee token.
+//
+// If the reward address is also the relayer's address, the accountant and the relayer share the
+// nonces of the key on each chain.
+type Accountant struct {
+	config  AccountantConfig
+	address common.Address
+	chains  map[ids.ID]*relayerChain
+	order   []ids.ID
+
+	lock sync.Mutex
+	// entries are the ledger entries in the order they were created
+	entries        []*LedgerEntry
+	entriesByID    map[ids.ID]*LedgerEntry
+	lastRedemption time.Time
+}
+
+// NewAccountant returns an accountant for the rewards of the address of config.Key
+func NewAccountant(config AccountantConfig) (*Accountant, error) {
+	if config.Key == nil {
+		return nil, fmt.Errorf("%w: no key", ErrInvalidConfig)
+	}
+	if config.PollInterval == 0 {
+		config.PollInterval = defaultPollInterval
+	}
+	if config.RedemptionInterval == 0 {
+		config.RedemptionInterval = defaultRedemptionInterval
+	}
+	if config.ReceiptTimeout == 0 {
+		config.ReceiptTimeout = defaultReceiptTimeout
+	}
+
+	a := &Accountant{
+		config:      config,
+		address:     crypto.PubkeyToAddress(config.Key.PublicKey),
+		chains:      make(map[ids.ID]*relayerChain, len(config.Chains)),
+		entriesByID: make(map[ids.ID]*LedgerEntry),
+	}
+	for _, chain := range config.Chains {
+		if _, ok := a.chains[chain.BlockchainID]; ok {
+			return nil, fmt.Errorf("%w: duplicate chain %s", ErrInvalidConfig, chain.BlockchainID)
+		}
+		c, err := newRelayerChain(chain, a.address, config.Pricing)
+		if err != nil {
+			return nil, err
+		}
+		if relayer := config.Relayer; relayer != nil && relayer.address == a.address {
+			if relayerChain, ok := relayer.chains[chain.BlockchainID]; ok {
+				c.nonces = relayerChain.nonces
+			}
+		}
+		a.chains[chain.BlockchainID] = c
+		a.order = append(a.order, chain.BlockchainID)
+	}
+	return a, nil
+}
+
+// Address returns the reward address
+func (a *Accountant) Address() common.Address {
+	return a.address
+}
+
+// RecordDelivery records the pending reward for [delivery]
+func (a *Accountant) RecordDelivery(delivery *Delivery) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	entry, ok := a.entriesByID[delivery.MessageID]
+	if !ok {
+		amount := new(big.Int)
+		if delivery.FeeInfo.Amount != nil {
+			amount.Set(delivery.FeeInfo.Amount)
+		}
+		entry = a.newEntry(delivery.MessageID, delivery.SourceBlockchainID, delivery.FeeInfo.FeeTokenAddress, amount)
+	}
+	entry.DestinationBlockchainID = delivery.DestinationBlockchainID
+	if delivery.Receipt != nil {
+		entry.DeliveryTxHash = delivery.Receipt.TxHash
+	}
+}
+
+// newEntry adds a pending ledger entry. Must be called with the lock held.
+func (a *Accountant) newEntry(
+	messageID ids.ID,
+	sourceBlockchainID ids.ID,
+	feeToken common.Address,
+	amount *big.Int,
+) *LedgerEntry {
+	entry := &LedgerEntry{
+		MessageID:          messageID,
+		SourceBlockchainID: sourceBlockchainID,
+		FeeTokenAddress:    feeToken,
+		Amount:             amount,
+		Status:             RewardPending,
+	}
+	a.entries = append(a.entries, entry)
+	a.entriesByID[messageID] = entry
+	return entry
+}
+
+// recordReceipt makes the reward for the message of [event], returned to [blockchainID],
+// redeemable
+func (a *Accountant) recordReceipt(blockchainID ids.ID, event *teleportermessenger.TeleporterMessengerReceiptReceived) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	messageID := ids.ID(event.MessageID)
+	entry, ok := a.entriesByID[messageID]
+	if !ok {
+		entry = a.newEntry(messageID, blockchainID, event.FeeInfo.FeeTokenAddress, nil)
+		entry.DestinationBlockchainID = ids.ID(event.DestinationBlockchainID)
+	}
+	if entry.Status != RewardPending {
+		return
+	}
+	// The fee may have been increased after the message was delivered
+	entry.FeeTokenAddress = event.FeeInfo.FeeTokenAddress
+	entry.Amount = new(big.Int)
+	if event.FeeInfo.Amount != nil {
+		entry.Amount.Set(event.FeeInfo.Amount)
+	}
+	entry.Status = RewardRedeemable
+	entry.ReceiptTxHash = event.Raw.TxHash
+	entry.receiptPosition = logPosition{blockNumber: event.Raw.BlockNumber, index: event.Raw.Index}
+}
+
+// recordRedemption marks the rewards in [feeToken] of [blockchainID] that were redeemable at
+// [event] as redeemed
+func (a *Accountant) recordRedemption(
+	blockchainID ids.ID,
+	event *teleportermessenger.TeleporterMessengerRelayerRewardsRedeemed,
+) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	position := logPosition{blockNumber: event.Raw.BlockNumber, index: event.Raw.Index}
+	for _, entry := range a.entries {
+		if entry.Status != RewardRedeemable ||
+			entry.SourceBlockchainID != blockchainID ||
+			entry.FeeTokenAddress != event.Asset ||
+			!entry.receiptPosition.before(position) {
+			continue
+		}
+		entry.Status = RewardRedeemed
+		entry.RedemptionTxHash = event.Raw.TxHash
+	}
+}
+
+// ProcessBlocks accounts for the receipts and redemptions of the reward address in blocks
+// [from] to [to] of [blockchainID]
+func (a *Accountant) ProcessBlocks(ctx context.Context, blockchainID ids.ID, from uint64, to uint64) error {
+	c, ok := a.chains[blockchainID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownChain, blockchainID)
+	}
+	opts := &bind.FilterOpts{Start: from, End: &to, Context: ctx}
+
+	receipts, err := c.messenger.FilterReceiptReceived(opts, nil, nil, []common.Address{a.address})
+	if err != nil {
+		return fmt.Errorf("failed to get receipts of %s: %w", blockchainID, err)
+	}
+	defer receipts.Close()
+	for receipts.Next() {
+		if !receipts.Event.Raw.Removed {
+			a.recordReceipt(blockchainID, receipts.Event)
+		}
+	}
+	if err := receipts.Error(); err != nil {
+		return fmt.Errorf("failed to get receipts of %s: %w", blockchainID, err)
+	}
+
+	redemptions, err := c.messenger.FilterRelayerRewardsRedeemed(opts, []common.Address{a.address}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get redemptions of %s: %w", blockchainID, err)
+	}
+	defer redemptions.Close()
+	for redemptions.Next() {
+		if !redemptions.Event.Raw.Removed {
+			a.recordRedemption(blockchainID, redemptions.Event)
+		}
+	}
+	if err := redemptions.Error(); err != nil {
+		return fmt.Errorf("failed to get redemptions of %s: %w", blockchainID, err)
+	}
+	return nil
+}
+
+// Redeem redeems the reward balance of each fee token that is at least its redemption
+// threshold. The balances are read from the TeleporterMessenger of each chain, so rewards that
+// are not in the ledger are redeemed too.
+func (a *Accountant) Redeem(ctx context.Context) ([]*Redemption, error) {
+	var redemptions []*Redemption
+	for _, blockchainID := range a.order {
+		c := a.chains[blockchainID]
+		for _, feeToken := range a.feeTokens(blockchainID) {
+			amount, err := c.messenger.CheckRelayerRewardAmount(&bind.CallOpts{Context: ctx}, a.address, feeToken)
+			if err != nil {
+				return redemptions, fmt.Errorf("failed to check reward amount on %s: %w", blockchainID, err)
+			}
+			threshold := a.config.RedemptionThresholds[ChainAddress{BlockchainID: blockchainID, Address: feeToken}]
+			if amount.Sign() == 0 || (threshold != nil && amount.Cmp(threshold) < 0) {
+				continue
+			}
+
+			receipt, err := a.redeem(ctx, c, feeToken)
+			if err != nil {
+				return redemptions, err
+			}
+			for _, receiptLog := range receipt.Logs {
+				event, err := c.messenger.ParseRelayerRewardsRedeemed(*receiptLog)
+				if err == nil {
+					a.recordRedemption(blockchainID, event)
+				}
+			}
+			redemptions = append(redemptions, &Redemption{
+				BlockchainID:    blockchainID,
+				FeeTokenAddress: feeToken,
+				Amount:          amount,
+				Receipt:         receipt,
+			})
+			log.Info(
+				"Redeemed relayer rewards",
+				"blockchainID", blockchainID,
+				"feeToken", feeToken,
+				"amount", amount,
+				"txHash", receipt.TxHash,
+			)
+		}
+	}
+	return redemptions, nil
+}
+
+// feeTokens returns the fee tokens of [blockchainID] that may have a reward balance
+func (a *Accountant) feeTokens(blockchainID ids.ID) []common.Address {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	var feeTokens []common.Address
+	for _, entry := range a.entries {
+		if entry.SourceBlockchainID == blockchainID && entry.Status == RewardRedeemable {
+			feeTokens = append(feeTokens, entry.FeeTokenAddress)
+		}
+	}
+	for feeToken := range a.config.RedemptionThresholds {
+		if feeToken.BlockchainID == blockchainID {
+			feeTokens = append(feeTokens, feeToken.Address)
+		}
+	}
+	slices.SortFunc(feeTokens, func(x, y common.Address) int {
+		return bytes.Compare(x[:], y[:])
+	})
+	return slices.Compact(feeTokens)
+}
+
+// redeem sends a redeemRelayerRewards transaction for [feeToken] on [c]
+func (a *Accountant) redeem(ctx context.Context, c *relayerChain, feeToken common.Address) (*types.Receipt, error) {
+	callData, err := teleportermessenger.PackRedeemRelayerRewards(feeToken)
+	if err != nil {
+		return nil, err
+	}
+	gasLimit, err := c.Client.EstimateGas(ctx, interfaces.CallMsg{
+		From: a.address,
+		To:   &c.TeleporterAddress,
+		Data: callData,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate redemption gas: %w", err)
+	}
+	price, err := c.pricer.Price(ctx, gasLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := sendTransaction(ctx, c, a.config.Key, func(nonce uint64) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   c.EVMChainID,
+			Nonce:     nonce,
+			GasTipCap: price.GasTipCap,
+			GasFeeCap: price.GasFeeCap,
+			Gas:       gasLimit,
+			To:        &c.TeleporterAddress,
+			Value:     big.NewInt(0),
+			Data:      callData,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := waitForReceipt(ctx, c.Client, tx.Hash(), a.config.ReceiptTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("%w: transaction %s", ErrRedemptionReverted, tx.Hash())
+	}
+	return receipt, nil
+}
+
+// Run accounts for receipts and redeems rewards until [ctx] is done
+func (a *Accountant) Run(ctx context.Context) error {
+	for _, blockchainID := range a.order {
+		c := a.chains[blockchainID]
+		if c.nextBlock != 0 {
+			continue
+		}
+		confirmed, err := confirmedHeight(ctx, c, a.config.ConfirmationDepth)
+		if err != nil {
+			return err
+		}
+		c.nextBlock = confirmed + 1
+	}
+
+	ticker := time.NewTicker(a.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		for _, blockchainID := range a.order {
+			if err := a.poll(ctx, a.chains[blockchainID]); err != nil {
+				log.Warn("Failed to account for receipts", "blockchainID", blockchainID, "err", err)
+			}
+		}
+		if time.Since(a.lastRedemption) >= a.config.RedemptionInterval {
+			// Failed redemptions are retried at the next poll
+			if _, err := a.Redeem(ctx); err != nil {
+				log.Warn("Failed to redeem rewards", "err", err)
+			} else {
+				a.lastRedemption = time.Now()
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *Accountant) poll(ctx context.Context, c *relayerChain) error {
+	confirmed, err := confirmedHeight(ctx, c, a.config.ConfirmationDepth)
+	if err != nil {
+		return err
+	}
+	if c.nextBlock > confirmed {
+		return nil
+	}
+	if err := a.ProcessBlocks(ctx, c.BlockchainID, c.nextBlock, confirmed); err != nil {
+		return err
+	}
+	c.nextBlock = confirmed + 1
+	return nil
+}
+
+// Ledger returns a copy of the ledger, in the order the messages were first recorded
+func (a *Accountant) Ledger() []LedgerEntry {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	ledger := make([]LedgerEntry, 0, len(a.entries))
+	for _, entry := range a.entries {
+		ledgerEntry := *entry
+		ledgerEntry.Amount = new(big.Int).Set(entry.Amount)
+		ledger = append(ledger, ledgerEntry)
+	}
+	return ledger
+}
+
+// Balances returns the pending and redeemable rewards in each fee token of each chain
+func (a *Accountant) Balances() map[ChainAddress]*RewardBalance {
+	balances := make(map[ChainAddress]*RewardBalance)
+	for _, entry := range a.Ledger() {
+		if entry.Status == RewardRedeemed {
+			continue
+		}
+		feeToken := ChainAddress{BlockchainID: entry.SourceBlockchainID, Address: entry.FeeTokenAddress}
+		balance, ok := balances[feeToken]
+		if !ok {
+			balance = &RewardBalance{Pending: new(big.Int), Redeemable: new(big.Int)}
+			balances[feeToken] = balance
+		}
+		if entry.Status == RewardPending {
+			balance.Pending.Add(balance.Pending, entry.Amount)
+		} else {
+			balance.Redeemable.Add(balance.Redeemable, entry.Amount)
+		}
+	}
+	return balances
+}
+
+var ledgerCSVHeader = []string{
+	"message_id",
+	"source_blockchain_id",
+	"destination_blockchain_id",
+	"fee_token_address",
+	"amount",
+	"status",
+	"delivery_tx_hash",
+	"receipt_tx_hash",
+	"redemption_tx_hash",
+}
+
+// WriteLedgerCSV writes the ledger to [w] as CSV, with one row per message
+func (a *Accountant) WriteLedgerCSV(w io.Writer) error {
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(ledgerCSVHeader); err != nil {
+		return err
+	}
+	for _, entry := range a.Ledger() {
+		err := csvWriter.Write([]string{
+			entry.MessageID.String(),
+			entry.SourceBlockchainID.String(),
+			entry.DestinationBlockchainID.String(),
+			entry.FeeTokenAddress.Hex(),
+			entry.Amount.String(),
+			entry.Status.String(),
+			hashString(entry.DeliveryTxHash),
+			hashString(entry.ReceiptTxHash),
+			hashString(entry.RedemptionTxHash),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// hashString returns the hex encoding of [hash], or an empty string if it is zero
+func hashString(hash common.Hash) string {
+	if hash == (common.Hash{}) {
+		return ""
+	}
+	return hash.Hex()
+}
@@ -0,0 +1,146 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAccountant(t *testing.T) *Accountant {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	accountant, err := NewAccountant(AccountantConfig{
+		Chains: []Chain{{
+			BlockchainID: testSourceID,
+			EVMChainID:   big.NewInt(1),
+			Client:       ethclient.NewClient(nil),
+		}},
+		Key: key,
+	})
+	require.NoError(t, err)
+	return accountant
+}
+
+func TestAccountantSharesRelayerNonces(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chain := Chain{
+		BlockchainID: testSourceID,
+		EVMChainID:   big.NewInt(1),
+		Client:       ethclient.NewClient(nil),
+	}
+	relayer, err := NewRelayer(Config{Key: key, Chains: []Chain{chain}}, nil)
+	require.NoError(t, err)
+
+	accountant, err := NewAccountant(AccountantConfig{Chains: []Chain{chain}, Key: key, Relayer: relayer})
+	require.NoError(t, err)
+	require.Same(t, relayer.chains[testSourceID].nonces, accountant.chains[testSourceID].nonces)
+
+	// A separate reward key has its own nonces
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	accountant, err = NewAccountant(AccountantConfig{Chains: []Chain{chain}, Key: otherKey, Relayer: relayer})
+	require.NoError(t, err)
+	require.NotSame(t, relayer.chains[testSourceID].nonces, accountant.chains[testSourceID].nonces)
+}
+
+func newTestDelivery(amount int64) *Delivery {
+	return &Delivery{
+		MessageID:               ids.GenerateTestID(),
+		SourceBlockchainID:      testSourceID,
+		DestinationBlockchainID: testDestinationID,
+		FeeInfo: teleportermessenger.TeleporterFeeInfo{
+			FeeTokenAddress: testFeeToken,
+			Amount:          big.NewInt(amount),
+		},
+		Receipt: &types.Receipt{TxHash: common.HexToHash("0x1")},
+	}
+}
+
+func newTestReceipt(
+	messageID ids.ID,
+	amount int64,
+	blockNumber uint64,
+) *teleportermessenger.TeleporterMessengerReceiptReceived {
+	return &teleportermessenger.TeleporterMessengerReceiptReceived{
+		MessageID:               messageID,
+		DestinationBlockchainID: testDestinationID,
+		FeeInfo: teleportermessenger.TeleporterFeeInfo{
+			FeeTokenAddress: testFeeToken,
+			Amount:          big.NewInt(amount),
+		},
+		Raw: types.Log{BlockNumber: blockNumber, TxHash: common.HexToHash("0x2")},
+	}
+}
+
+func TestAccountantLedger(t *testing.T) {
+	accountant := newTestAccountant(t)
+	feeToken := ChainAddress{BlockchainID: testSourceID, Address: testFeeToken}
+
+	first := newTestDelivery(10)
+	second := newTestDelivery(20)
+	accountant.RecordDelivery(first)
+	accountant.RecordDelivery(second)
+	balance := accountant.Balances()[feeToken]
+	require.Equal(t, big.NewInt(30), balance.Pending)
+	require.Zero(t, balance.Redeemable.Sign())
+
+	// The fee of the first message was increased after it was delivered
+	accountant.recordReceipt(testSourceID, newTestReceipt(first.MessageID, 15, 5))
+	// Receipts of messages that weren't recorded are still redeemable
+	unknownID := ids.GenerateTestID()
+	accountant.recordReceipt(testSourceID, newTestReceipt(unknownID, 1, 7))
+	// Receipts are only accounted for once
+	accountant.recordReceipt(testSourceID, newTestReceipt(first.MessageID, 100, 8))
+	balance = accountant.Balances()[feeToken]
+	require.Equal(t, big.NewInt(20), balance.Pending)
+	require.Equal(t, big.NewInt(16), balance.Redeemable)
+
+	// Only the rewards that were redeemable before the redemption are redeemed
+	accountant.recordRedemption(testSourceID, &teleportermessenger.TeleporterMessengerRelayerRewardsRedeemed{
+		Asset: testFeeToken,
+		Raw:   types.Log{BlockNumber: 6, TxHash: common.HexToHash("0x3")},
+	})
+	ledger := accountant.Ledger()
+	require.Len(t, ledger, 3)
+	require.Equal(t, first.MessageID, ledger[0].MessageID)
+	require.Equal(t, RewardRedeemed, ledger[0].Status)
+	require.Equal(t, common.HexToHash("0x3"), ledger[0].RedemptionTxHash)
+	require.Equal(t, RewardPending, ledger[1].Status)
+	require.Equal(t, unknownID, ledger[2].MessageID)
+	require.Equal(t, RewardRedeemable, ledger[2].Status)
+	require.Equal(t, testDestinationID, ledger[2].DestinationBlockchainID)
+	require.Equal(t, []common.Address{testFeeToken}, accountant.feeTokens(testSourceID))
+
+	var buf bytes.Buffer
+	require.NoError(t, accountant.WriteLedgerCSV(&buf))
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	require.Equal(t, ledgerCSVHeader, records[0])
+	require.Equal(t, []string{
+		first.MessageID.String(),
+		testSourceID.String(),
+		testDestinationID.String(),
+		testFeeToken.Hex(),
+		"15",
+		"redeemed",
+		common.HexToHash("0x1").Hex(),
+		common.HexToHash("0x2").Hex(),
+		common.HexToHash("0x3").Hex(),
+	}, records[1])
+	// Hashes that aren't known are left empty
+	require.Empty(t, records[3][6])
+}
@@ -0,0 +1,258 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MaxMessageIDScan bounds the number of message IDs computed by a single reverse lookup
+const MaxMessageIDScan = uint64(1) << 24
+
+var (
+	ErrInvalidNonce       = errors.New("nonce must be a non-negative 256 bit integer")
+	ErrInvalidNonceRange  = errors.New("invalid nonce range")
+	ErrMessageIDNotFound  = errors.New("message ID not found")
+	ErrZeroBlockchainID   = errors.New("TeleporterMessenger blockchain ID is not initialized")
+	ErrNonceRangeTooLarge = fmt.Errorf("nonce range exceeds %d message IDs", MaxMessageIDScan)
+	ErrNonceOverflow      = errors.New("message nonce overflows uint64")
+)
+
+// MessageIDCalculator computes the IDs of messages sent from one TeleporterMessenger to a single
+// destination. The ABI encoding of the message ID preimage is built once, and each ID only
+// overwrites the nonce and rehashes, so computing thousands of IDs does not allocate per ID.
+// A MessageIDCalculator is not safe for concurrent use.
+type MessageIDCalculator struct {
+	// abi.encode(teleporterMessengerAddress, sourceBlockchainID, destinationBlockchainID, nonce)
+	preimage [4 * common.HashLength]byte
+	hasher   crypto.KeccakState
+}
+
+// NewMessageIDCalculator returns a calculator for messages from [sourceBlockchainID] to [destinationBlockchainID]
+func NewMessageIDCalculator(
+	teleporterMessengerAddress common.Address,
+	sourceBlockchainID ids.ID,
+	destinationBlockchainID ids.ID,
+) *MessageIDCalculator {
+	c := &MessageIDCalculator{hasher: crypto.NewKeccakState()}
+	copy(c.preimage[common.HashLength-common.AddressLength:common.HashLength], teleporterMessengerAddress[:])
+	copy(c.preimage[common.HashLength:2*common.HashLength], sourceBlockchainID[:])
+	copy(c.preimage[2*common.HashLength:3*common.HashLength], destinationBlockchainID[:])
+	return c
+}
+
+// MessageID returns the ID of the message with [nonce], matching CalculateMessageID
+func (c *MessageIDCalculator) MessageID(nonce *big.Int) (ids.ID, error) {
+	if nonce == nil || nonce.Sign() < 0 || nonce.BitLen() > 256 {
+		return ids.ID{}, ErrInvalidNonce
+	}
+	nonce.FillBytes(c.preimage[3*common.HashLength:])
+	return c.hash(), nil
+}
+
+// MessageIDAtNonce returns the ID of the message with [nonce]
+func (c *MessageIDCalculator) MessageIDAtNonce(nonce uint64) ids.ID {
+	c.setNonce(nonce)
+	return c.hash()
+}
+
+// MessageIDs returns the IDs of the [count] messages starting at [firstNonce]
+func (c *MessageIDCalculator) MessageIDs(firstNonce uint64, count uint64) ([]ids.ID, error) {
+	if count > 0 && firstNonce+count-1 < firstNonce {
+		return nil, ErrNonceOverflow
+	}
+	messageIDs := make([]ids.ID, count)
+	for i := range messageIDs {
+		messageIDs[i] = c.MessageIDAtNonce(firstNonce + uint64(i))
+	}
+	return messageIDs, nil
+}
+
+func (c *MessageIDCalculator) setNonce(nonce uint64) {
+	noncePos := 3 * common.HashLength
+	clear(c.preimage[noncePos : len(c.preimage)-8])
+	binary.BigEndian.PutUint64(c.preimage[len(c.preimage)-8:], nonce)
+}
+
+func (c *MessageIDCalculator) hash() ids.ID {
+	var id ids.ID
+	c.hasher.Reset()
+	c.hasher.Write(c.preimage[:])
+	c.hasher.Read(id[:])
+	return id
+}
+
+// TeleporterMessengerNonceReader is implemented by the TeleporterMessenger contract bindings
+type TeleporterMessengerNonceReader interface {
+	BlockchainID(opts *bind.CallOpts) ([32]byte, error)
+	MessageNonce(opts *bind.CallOpts) (*big.Int, error)
+	GetNextMessageID(opts *bind.CallOpts, destinationBlockchainID [32]byte) ([32]byte, error)
+}
+
+// NextMessageID returns the ID the next message sent to [destinationBlockchainID] will have,
+// as reported by the contract's getNextMessageID.
+func NextMessageID(
+	opts *bind.CallOpts,
+	messenger TeleporterMessengerNonceReader,
+	destinationBlockchainID ids.ID,
+) (ids.ID, error) {
+	messageID, err := messenger.GetNextMessageID(opts, destinationBlockchainID)
+	if err != nil {
+		return ids.ID{}, err
+	}
+	return ids.ID(messageID), nil
+}
+
+// PredictMessageIDs computes the IDs of the next [count] messages sent from [messenger] to
+// [destinationBlockchainID], from the contract's messageNonce. Since the nonce is shared by all
+// destinations, the predictions only hold if no other message is sent in between.
+func PredictMessageIDs(
+	opts *bind.CallOpts,
+	teleporterMessengerAddress common.Address,
+	messenger TeleporterMessengerNonceReader,
+	destinationBlockchainID ids.ID,
+	count uint64,
+) ([]ids.ID, error) {
+	blockchainID, err := messenger.BlockchainID(opts)
+	if err != nil {
+		return nil, err
+	}
+	if blockchainID == (ids.ID{}) {
+		return nil, ErrZeroBlockchainID
+	}
+	messageNonce, err := messenger.MessageNonce(opts)
+	if err != nil {
+		return nil, err
+	}
+	if !messageNonce.IsUint64() || messageNonce.Uint64() == ^uint64(0) {
+		return nil, ErrNonceOverflow
+	}
+	calculator := NewMessageIDCalculator(teleporterMessengerAddress, blockchainID, destinationBlockchainID)
+	return calculator.MessageIDs(messageNonce.Uint64()+1, count)
+}
+
+// MessageIDPreimage is the source, destination and nonce a message ID was computed from
+type MessageIDPreimage struct {
+	SourceBlockchainID      ids.ID
+	DestinationBlockchainID ids.ID
+	Nonce                   uint64
+}
+
+// BlockchainPair is a source and destination messages may be sent between
+type BlockchainPair struct {
+	SourceBlockchainID      ids.ID
+	DestinationBlockchainID ids.ID
+}
+
+// FindMessageIDPreimage maps [messageID] back to its source, destination and nonce by computing
+// the IDs of each pair in [pairs] for nonces [firstNonce, lastNonce]. Message IDs can't be
+// inverted, so the range must be bounded; at most MaxMessageIDScan IDs are computed.
+func FindMessageIDPreimage(
+	teleporterMessengerAddress common.Address,
+	messageID ids.ID,
+	pairs []BlockchainPair,
+	firstNonce uint64,
+	lastNonce uint64,
+) (MessageIDPreimage, error) {
+	if _, err := messageIDScanSize(pairs, firstNonce, lastNonce); err != nil {
+		return MessageIDPreimage{}, err
+	}
+	var (
+		preimage MessageIDPreimage
+		found    bool
+	)
+	scanMessageIDs(
+		teleporterMessengerAddress,
+		pairs,
+		firstNonce,
+		lastNonce,
+		func(id ids.ID, p MessageIDPreimage) bool {
+			if id == messageID {
+				preimage, found = p, true
+			}
+			return !found
+		},
+	)
+	if !found {
+		return MessageIDPreimage{}, fmt.Errorf("%w: %s", ErrMessageIDNotFound, messageID)
+	}
+	return preimage, nil
+}
+
+// IndexMessageIDs computes the IDs of each pair in [pairs] for nonces [firstNonce, lastNonce],
+// so that many message IDs can be mapped back to their preimages.
+func IndexMessageIDs(
+	teleporterMessengerAddress common.Address,
+	pairs []BlockchainPair,
+	firstNonce uint64,
+	lastNonce uint64,
+) (map[ids.ID]MessageIDPreimage, error) {
+	count, err := messageIDScanSize(pairs, firstNonce, lastNonce)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[ids.ID]MessageIDPreimage, count)
+	scanMessageIDs(
+		teleporterMessengerAddress,
+		pairs,
+		firstNonce,
+		lastNonce,
+		func(id ids.ID, p MessageIDPreimage) bool {
+			index[id] = p
+			return true
+		},
+	)
+	return index, nil
+}
+
+// messageIDScanSize returns the number of message IDs in the range, at most MaxMessageIDScan
+func messageIDScanSize(pairs []BlockchainPair, firstNonce uint64, lastNonce uint64) (uint64, error) {
+	if lastNonce < firstNonce {
+		return 0, fmt.Errorf("%w: first nonce %d is after last nonce %d", ErrInvalidNonceRange, firstNonce, lastNonce)
+	}
+	nonces := lastNonce - firstNonce + 1
+	if nonces == 0 || nonces > MaxMessageIDScan || uint64(len(pairs)) > MaxMessageIDScan/nonces {
+		return 0, ErrNonceRangeTooLarge
+	}
+	return uint64(len(pairs)) * nonces, nil
+}
+
+// scanMessageIDs calls [visit] with each message ID in a range checked by messageIDScanSize,
+// until it returns false
+func scanMessageIDs(
+	teleporterMessengerAddress common.Address,
+	pairs []BlockchainPair,
+	firstNonce uint64,
+	lastNonce uint64,
+	visit func(ids.ID, MessageIDPreimage) bool,
+) {
+	for _, pair := range pairs {
+		calculator := NewMessageIDCalculator(
+			teleporterMessengerAddress,
+			pair.SourceBlockchainID,
+			pair.DestinationBlockchainID,
+		)
+		preimage := MessageIDPreimage{
+			SourceBlockchainID:      pair.SourceBlockchainID,
+			DestinationBlockchainID: pair.DestinationBlockchainID,
+		}
+		for nonce := firstNonce; ; nonce++ {
+			preimage.Nonce = nonce
+			if !visit(calculator.MessageIDAtNonce(nonce), preimage) {
+				return
+			}
+			if nonce == lastNonce {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/stretchr/testify/require"
+)
+
+type mockNonceReader struct {
+	blockchainID ids.ID
+	messageNonce *big.Int
+}
+
+func (m *mockNonceReader) BlockchainID(*bind.CallOpts) ([32]byte, error) {
+	return m.blockchainID, nil
+}
+
+func (m *mockNonceReader) MessageNonce(*bind.CallOpts) (*big.Int, error) {
+	return m.messageNonce, nil
+}
+
+// GetNextMessageID mirrors the contract, which uses the next value of the shared nonce
+func (m *mockNonceReader) GetNextMessageID(_ *bind.CallOpts, destinationBlockchainID [32]byte) ([32]byte, error) {
+	return CalculateMessageID(
+		teleporterMessengerAddress,
+		m.blockchainID,
+		destinationBlockchainID,
+		new(big.Int).Add(m.messageNonce, big.NewInt(1)),
+	)
+}
+
+func TestMessageIDCalculator(t *testing.T) {
+	sourceBlockchainID := ids.GenerateTestID()
+	destinationBlockchainID := ids.GenerateTestID()
+	calculator := NewMessageIDCalculator(teleporterMessengerAddress, sourceBlockchainID, destinationBlockchainID)
+
+	// Large nonces leave bytes in the buffer that smaller nonces must clear.
+	nonces := []*big.Int{
+		new(big.Int).Lsh(big.NewInt(1), 255),
+		big.NewInt(1),
+		new(big.Int).SetUint64(math.MaxUint64),
+		big.NewInt(0),
+	}
+	for _, nonce := range nonces {
+		expected, err := CalculateMessageID(teleporterMessengerAddress, sourceBlockchainID, destinationBlockchainID, nonce)
+		require.NoError(t, err)
+		messageID, err := calculator.MessageID(nonce)
+		require.NoError(t, err)
+		require.Equal(t, expected, messageID)
+		if nonce.IsUint64() {
+			require.Equal(t, expected, calculator.MessageIDAtNonce(nonce.Uint64()))
+		}
+	}
+
+	_, err := calculator.MessageID(big.NewInt(-1))
+	require.ErrorIs(t, err, ErrInvalidNonce)
+	_, err = calculator.MessageID(new(big.Int).Lsh(big.NewInt(1), 256))
+	require.ErrorIs(t, err, ErrInvalidNonce)
+
+	messageIDs, err := calculator.MessageIDs(10, 1000)
+	require.NoError(t, err)
+	require.Len(t, messageIDs, 1000)
+	for i, messageID := range messageIDs {
+		expected, err := CalculateMessageID(
+			teleporterMessengerAddress,
+			sourceBlockchainID,
+			destinationBlockchainID,
+			big.NewInt(int64(10+i)),
+		)
+		require.NoError(t, err)
+		require.Equal(t, expected, messageID)
+	}
+
+	_, err = calculator.MessageIDs(math.MaxUint64, 2)
+	require.ErrorIs(t, err, ErrNonceOverflow)
+}
+
+func TestPredictMessageIDs(t *testing.T) {
+	destinationBlockchainID := ids.GenerateTestID()
+	messenger := &mockNonceReader{
+		blockchainID: ids.GenerateTestID(),
+		messageNonce: big.NewInt(41),
+	}
+
+	nextMessageID, err := NextMessageID(&bind.CallOpts{}, messenger, destinationBlockchainID)
+	require.NoError(t, err)
+	messageIDs, err := PredictMessageIDs(
+		&bind.CallOpts{},
+		teleporterMessengerAddress,
+		messenger,
+		destinationBlockchainID,
+		3,
+	)
+	require.NoError(t, err)
+	require.Len(t, messageIDs, 3)
+	require.Equal(t, nextMessageID, messageIDs[0])
+
+	// Sending a message advances the nonce to the second prediction
+	messenger.messageNonce = big.NewInt(42)
+	nextMessageID, err = NextMessageID(&bind.CallOpts{}, messenger, destinationBlockchainID)
+	require.NoError(t, err)
+	require.Equal(t, nextMessageID, messageIDs[1])
+
+	messenger.blockchainID = ids.Empty
+	_, err = PredictMessageIDs(&bind.CallOpts{}, teleporterMessengerAddress, messenger, destinationBlockchainID, 1)
+	require.ErrorIs(t, err, ErrZeroBlockchainID)
+}
+
+func TestFindMessageIDPreimage(t *testing.T) {
+	chainA := ids.GenerateTestID()
+	chainB := ids.GenerateTestID()
+	pairs := []BlockchainPair{
+		{SourceBlockchainID: chainA, DestinationBlockchainID: chainB},
+		{SourceBlockchainID: chainB, DestinationBlockchainID: chainA},
+	}
+	messageID, err := CalculateMessageID(teleporterMessengerAddress, chainB, chainA, big.NewInt(77))
+	require.NoError(t, err)
+
+	preimage, err := FindMessageIDPreimage(teleporterMessengerAddress, messageID, pairs, 1, 100)
+	require.NoError(t, err)
+	require.Equal(t, MessageIDPreimage{SourceBlockchainID: chainB, DestinationBlockchainID: chainA, Nonce: 77}, preimage)
+
+	_, err = FindMessageIDPreimage(teleporterMessengerAddress, messageID, pairs, 1, 76)
+	require.ErrorIs(t, err, ErrMessageIDNotFound)
+	_, err = FindMessageIDPreimage(teleporterMessengerAddress, messageID, pairs, 10, 9)
+	require.ErrorIs(t, err, ErrInvalidNonceRange)
+	_, err = FindMessageIDPreimage(teleporterMessengerAddress, messageID, pairs, 0, MaxMessageIDScan-1)
+	require.ErrorIs(t, err, ErrNonceRangeTooLarge)
+	_, err = FindMessageIDPreimage(teleporterMessengerAddress, messageID, pairs, 0, math.MaxUint64)
+	require.ErrorIs(t, err, ErrNonceRangeTooLarge)
+
+	index, err := IndexMessageIDs(teleporterMessengerAddress, pairs, 1, 100)
+	require.NoError(t, err)
+	require.Len(t, index, 200)
+	require.Equal(t, preimage, index[messageID])
+}